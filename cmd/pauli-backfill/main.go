@@ -33,6 +33,9 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to load configuration")
 	}
+	if cfg.OutputJSONL {
+		logsetup.SetupOutput(*debug, os.Stderr)
+	}
 	cfg.Backfill.Enabled = true
 
 	opts := backfill.Options{OneShot: true}
@@ -80,7 +83,7 @@ func main() {
 
 	execClient := execution.NewClient(cfg)
 	noopEnqueue := func(context.Context, steps.Job) error { return nil }
-	backfillR := backfill.New(cfg.Backfill, opts, beaconClient, execClient, repo, beaconClient.GetHeadSlot, log.Logger, noopEnqueue)
+	backfillR := backfill.New(network, cfg.Backfill, opts, beaconClient, execClient, repo, beaconClient.GetHeadSlot, log.Logger, noopEnqueue)
 
 	log.Info().Msg("pauli-backfill running (one-shot); Ctrl+C to cancel")
 	backfillR.Start(ctx)
@@ -0,0 +1,246 @@
+// Command pauli-bench drives the block and epoch indexing pipeline against a synthetic beacon node and
+// reports throughput, job latency and write rate, to measure performance changes reproducibly.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/logsetup"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+	"github.com/tharun/pauli/internal/store"
+)
+
+func main() {
+	validators := flag.Uint64("validators", 10000, "Number of synthetic validators")
+	slots := flag.Uint64("slots", 100, "Number of slots to index (one block job per slot, one epoch job per epoch boundary)")
+	workers := flag.Int("workers", 10, "Concurrent indexing jobs (cf. worker_pool_size)")
+	startEpoch := flag.Uint64("start-epoch", 100, "First epoch of the synthetic range")
+	configPath := flag.String("config", "", "Write to the database in this configuration file (default: in-memory, nothing stored)")
+	debug := flag.Bool("debug", false, "Verbose debug logging")
+	flag.Parse()
+
+	logsetup.SetupOutput(*debug, os.Stderr)
+
+	if *validators == 0 || *slots == 0 || *workers <= 0 {
+		log.Fatal().Msg("-validators, -slots and -workers must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	var repo storage.Repository = noop.NewRepository()
+	cfg := &config.Config{}
+	if *configPath != "" {
+		loaded, err := config.Load(*configPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load configuration")
+		}
+		cfg = loaded
+		dbStore, err := store.NewStore(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to initialize database store")
+		}
+		defer dbStore.Close()
+		if err := dbStore.RunMigrations(); err != nil {
+			log.Fatal().Err(err).Msg("failed to run database migrations")
+		}
+		repo = dbStore.Repository()
+		log.Warn().Str("database", cfg.Postgres.Database).Msg("pauli-bench writes synthetic rows to the configured database")
+	}
+
+	server, err := newSyntheticBeacon(*validators)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to generate synthetic beacon data")
+	}
+	srv := server.start()
+	defer srv.Close()
+
+	// The synthetic node must never be the bottleneck, whatever the configuration's rate limits.
+	cfg.BeaconNodeURL = srv.URL
+	cfg.BeaconAPIKey = ""
+	cfg.BeaconAPI = config.BeaconAPIConf{}
+	cfg.RateLimit = config.RateLimitConf{RequestsPerSecond: 1e9, Burst: 1 << 30}
+	cfg.HTTP.TimeoutSeconds = 60
+	cfg.HTTP.MaxRetries = 0
+	if cfg.HTTP.MaxIdleConns < *workers {
+		cfg.HTTP.MaxIdleConns = *workers
+	}
+	client := beacon.NewClient(cfg)
+	defer client.Close()
+
+	counted := &countingRepository{Repository: repo}
+	b := &bench{
+		blocks: &indexing.BlockIndexer{Client: client, Repo: counted, Log: log.Logger},
+		epochs: &indexing.EpochIndexer{Client: client, Repo: counted, Log: log.Logger},
+	}
+
+	log.Info().
+		Uint64("validators", *validators).
+		Uint64("slots", *slots).
+		Int("workers", *workers).
+		Bool("database", *configPath != "").
+		Msg("pauli-bench started")
+
+	startSlot := *startEpoch * config.SlotsPerEpoch()
+	started := time.Now()
+	results := b.run(ctx, jobsFor(startSlot, *slots), *workers)
+	elapsed := time.Since(started)
+
+	printReport(os.Stdout, results, elapsed, counted, server.requests.Load())
+	if ctx.Err() != nil {
+		os.Exit(1)
+	}
+}
+
+// job is one unit of pipeline work: a block at slot, or the epoch starting at slot.
+type job struct {
+	kind string // "block" or "epoch"
+	slot uint64
+}
+
+// jobsFor lists a block job per slot and an epoch job per epoch boundary in [start, start+n).
+func jobsFor(start, n uint64) []job {
+	var jobs []job
+	for slot := start; slot < start+n; slot++ {
+		if slot%config.SlotsPerEpoch() == 0 {
+			jobs = append(jobs, job{kind: "epoch", slot: slot})
+		}
+		jobs = append(jobs, job{kind: "block", slot: slot})
+	}
+	return jobs
+}
+
+type bench struct {
+	blocks *indexing.BlockIndexer
+	epochs *indexing.EpochIndexer
+}
+
+type result struct {
+	kind    string
+	latency time.Duration
+	err     error
+}
+
+// run executes jobs on workers goroutines and returns one result per started job.
+func (b *bench) run(ctx context.Context, jobs []job, workers int) []result {
+	queue := make(chan job)
+	out := make(chan result, len(jobs))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				t0 := time.Now()
+				err := b.do(ctx, j)
+				out <- result{kind: j.kind, latency: time.Since(t0), err: err}
+			}
+		}()
+	}
+feed:
+	for _, j := range jobs {
+		select {
+		case queue <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+	close(out)
+
+	results := make([]result, 0, len(jobs))
+	for r := range out {
+		results = append(results, r)
+	}
+	return results
+}
+
+func (b *bench) do(ctx context.Context, j job) error {
+	if j.kind == "epoch" {
+		return indexing.IndexEpochAtBoundary(ctx, b.epochs, j.slot/config.SlotsPerEpoch())
+	}
+	return indexing.IndexBlockAtSlot(ctx, b.blocks, j.slot)
+}
+
+// countingRepository counts rows and time spent in the writes the benchmarked pipeline makes.
+type countingRepository struct {
+	storage.Repository
+	rows      atomic.Int64
+	writeNano atomic.Int64
+}
+
+func (r *countingRepository) SaveValidatorEpochRecords(ctx context.Context, records []*storage.ValidatorEpochRecord) error {
+	defer r.observe(time.Now(), len(records))
+	return r.Repository.SaveValidatorEpochRecords(ctx, records)
+}
+
+func (r *countingRepository) SaveBlock(ctx context.Context, block *storage.Block) error {
+	defer r.observe(time.Now(), 1)
+	return r.Repository.SaveBlock(ctx, block)
+}
+
+func (r *countingRepository) observe(start time.Time, rows int) {
+	r.rows.Add(int64(rows))
+	r.writeNano.Add(int64(time.Since(start)))
+}
+
+func printReport(w *os.File, results []result, elapsed time.Duration, repo *countingRepository, requests int64) {
+	byKind := map[string][]time.Duration{}
+	var failed int
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			log.Error().Err(r.err).Str("job", r.kind).Msg("bench job failed")
+			continue
+		}
+		byKind[r.kind] = append(byKind[r.kind], r.latency)
+	}
+
+	secs := elapsed.Seconds()
+	_, _ = fmt.Fprintf(w, "elapsed           %s\n", elapsed.Round(time.Millisecond))
+	_, _ = fmt.Fprintf(w, "jobs              %d (%d failed), %.1f jobs/s\n", len(results), failed, float64(len(results))/secs)
+	for _, kind := range []string{"block", "epoch"} {
+		lat := byKind[kind]
+		if len(lat) == 0 {
+			continue
+		}
+		slices.Sort(lat)
+		_, _ = fmt.Fprintf(w, "%-17s n=%d p50=%s p99=%s max=%s\n", kind+" latency", len(lat),
+			percentile(lat, 50), percentile(lat, 99), lat[len(lat)-1].Round(time.Microsecond))
+	}
+	rows := repo.rows.Load()
+	_, _ = fmt.Fprintf(w, "rows written      %d, %.0f rows/s (%s in writes)\n", rows, float64(rows)/secs,
+		time.Duration(repo.writeNano.Load()).Round(time.Millisecond))
+	_, _ = fmt.Fprintf(w, "beacon requests   %d, %.1f req/s\n", requests, float64(requests)/secs)
+}
+
+// percentile returns the p-th percentile (nearest rank) of sorted.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i].Round(time.Microsecond)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tharun/pauli/internal/beacon"
+)
+
+// syncCommitteeSize is the mainnet sync committee size, the row count of every sync rewards response.
+const syncCommitteeSize = 512
+
+// syntheticBeacon serves generated Beacon API responses for a network of n validators: every slot
+// has a block, every validator is active and earns the same rewards. Epoch-wide bodies are rendered
+// once so the server's own cost stays small next to the client's decoding and the writes.
+type syntheticBeacon struct {
+	n        uint64
+	requests atomic.Int64
+
+	vals       []beacon.Validator
+	validators []byte
+	rewards    []byte
+}
+
+func newSyntheticBeacon(n uint64) (*syntheticBeacon, error) {
+	vals := make([]beacon.Validator, n)
+	s := &syntheticBeacon{n: n, vals: vals}
+	rewards := make([]beacon.AttestationReward, n)
+	for i := range vals {
+		v := &vals[i]
+		v.Index = beacon.Uint64Str(i)
+		v.Balance = 32_001_000_000
+		v.Status = "active_ongoing"
+		v.Validator.Pubkey = fmt.Sprintf("0x%096x", i)
+		v.Validator.EffectiveBalance = 32_000_000_000
+		v.Validator.ExitEpoch = beacon.Uint64Str(beacon.FarFutureEpoch)
+		v.Validator.WithdrawableEpoch = beacon.Uint64Str(beacon.FarFutureEpoch)
+		rewards[i] = beacon.AttestationReward{ValidatorIndex: beacon.Uint64Str(i), Head: 2800, Target: 5300, Source: 2900}
+	}
+	var err error
+	if s.validators, err = json.Marshal(beacon.ValidatorsResponse{Data: vals}); err != nil {
+		return nil, err
+	}
+	if s.rewards, err = json.Marshal(beacon.AttestationRewardsResponse{Data: beacon.AttestationRewardsData{TotalRewards: rewards}}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// start serves s on a local listener; the caller closes the returned server.
+func (s *syntheticBeacon) start() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+}
+
+func (s *syntheticBeacon) proposer(slot uint64) uint64 { return slot % s.n }
+
+func (s *syntheticBeacon) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	w.Header().Set("Content-Type", "application/json")
+	path := r.URL.Path
+	last := path[strings.LastIndex(path, "/")+1:]
+
+	switch {
+	case strings.HasPrefix(path, "/eth/v1/beacon/states/") && strings.HasSuffix(path, "/validators"):
+		if ids := r.URL.Query().Get("id"); ids != "" {
+			s.writeValidators(w, strings.Split(ids, ","))
+			return
+		}
+		_, _ = w.Write(s.validators)
+	case strings.HasPrefix(path, "/eth/v1/beacon/rewards/attestations/"):
+		_, _ = w.Write(s.rewards)
+	case strings.HasPrefix(path, "/eth/v1/beacon/headers/"):
+		slot, ok := parseSlot(w, last)
+		if !ok {
+			return
+		}
+		var resp beacon.BlockHeaderResponse
+		resp.Data.Root = fmt.Sprintf("0x%064x", slot)
+		resp.Data.Canonical = true
+		resp.Data.Header.Message = beacon.BeaconBlockHeader{Slot: beacon.Uint64Str(slot), ProposerIndex: beacon.Uint64Str(s.proposer(slot))}
+		writeJSON(w, resp)
+	case strings.HasPrefix(path, "/eth/v1/beacon/rewards/blocks/"):
+		slot, ok := parseSlot(w, last)
+		if !ok {
+			return
+		}
+		writeJSON(w, beacon.BlockRewardsResponse{Data: beacon.BlockRewardsData{
+			ProposerIndex: beacon.Uint64Str(s.proposer(slot)),
+			Total:         45_000_000,
+			Attestations:  40_000_000,
+			SyncAggregate: 5_000_000,
+		}})
+	case strings.HasPrefix(path, "/eth/v2/beacon/blocks/"):
+		slot, ok := parseSlot(w, last)
+		if !ok {
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data":{"message":{"body":{"execution_payload":{"block_number":"%d"}}}}}`, slot)
+	case strings.HasPrefix(path, "/eth/v1/beacon/rewards/sync_committee/"):
+		slot, ok := parseSlot(w, last)
+		if !ok {
+			return
+		}
+		rows := make([]beacon.SyncCommitteeRewardRow, syncCommitteeSize)
+		for i := range rows {
+			rows[i] = beacon.SyncCommitteeRewardRow{ValidatorIndex: beacon.Uint64Str((slot + uint64(i)) % s.n), Reward: 20_000}
+		}
+		writeJSON(w, beacon.SyncCommitteeRewardsResponse{Data: rows})
+	default:
+		http.Error(w, `{"code":404,"message":"NOT_FOUND: not served by pauli-bench"}`, http.StatusNotFound)
+	}
+}
+
+func (s *syntheticBeacon) writeValidators(w http.ResponseWriter, ids []string) {
+	out := make([]beacon.Validator, 0, len(ids))
+	for _, id := range ids {
+		i, err := strconv.ParseUint(id, 10, 64)
+		if err != nil || i >= s.n {
+			continue
+		}
+		out = append(out, s.vals[i])
+	}
+	writeJSON(w, beacon.ValidatorsResponse{Data: out})
+}
+
+func parseSlot(w http.ResponseWriter, s string) (uint64, bool) {
+	slot, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		http.Error(w, `{"code":400,"message":"pauli-bench serves numeric block ids only"}`, http.StatusBadRequest)
+		return 0, false
+	}
+	return slot, true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/logsetup"
+	"github.com/tharun/pauli/internal/monitor"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/store"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	epoch := flag.Uint64("epoch", ^uint64(0), "Epoch to fetch rewards for (required)")
+	all := flag.Bool("all", false, "Index every validator in the epoch instead of only the configured validators")
+	debug := flag.Bool("debug", false, "Verbose debug logging")
+	flag.Parse()
+
+	logsetup.Setup(*debug)
+
+	if *epoch == ^uint64(0) {
+		log.Fatal().Msg("-epoch is required")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load configuration")
+	}
+	if cfg.OutputJSONL {
+		logsetup.SetupOutput(*debug, os.Stderr)
+	}
+
+	validators := cfg.Validators
+	if *all {
+		validators = nil
+	} else if len(validators) == 0 {
+		log.Fatal().Msg("no validators configured; set validators in the config or pass -all")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	dbStore, err := store.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize database store")
+	}
+	defer dbStore.Close()
+
+	if err := dbStore.RunMigrations(); err != nil {
+		log.Fatal().Err(err).Msg("failed to run database migrations")
+	}
+
+	beaconClient := beacon.NewClient(cfg)
+	defer beaconClient.Close()
+
+	network := config.NewBlockchainNetwork(cfg)
+	if err := monitor.InitBeaconNetworkClock(ctx, beaconClient, network, log.Logger); err != nil {
+		log.Fatal().Err(err).Msg("beacon network init failed")
+	}
+
+	finalized, err := beaconClient.FinalizedEpoch(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read finalized epoch")
+	}
+	if *epoch > finalized {
+		log.Fatal().
+			Uint64("epoch", *epoch).
+			Uint64("finalized_epoch", finalized).
+			Msg("epoch is not finalized yet; rewards may still change")
+	}
+
+	n, err := indexing.IndexEpochRewards(ctx, &indexing.EpochIndexer{
+		Client:  beaconClient,
+		Repo:    dbStore.Repository(),
+		Network: network,
+		Log:     log.Logger,
+	}, *epoch, validators)
+	if err != nil {
+		log.Fatal().Err(err).Uint64("epoch", *epoch).Msg("fetch rewards failed")
+	}
+
+	log.Info().
+		Uint64("epoch", *epoch).
+		Int("validators", n).
+		Msg("pauli-fetch-rewards finished")
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/logsetup"
+	"github.com/tharun/pauli/internal/store"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	validator := flag.Uint64("validator", ^uint64(0), "Validator index whose data is deleted (required)")
+	yes := flag.Bool("yes", false, "Confirm the purge; without it only the plan is printed")
+	debug := flag.Bool("debug", false, "Verbose debug logging")
+	flag.Parse()
+
+	logsetup.Setup(*debug)
+
+	if *validator == ^uint64(0) {
+		log.Fatal().Msg("-validator is required")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load configuration")
+	}
+	if slices.Contains(cfg.Validators, *validator) {
+		log.Fatal().Uint64("validator_index", *validator).
+			Msg("validator is still in the configured validators; remove it first or the monitor will index it again")
+	}
+
+	if !*yes {
+		log.Warn().Uint64("validator_index", *validator).
+			Msg("this PERMANENTLY deletes the validator's duties, liveness, mismatches, epoch records and validator set events; " +
+				"there is no undo and epoch records are only restored by re-running backfill. Re-run with -yes to proceed")
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	dbStore, err := store.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize database store")
+	}
+	defer dbStore.Close()
+
+	deleted, err := dbStore.Repository().PurgeValidator(ctx, *validator)
+	if err != nil {
+		log.Fatal().Err(err).Msg("purge failed; nothing was deleted")
+	}
+	log.Info().
+		Uint64("validator_index", *validator).
+		Int64("rows_deleted", deleted).
+		Msg("validator data purged")
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/logsetup"
+	"github.com/tharun/pauli/internal/report"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/store"
+)
+
+// defaultEpochSpan is the report range when -from-epoch is not given (about one day).
+const defaultEpochSpan = 225
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	fromEpoch := flag.Uint64("from-epoch", ^uint64(0), "First epoch (default: to-epoch - 224)")
+	toEpoch := flag.Uint64("to-epoch", ^uint64(0), "Last epoch (default: highest indexed epoch)")
+	all := flag.Bool("all", false, "Report every validator instead of only the configured validators")
+	format := flag.String("format", report.FormatTable, "Output format: table or csv")
+	effectiveBalance := flag.Uint64("effective-balance", ^uint64(0), "Print this validator's effective balance per epoch instead of the rewards report")
+	fillGaps := flag.Bool("fill-gaps", false, "With -effective-balance, fetch epochs missing from the database from the beacon node")
+	tz := flag.String("tz", "UTC", "Timezone for displayed timestamps (tz database name, e.g. Europe/Berlin); storage stays UTC")
+	debug := flag.Bool("debug", false, "Verbose debug logging")
+	flag.Parse()
+
+	logsetup.SetupOutput(*debug, os.Stderr)
+
+	loc, err := report.LoadTimezone(*tz)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid -tz")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load configuration")
+	}
+	validators := cfg.Validators
+	if *all {
+		validators = nil
+	} else if len(validators) == 0 && *effectiveBalance == ^uint64(0) {
+		log.Fatal().Msg("no validators configured; set validators in the config or pass -all")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	dbStore, err := store.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize database store")
+	}
+	defer dbStore.Close()
+	repo := dbStore.Repository()
+
+	to := *toEpoch
+	if to == ^uint64(0) {
+		maxEpoch, ok, err := repo.MaxIndexedEpoch(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read highest indexed epoch")
+		}
+		if !ok {
+			log.Fatal().Msg("no indexed epochs; pass -to-epoch")
+		}
+		to = maxEpoch
+	}
+	from := *fromEpoch
+	if from == ^uint64(0) {
+		from = 0
+		if to >= defaultEpochSpan-1 {
+			from = to - (defaultEpochSpan - 1)
+		}
+	}
+	if from > to {
+		log.Fatal().Uint64("from_epoch", from).Uint64("to_epoch", to).Msg("-from-epoch is after -to-epoch")
+	}
+
+	if *effectiveBalance != ^uint64(0) {
+		writeEffectiveBalance(ctx, cfg, repo, *effectiveBalance, from, to, *fillGaps, *format, loc)
+		return
+	}
+
+	rows, err := report.LoadValidatorRewards(ctx, repo, validators, from, to)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load rewards")
+	}
+
+	var fiat *report.Fiat
+	if cfg.Report.PriceURL != "" {
+		src := report.NewPriceSource(cfg.Report.PriceURL, cfg.Report.PriceField, cfg.Report.PriceCacheTTL(), time.Duration(cfg.HTTP.TimeoutSeconds)*time.Second)
+		if price, err := src.Price(ctx); err != nil {
+			log.Warn().Err(err).Msg("price source unavailable; reporting ETH only")
+		} else {
+			fiat = &report.Fiat{Currency: cfg.Report.Currency, Price: price}
+		}
+	}
+
+	log.Info().
+		Uint64("from_epoch", from).
+		Uint64("to_epoch", to).
+		Int("validators", len(rows)).
+		Msg("pauli-report")
+	if err := report.Write(os.Stdout, rows, *format, fiat); err != nil {
+		log.Fatal().Err(err).Msg("failed to write report")
+	}
+}
+
+func writeEffectiveBalance(ctx context.Context, cfg *config.Config, repo storage.Repository, index, from, to uint64, fillGaps bool, format string, loc *time.Location) {
+	points, err := repo.GetEffectiveBalanceSeries(ctx, index, from, to)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load effective balance series")
+	}
+	if fillGaps {
+		filled, err := report.FillEffectiveBalanceGaps(ctx, beacon.NewClient(cfg), points)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to fetch historical validator state")
+		}
+		log.Info().Int("filled_epochs", filled).Msg("filled effective balance gaps from beacon node")
+	}
+	log.Info().
+		Uint64("validator_index", index).
+		Uint64("from_epoch", from).
+		Uint64("to_epoch", to).
+		Msg("pauli-report effective balance")
+	if err := report.WriteEffectiveBalance(os.Stdout, points, format, loc); err != nil {
+		log.Fatal().Err(err).Msg("failed to write report")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/tharun/pauli/internal/beacon"
 	"github.com/tharun/pauli/internal/config"
 	"github.com/tharun/pauli/internal/logsetup"
+	"github.com/tharun/pauli/internal/metrics"
 	"github.com/tharun/pauli/internal/monitor"
 	"github.com/tharun/pauli/internal/store"
 )
@@ -19,22 +21,31 @@ import (
 func main() {
 	configPath := flag.String("config", "config.yaml", "Path to configuration file")
 	debug := flag.Bool("debug", false, "Verbose debug logging (default: info/warn/error for operations)")
+	once := flag.Bool("once", false, "Run one indexing cycle (head block + latest finalized epoch), then exit")
+	beaconURL := flag.String("beacon-url", "", "Use this beacon node instead of the configuration file's beacon_node_url")
+	validators := flag.String("validators", "", "Comma-separated validator indices to watch instead of the configuration file's (validator_select is ignored)")
 	flag.Parse()
 
 	logsetup.Setup(*debug)
 
+	override := &overrides{beaconURL: *beaconURL}
+	if *validators != "" {
+		list, err := parseValidatorList(*validators)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid command-line flag")
+		}
+		override.validators = list
+	}
+
 	log.Debug().Str("config", *configPath).Msg("starting validator monitor")
 
-	cfg, err := config.Load(*configPath)
+	cfg, err := config.Load(*configPath, override.apply)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to load configuration")
 	}
-
-	log.Debug().
-		Str("beacon_url", cfg.BeaconNodeURL).
-		Int("validators", len(cfg.Validators)).
-		Int("worker_pool_size", cfg.WorkerPoolSize).
-		Msg("configuration loaded")
+	if cfg.OutputJSONL {
+		logsetup.SetupOutput(*debug, os.Stderr)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -103,24 +114,83 @@ func main() {
 		}
 	}
 
+	configured := append([]uint64(nil), cfg.Validators...)
+	selectFromCache, err := addSelectedValidators(ctx, beaconClient, cfg, true)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to resolve validator_select")
+	}
+	logEffectiveConfig(cfg)
+
 	mon := monitor.NewMonitor(cfg, beaconClient, repo, log.Logger)
 
+	if cfg.Metrics.Enabled && !cfg.Metrics.DisableScrape && !*once {
+		go func() {
+			if err := metrics.Serve(ctx, cfg.Metrics.Listen, log.Logger); err != nil {
+				log.Error().Err(err).Msg("metrics endpoint failed")
+			}
+		}()
+	}
+	if rw := cfg.Metrics.RemoteWrite; cfg.Metrics.Enabled && rw.URL != "" && !*once {
+		go metrics.NewRemoteWriter(metrics.RemoteWriteOptions{
+			URL:         rw.URL,
+			BearerToken: rw.BearerToken,
+			Username:    rw.Username,
+			Password:    rw.Password,
+			Headers:     rw.Headers,
+			Labels:      rw.Labels,
+			Interval:    time.Duration(rw.IntervalSeconds) * time.Second,
+			Timeout:     time.Duration(rw.TimeoutSeconds) * time.Second,
+		}, log.Logger).Run(ctx)
+	}
+
+	if *once {
+		go func() {
+			sig := <-sigChan
+			log.Info().Str("signal", sig.String()).Msg("one-shot cancelled")
+			cancel()
+		}()
+		if err := mon.RunOnce(ctx); err != nil {
+			log.Fatal().Err(err).Msg("one-shot cycle failed")
+		}
+		return
+	}
+
 	if err := mon.Start(ctx); err != nil {
 		log.Fatal().Err(err).Msg("failed to start monitor")
 	}
 
+	if selectFromCache {
+		go refreshSelectedValidators(ctx, beaconClient, cfg, configured, mon)
+	}
+
 	log.Info().
 		Str("beacon_url", cfg.BeaconNodeURL).
 		Int("validators", len(cfg.Validators)).
 		Str("database_driver", cfg.DatabaseDriver).
-		Msg("pauli running; Ctrl+C to stop (-debug for verbose logs)")
+		Msg("pauli running; Ctrl+C to stop, SIGHUP reloads validators and worker_pool_size (-debug for verbose logs)")
+
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go func() {
+		for range hupChan {
+			err := mon.ReloadConfig(ctx, *configPath, override.apply, func(next *config.Config) error {
+				_, err := addSelectedValidators(ctx, beaconClient, next, false)
+				return err
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("config reload failed; keeping the running configuration")
+				continue
+			}
+			log.Info().Msg("config reloaded")
+		}
+	}()
 
 	sig := <-sigChan
 	log.Info().Str("signal", sig.String()).Msg("shutdown initiated")
 
 	cancel()
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout())
 	defer shutdownCancel()
 
 	done := make(chan struct{})
@@ -133,6 +203,106 @@ func main() {
 	case <-done:
 		log.Info().Msg("shutdown complete")
 	case <-shutdownCtx.Done():
-		log.Warn().Msg("shutdown timed out")
+		queued, running := mon.PendingJobs()
+		log.Warn().
+			Dur("timeout", cfg.ShutdownTimeout()).
+			Int("jobs_queued", queued).
+			Int("jobs_running", running).
+			Msg("shutdown timed out; unfinished jobs are cancelled and their results not stored")
+	}
+}
+
+// addSelectedValidators resolves validator_select against the head state and adds the matches to cfg.Validators.
+// With validator_select.cache_file, resolved indices are cached on disk and a failed resolution falls back to
+// the cache; preferCache (startup) uses a cache hit without asking the node and reports fromCache, so the
+// caller refreshes it in the background.
+func addSelectedValidators(ctx context.Context, client *beacon.Client, cfg *config.Config, preferCache bool) (fromCache bool, err error) {
+	sel := cfg.ValidatorSelect
+	if !sel.Enabled() {
+		return false, nil
+	}
+	useCache := func(cause error) (bool, error) {
+		cached, resolvedAt, ok, err := monitor.LoadValidatorSelection(sel.CacheFile, sel)
+		if err != nil || !ok {
+			if err != nil {
+				log.Warn().Err(err).Str("cache_file", sel.CacheFile).Msg("validator_select cache unusable")
+			}
+			return false, cause
+		}
+		cfg.AddValidators(cached)
+		log.Info().
+			Int("selected", len(cached)).
+			Time("resolved_at", resolvedAt).
+			Int("validators", len(cfg.Validators)).
+			Msg("validator_select loaded from cache")
+		return true, nil
+	}
+	if preferCache && sel.CacheFile != "" {
+		if ok, _ := useCache(nil); ok {
+			return true, nil
+		}
+	}
+	selected, err := monitor.ResolveValidatorSelection(ctx, client, sel)
+	if err != nil {
+		if sel.CacheFile == "" {
+			return false, err
+		}
+		log.Warn().Err(err).Msg("validator_select resolution failed; trying cache")
+		return useCache(err)
+	}
+	saveSelectionCache(sel, selected)
+	cfg.AddValidators(selected)
+	log.Info().Int("selected", len(selected)).Int("validators", len(cfg.Validators)).Msg("validator_select resolved")
+	return false, nil
+}
+
+// refreshSelectedValidators resolves validator_select after a cached startup, updates the cache and reloads
+// the watched set (configured plus selected indices) if the selection changed.
+func refreshSelectedValidators(ctx context.Context, client *beacon.Client, cfg *config.Config, configured []uint64, mon *monitor.Monitor) {
+	selected, err := monitor.ResolveValidatorSelection(ctx, client, cfg.ValidatorSelect)
+	if err != nil {
+		log.Warn().Err(err).Msg("validator_select refresh failed; keeping cached selection until reload")
+		return
+	}
+	saveSelectionCache(cfg.ValidatorSelect, selected)
+	refreshed := &config.Config{Validators: configured}
+	refreshed.AddValidators(selected)
+	if slices.Equal(refreshed.Validators, cfg.Validators) {
+		log.Debug().Int("selected", len(selected)).Msg("validator_select cache up to date")
+		return
+	}
+	log.Info().Int("selected", len(selected)).Int("validators", len(refreshed.Validators)).Msg("validator_select refreshed; cached selection was stale")
+	if err := mon.ReloadValidators(ctx, refreshed.Validators); err != nil {
+		log.Error().Err(err).Msg("validator set reload failed")
+	}
+}
+
+func saveSelectionCache(sel config.ValidatorSelectConf, selected []uint64) {
+	if sel.CacheFile == "" {
+		return
+	}
+	if err := monitor.SaveValidatorSelection(sel.CacheFile, sel, selected); err != nil {
+		log.Warn().Err(err).Str("cache_file", sel.CacheFile).Msg("failed to write validator_select cache")
+	}
+}
+
+// logEffectiveConfig logs the resolved configuration once at startup, secrets redacted, so the
+// settings actually in force (defaults, command-line overrides and validator_select matches included)
+// are on record.
+func logEffectiveConfig(cfg *config.Config) {
+	network := config.NewBlockchainNetwork(cfg)
+	ev := log.Info().
+		Int("validators", len(cfg.Validators)).
+		Str("network", cfg.Network).
+		Dur("slot_duration", cfg.SlotDuration()).
+		Dur("poll_interval", network.PollInterval()).
+		Int("worker_pool_size", cfg.WorkerPoolSize).
+		Str("database_driver", cfg.DatabaseDriver)
+	effective, err := cfg.Effective()
+	if err != nil {
+		ev = ev.AnErr("config_error", err)
+	} else {
+		ev = ev.Interface("config", effective)
 	}
+	ev.Msg("configuration loaded")
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tharun/pauli/internal/config"
+)
+
+// overrides are command-line values that replace the configuration file's, for one-off runs
+// against another node or validator set. They are applied again on every SIGHUP reload.
+type overrides struct {
+	beaconURL  string
+	validators []uint64
+}
+
+// parseValidatorList parses a comma-separated list of validator indices.
+func parseValidatorList(s string) ([]uint64, error) {
+	var out []uint64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("-validators: %q is not a validator index", part)
+		}
+		out = append(out, v)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("-validators: no validator indices in %q", s)
+	}
+	return out, nil
+}
+
+// apply replaces the overridden fields of cfg and logs each override, so it is clear the file's
+// value is not in effect. It runs as a config.Load override, before validation.
+func (o *overrides) apply(cfg *config.Config) {
+	if o.beaconURL != "" {
+		log.Warn().
+			Str("beacon_url", config.RedactURL(o.beaconURL)).
+			Str("config_beacon_url", config.RedactURL(cfg.BeaconNodeURL)).
+			Msg("beacon_node_url overridden by -beacon-url")
+		cfg.BeaconNodeURL = o.beaconURL
+	}
+	if o.validators != nil {
+		log.Warn().
+			Int("validators", len(o.validators)).
+			Int("config_validators", len(cfg.Validators)).
+			Bool("validator_ranges_ignored", len(cfg.ValidatorRanges) > 0).
+			Bool("validator_select_ignored", cfg.ValidatorSelect.Enabled()).
+			Msg("validators overridden by -validators")
+		cfg.Validators = append([]uint64(nil), o.validators...)
+		cfg.ValidatorRanges = nil
+		cfg.ValidatorSelect = config.ValidatorSelectConf{}
+	}
+}
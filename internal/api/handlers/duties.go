@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SlotAttestationDuties returns the watched validators' attester duties at one slot.
+func (a *API) SlotAttestationDuties(c *gin.Context) {
+	slot, err := parseUintPath(c, "slot")
+	if err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+	defer cancel()
+	duties, err := a.Store.Repository().GetDutiesForSlot(ctx, slot)
+	if err != nil {
+		writeInternal(c)
+		return
+	}
+	if duties == nil {
+		duties = []*storage.AttestationDuty{}
+	}
+	c.JSON(200, gin.H{"data": duties})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -88,3 +89,30 @@ func (a *API) CountSnapshots(c *gin.Context) {
 	}
 	c.JSON(200, gin.H{"count": n})
 }
+
+// EffectiveBalanceSeries returns one effective balance point per epoch for a validator; epochs that
+// were never indexed have source "missing". At most maxListLimit epochs per request.
+func (a *API) EffectiveBalanceSeries(c *gin.Context) {
+	idx, err := parseUintPath(c, "validatorIndex")
+	if err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+	from, to, err := parseEpochWindow(c)
+	if err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+	if to-from >= maxListLimit {
+		writeBadRequest(c, fmt.Sprintf("epoch range is limited to %d epochs", maxListLimit))
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+	defer cancel()
+	points, err := a.Store.Repository().GetEffectiveBalanceSeries(ctx, idx, from, to)
+	if err != nil {
+		writeInternal(c)
+		return
+	}
+	c.JSON(200, gin.H{"data": points})
+}
@@ -28,10 +28,12 @@ func NewRouter(store storage.Store) *gin.Engine {
 		v1.GET("/attestation-rewards", h.ListAttestationRewardsQuery)
 		v1.GET("/block-proposer-rewards", h.ListBlockProposerRewardsQuery)
 		v1.GET("/sync-committee-rewards", h.ListSyncCommitteeRewardsQuery)
+		v1.GET("/slots/:slot/attestation-duties", h.SlotAttestationDuties)
 
 		v1.GET("/validators/:validatorIndex/snapshots/latest", h.LatestSnapshot)
 		v1.GET("/validators/:validatorIndex/snapshots", h.ListSnapshots)
 		v1.GET("/validators/:validatorIndex/snapshots/count", h.CountSnapshots)
+		v1.GET("/validators/:validatorIndex/effective-balance", h.EffectiveBalanceSeries)
 
 		v1.GET("/validators/:validatorIndex/attestation-rewards", h.ListAttestationRewardsScoped)
 		v1.GET("/validators/:validatorIndex/block-proposer-rewards", h.ListBlockProposerRewardsScoped)
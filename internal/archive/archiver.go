@@ -0,0 +1,201 @@
+// Package archive copies aged validator epoch records to S3-compatible object storage, the cold tier
+// that keeps long-term history outside the TTL-bounded database (archive config block).
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ObjectStore receives archive objects; *S3Client implements it.
+type ObjectStore interface {
+	PutObject(ctx context.Context, key string, body []byte, contentType string) error
+}
+
+// Options configures an Archiver.
+type Options struct {
+	// Prefix is prepended to every object key (no leading or trailing slash needed).
+	Prefix string
+	// After is how old an epoch must be before it is archived.
+	After time.Duration
+	// Interval is how often the archive job runs.
+	Interval time.Duration
+}
+
+// Archiver writes the epoch records of the watched validators, once they are older than Options.After,
+// as gzip-compressed JSON lines partitioned by validator and month:
+//
+//	<prefix>/validator_epoch_records/validator=<index>/month=<YYYY-MM>/epochs-<from>-<to>.jsonl.gz
+//
+// Progress is each validator's last archived epoch in scheduler_state (kind archive_epoch:<index>), so
+// each epoch is uploaded once. Rows are not deleted from the database.
+type Archiver struct {
+	store      ObjectStore
+	repo       storage.Repository
+	network    *config.BlockchainNetwork
+	validators func() []uint64
+	opts       Options
+	log        zerolog.Logger
+	now        func() time.Time
+}
+
+// New creates an archiver; validators returns the indices to archive on each run. The network
+// genesis time must be set before Run.
+func New(store ObjectStore, repo storage.Repository, network *config.BlockchainNetwork, validators func() []uint64, opts Options, log zerolog.Logger) *Archiver {
+	return &Archiver{
+		store:      store,
+		repo:       repo,
+		network:    network,
+		validators: validators,
+		opts:       opts,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+// Run archives once at start and then every Options.Interval until ctx is cancelled.
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if objects, err := a.ArchiveOnce(ctx); err != nil {
+			if ctx.Err() == nil {
+				a.log.Warn().Err(err).Msg("archive: run failed; retrying next interval")
+			}
+		} else if objects > 0 {
+			a.log.Info().Int("objects", objects).Msg("archive: uploaded aged epoch records")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveOnce uploads every epoch that ended more than Options.After ago and is not archived yet, one
+// validator and calendar month at a time, and returns how many objects were written. Each validator
+// has its own cursor, so one added later is archived from its oldest record rather than from where the
+// others are. A cursor only advances past a month once its object is uploaded, so a failed run is
+// retried from that month.
+func (a *Archiver) ArchiveOnce(ctx context.Context) (int, error) {
+	if a.network.GenesisTime().IsZero() {
+		return 0, errors.New("archive: genesis time unknown")
+	}
+	ended := a.epochsEndedBy(a.now().Add(-a.opts.After))
+	if ended == 0 {
+		return 0, nil
+	}
+	last := ended - 1
+
+	objects := 0
+	for _, v := range a.validators() {
+		kind := storage.ArchiveEpochKind(v)
+		var from uint64
+		state, ok, err := a.repo.GetSchedulerState(ctx, kind)
+		if err != nil {
+			return objects, fmt.Errorf("archive: load validator %d cursor: %w", v, err)
+		}
+		if ok {
+			from = state.Position + 1
+		}
+		for from <= last {
+			to := min(last, a.monthLastEpoch(from))
+			written, err := a.archiveValidator(ctx, v, from, to)
+			if err != nil {
+				return objects, err
+			}
+			if written {
+				objects++
+			}
+			if err := a.repo.SaveSchedulerState(ctx, &storage.SchedulerState{
+				Kind:      kind,
+				Position:  to,
+				UpdatedAt: a.now().UTC(),
+			}); err != nil {
+				return objects, fmt.Errorf("archive: save validator %d cursor at epoch %d: %w", v, to, err)
+			}
+			from = to + 1
+		}
+	}
+	return objects, nil
+}
+
+// archiveValidator uploads one validator's records in from..to; written is false when there are none.
+func (a *Archiver) archiveValidator(ctx context.Context, validator, from, to uint64) (written bool, err error) {
+	records, err := a.repo.ListValidatorEpochRecords(ctx, validator, from, to)
+	if err != nil {
+		return false, fmt.Errorf("archive: list validator %d epochs %d-%d: %w", validator, from, to, err)
+	}
+	if len(records) == 0 {
+		return false, nil
+	}
+	body, err := encodeJSONLines(records)
+	if err != nil {
+		return false, fmt.Errorf("archive: encode validator %d epochs %d-%d: %w", validator, from, to, err)
+	}
+	key := a.key(validator, from, to)
+	if err := a.store.PutObject(ctx, key, body, "application/gzip"); err != nil {
+		return false, fmt.Errorf("archive: %w", err)
+	}
+	a.log.Debug().Str("key", key).Int("records", len(records)).Msg("archive: object uploaded")
+	return true, nil
+}
+
+func (a *Archiver) key(validator, from, to uint64) string {
+	return recordsKey(a.opts.Prefix, validator, a.epochStart(from).Format("2006-01"), from, to)
+}
+
+func (a *Archiver) epochDuration() time.Duration {
+	return time.Duration(a.network.SlotsPerEpoch()) * a.network.SlotDuration()
+}
+
+func (a *Archiver) epochStart(epoch uint64) time.Time {
+	return a.network.SlotTime(epoch * a.network.SlotsPerEpoch())
+}
+
+// epochsEndedBy returns how many epochs (from genesis) ended at or before t.
+func (a *Archiver) epochsEndedBy(t time.Time) uint64 {
+	since := t.Sub(a.network.GenesisTime())
+	if since <= 0 {
+		return 0
+	}
+	return uint64(since / a.epochDuration())
+}
+
+// monthLastEpoch returns the last epoch starting in the same UTC calendar month as epoch.
+func (a *Archiver) monthLastEpoch(epoch uint64) uint64 {
+	start := a.epochStart(epoch)
+	nextMonth := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	since := nextMonth.Sub(a.network.GenesisTime())
+	n := uint64(since / a.epochDuration())
+	if since%a.epochDuration() != 0 {
+		n++
+	}
+	return n - 1
+}
+
+// encodeJSONLines gzips one JSON object per line.
+func encodeJSONLines[T any](rows []T) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
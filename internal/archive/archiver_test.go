@@ -0,0 +1,115 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type memoryStore map[string][]byte
+
+func (m memoryStore) PutObject(_ context.Context, key string, body []byte, _ string) error {
+	m[key] = body
+	return nil
+}
+
+func (m memoryStore) ListObjects(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m memoryStore) GetObject(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m[key])), nil
+}
+
+type recordsRepo struct {
+	*noop.Repository
+	records []*storage.ValidatorEpochRecord
+}
+
+func (r *recordsRepo) ListValidatorEpochRecords(_ context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.ValidatorEpochRecord, error) {
+	var out []*storage.ValidatorEpochRecord
+	for _, rec := range r.records {
+		if rec.ValidatorIndex == validatorIndex && rec.Epoch >= fromEpoch && rec.Epoch <= toEpoch {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+func TestArchiver_ArchiveOnceByValidatorAndMonth(t *testing.T) {
+	// Epochs are 384s; genesis is an hour before February, so epochs 0-9 start in January.
+	network := config.NewBlockchainNetwork(&config.Config{SlotDurationSeconds: 12})
+	genesis := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	network.SetGenesisTime(genesis)
+	repo := &recordsRepo{Repository: noop.NewRepository()}
+	for epoch := uint64(0); epoch < 30; epoch++ {
+		repo.records = append(repo.records, &storage.ValidatorEpochRecord{ValidatorIndex: 5, Epoch: epoch})
+	}
+	store := memoryStore{}
+	a := New(store, repo, network, func() []uint64 { return []uint64{5, 6} }, Options{Prefix: "/cold/", After: time.Hour}, zerolog.Nop())
+	a.now = func() time.Time { return genesis.Add(20*384*time.Second + time.Hour) }
+
+	objects, err := a.ArchiveOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, objects, "validator 6 has no records")
+	require.Contains(t, store, "cold/validator_epoch_records/validator=5/month=2026-01/epochs-0-9.jsonl.gz")
+	require.Contains(t, store, "cold/validator_epoch_records/validator=5/month=2026-02/epochs-10-19.jsonl.gz")
+
+	zr, err := gzip.NewReader(bytes.NewReader(store["cold/validator_epoch_records/validator=5/month=2026-02/epochs-10-19.jsonl.gz"]))
+	require.NoError(t, err)
+	lines, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(lines)), "\n"), 10)
+
+	state, ok, err := repo.GetSchedulerState(context.Background(), storage.ArchiveEpochKind(5))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(19), state.Position)
+
+	objects, err = a.ArchiveOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, objects, "archived epochs are not uploaded again")
+}
+
+func TestArchiver_ArchiveOnceValidatorAddedLater(t *testing.T) {
+	network := config.NewBlockchainNetwork(&config.Config{SlotDurationSeconds: 12})
+	genesis := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	network.SetGenesisTime(genesis)
+	repo := &recordsRepo{Repository: noop.NewRepository()}
+	for epoch := uint64(0); epoch < 20; epoch++ {
+		repo.records = append(repo.records,
+			&storage.ValidatorEpochRecord{ValidatorIndex: 5, Epoch: epoch},
+			&storage.ValidatorEpochRecord{ValidatorIndex: 6, Epoch: epoch})
+	}
+	store := memoryStore{}
+	validators := []uint64{5}
+	a := New(store, repo, network, func() []uint64 { return validators }, Options{After: time.Hour}, zerolog.Nop())
+	a.now = func() time.Time { return genesis.Add(20*384*time.Second + time.Hour) }
+
+	objects, err := a.ArchiveOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, objects)
+
+	validators = []uint64{5, 6}
+	objects, err = a.ArchiveOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, objects, "validator 6 is archived from its oldest record")
+	require.Contains(t, store, "validator_epoch_records/validator=6/month=2026-01/epochs-0-9.jsonl.gz")
+	require.Contains(t, store, "validator_epoch_records/validator=6/month=2026-02/epochs-10-19.jsonl.gz")
+}
@@ -0,0 +1,111 @@
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ObjectReader lists and opens archive objects; *S3Client implements it.
+type ObjectReader interface {
+	ListObjects(ctx context.Context, prefix string) ([]string, error)
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// recordsPrefix is the key prefix of one validator's archived epoch records.
+func recordsPrefix(prefix string, validator uint64) string {
+	p := fmt.Sprintf("validator_epoch_records/validator=%d/", validator)
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		p = prefix + "/" + p
+	}
+	return p
+}
+
+// recordsKey is the key of one validator's records for epochs from..to, in the month named by month.
+func recordsKey(prefix string, validator uint64, month string, from, to uint64) string {
+	return fmt.Sprintf("%smonth=%s/epochs-%d-%d.jsonl.gz", recordsPrefix(prefix, validator), month, from, to)
+}
+
+// keyEpochs parses the epoch range from a key written by recordsKey.
+func keyEpochs(key string) (from, to uint64, ok bool) {
+	n, err := fmt.Sscanf(path.Base(key), "epochs-%d-%d.jsonl.gz", &from, &to)
+	return from, to, err == nil && n == 2
+}
+
+// Reader reads archived epoch records back.
+type Reader struct {
+	objects ObjectReader
+	prefix  string
+}
+
+// NewReader reads the objects an Archiver with the same prefix wrote.
+func NewReader(objects ObjectReader, prefix string) *Reader {
+	return &Reader{objects: objects, prefix: prefix}
+}
+
+// Records returns the validator's archived records in fromEpoch..toEpoch for which keep returns true
+// (nil keeps all), oldest first. Objects are streamed and filtered line by line, so memory holds only
+// the matching rows.
+func (rd *Reader) Records(ctx context.Context, validator, fromEpoch, toEpoch uint64, keep func(*storage.ValidatorEpochRecord) bool) ([]*storage.ValidatorEpochRecord, error) {
+	keys, err := rd.objects.ListObjects(ctx, recordsPrefix(rd.prefix, validator))
+	if err != nil {
+		return nil, err
+	}
+	type object struct {
+		key      string
+		from, to uint64
+	}
+	var objects []object
+	for _, key := range keys {
+		from, to, ok := keyEpochs(key)
+		if ok && from <= toEpoch && to >= fromEpoch {
+			objects = append(objects, object{key, from, to})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].from < objects[j].from })
+
+	var out []*storage.ValidatorEpochRecord
+	for _, obj := range objects {
+		err := rd.scan(ctx, obj.key, func(rec *storage.ValidatorEpochRecord) {
+			if rec.Epoch >= fromEpoch && rec.Epoch <= toEpoch && (keep == nil || keep(rec)) {
+				out = append(out, rec)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// scan decodes every record of one object.
+func (rd *Reader) scan(ctx context.Context, key string, each func(*storage.ValidatorEpochRecord)) error {
+	body, err := rd.objects.GetObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		return fmt.Errorf("archive object %s: %w", key, err)
+	}
+	dec := json.NewDecoder(zr)
+	for {
+		var rec storage.ValidatorEpochRecord
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("archive object %s: %w", key, err)
+		}
+		each(&rec)
+	}
+}
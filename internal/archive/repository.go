@@ -0,0 +1,241 @@
+package archive
+
+import (
+	"context"
+	"slices"
+
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Repository answers per-validator epoch record reads from the hot store and, for the part of the
+// requested range older than the oldest hot row (expired or not yet indexed there), from the archive,
+// so callers need not know where the data lives. Other methods, and listings without a validator
+// filter, use the hot store only.
+type Repository struct {
+	storage.Repository
+	archive *Reader
+}
+
+// NewRepository federates reads of inner with the archive read by reader.
+func NewRepository(inner storage.Repository, reader *Reader) *Repository {
+	return &Repository{Repository: inner, archive: reader}
+}
+
+// Store wraps a storage.Store so Repository() returns the federated repository.
+type Store struct {
+	storage.Store
+	repo *Repository
+}
+
+// NewStore wraps inner so validator reads fall back to the archive.
+func NewStore(inner storage.Store, reader *Reader) *Store {
+	return &Store{Store: inner, repo: NewRepository(inner.Repository(), reader)}
+}
+
+// Repository returns the federated repository.
+func (s *Store) Repository() storage.Repository {
+	return s.repo
+}
+
+// GetValidatorSnapshots returns hot snapshots followed by older archived ones, newest first.
+func (r *Repository) GetValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64) ([]*storage.ValidatorSnapshot, error) {
+	hot, err := r.Repository.GetValidatorSnapshots(ctx, validatorIndex, fromSlot, toSlot)
+	if err != nil {
+		return nil, err
+	}
+	older, err := r.archivedSnapshots(ctx, validatorIndex, fromSlot, toSlot, oldestSlot(hot))
+	if err != nil {
+		return nil, err
+	}
+	return append(hot, older...), nil
+}
+
+// ListValidatorSnapshots pages through hot and then archived snapshots, newest first.
+func (r *Repository) ListValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64, limit, offset int) ([]*storage.ValidatorSnapshot, error) {
+	return federatePage(limit, offset,
+		func(limit, offset int) ([]*storage.ValidatorSnapshot, error) {
+			return r.Repository.ListValidatorSnapshots(ctx, validatorIndex, fromSlot, toSlot, limit, offset)
+		},
+		func() ([]*storage.ValidatorSnapshot, error) {
+			return r.Repository.GetValidatorSnapshots(ctx, validatorIndex, fromSlot, toSlot)
+		},
+		func(hot []*storage.ValidatorSnapshot) ([]*storage.ValidatorSnapshot, error) {
+			return r.archivedSnapshots(ctx, validatorIndex, fromSlot, toSlot, oldestSlot(hot))
+		})
+}
+
+// GetAttestationRewards returns hot rewards followed by older archived ones, newest first.
+func (r *Repository) GetAttestationRewards(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.AttestationReward, error) {
+	hot, err := r.Repository.GetAttestationRewards(ctx, validatorIndex, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	older, err := r.archivedRewards(ctx, validatorIndex, fromEpoch, toEpoch, oldestRewardEpoch(hot))
+	if err != nil {
+		return nil, err
+	}
+	return append(hot, older...), nil
+}
+
+// ListAttestationRewards pages through hot and then archived rewards for one validator, newest first.
+// Without a validator filter only the hot store is listed.
+func (r *Repository) ListAttestationRewards(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*storage.AttestationReward, error) {
+	if validatorIndex == nil {
+		return r.Repository.ListAttestationRewards(ctx, nil, fromEpoch, toEpoch, limit, offset)
+	}
+	v := *validatorIndex
+	return federatePage(limit, offset,
+		func(limit, offset int) ([]*storage.AttestationReward, error) {
+			return r.Repository.ListAttestationRewards(ctx, validatorIndex, fromEpoch, toEpoch, limit, offset)
+		},
+		func() ([]*storage.AttestationReward, error) {
+			return r.Repository.GetAttestationRewards(ctx, v, fromEpoch, toEpoch)
+		},
+		func(hot []*storage.AttestationReward) ([]*storage.AttestationReward, error) {
+			return r.archivedRewards(ctx, v, fromEpoch, toEpoch, oldestRewardEpoch(hot))
+		})
+}
+
+// GetEffectiveBalanceSeries fills the missing epochs before the first hot point from the archive.
+func (r *Repository) GetEffectiveBalanceSeries(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.EffectiveBalancePoint, error) {
+	series, err := r.Repository.GetEffectiveBalanceSeries(ctx, validatorIndex, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	gap := 0
+	for gap < len(series) && series[gap].Source == storage.EffectiveBalanceMissing {
+		gap++
+	}
+	if gap == 0 {
+		return series, nil
+	}
+	records, err := r.archive.Records(ctx, validatorIndex, fromEpoch, series[gap-1].Epoch, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		series[rec.Epoch-fromEpoch] = &storage.EffectiveBalancePoint{
+			ValidatorIndex:   rec.ValidatorIndex,
+			Epoch:            rec.Epoch,
+			EffectiveBalance: rec.EffectiveBalance,
+			Balance:          rec.Balance,
+			Status:           rec.Status,
+			SlotTime:         rec.SlotTime,
+			Source:           storage.EffectiveBalanceIndexed,
+		}
+	}
+	return series, nil
+}
+
+// archivedSnapshots returns archived snapshots in fromSlot..toSlot below the oldest hot slot (the whole
+// range when below is nil), newest first.
+func (r *Repository) archivedSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64, below *uint64) ([]*storage.ValidatorSnapshot, error) {
+	if below != nil {
+		if *below <= fromSlot {
+			return nil, nil
+		}
+		toSlot = min(toSlot, *below-1)
+	}
+	spe := config.SlotsPerEpoch()
+	records, err := r.archive.Records(ctx, validatorIndex, fromSlot/spe, toSlot/spe, func(rec *storage.ValidatorEpochRecord) bool {
+		return rec.EpochStartSlot >= fromSlot && rec.EpochStartSlot <= toSlot
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*storage.ValidatorSnapshot, 0, len(records))
+	for _, rec := range slices.Backward(records) {
+		out = append(out, &storage.ValidatorSnapshot{
+			ValidatorIndex:   rec.ValidatorIndex,
+			Slot:             rec.EpochStartSlot,
+			Status:           rec.Status,
+			Balance:          rec.Balance,
+			EffectiveBalance: rec.EffectiveBalance,
+			SlotTime:         rec.SlotTime,
+			Timestamp:        rec.IndexedAt,
+		})
+	}
+	return out, nil
+}
+
+// archivedRewards returns archived attestation rewards in fromEpoch..toEpoch below the oldest hot epoch
+// (the whole range when below is nil), newest first.
+func (r *Repository) archivedRewards(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64, below *uint64) ([]*storage.AttestationReward, error) {
+	if below != nil {
+		if *below <= fromEpoch {
+			return nil, nil
+		}
+		toEpoch = min(toEpoch, *below-1)
+	}
+	records, err := r.archive.Records(ctx, validatorIndex, fromEpoch, toEpoch, func(rec *storage.ValidatorEpochRecord) bool {
+		return rec.HeadReward != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*storage.AttestationReward, 0, len(records))
+	for _, rec := range slices.Backward(records) {
+		out = append(out, &storage.AttestationReward{
+			ValidatorIndex: rec.ValidatorIndex,
+			Epoch:          rec.Epoch,
+			HeadReward:     deref(rec.HeadReward),
+			SourceReward:   deref(rec.SourceReward),
+			TargetReward:   deref(rec.TargetReward),
+			TotalReward:    deref(rec.TotalReward),
+			Timestamp:      rec.IndexedAt,
+		})
+	}
+	return out, nil
+}
+
+// federatePage returns one newest-first page over hot rows followed by older archived rows. The
+// archive is only read when the hot rows run out within the page; hotAll is only called when the page
+// starts past the last hot row, to count the hot rows and find the oldest.
+func federatePage[T any](limit, offset int, hotPage func(limit, offset int) ([]T, error), hotAll func() ([]T, error), older func(hot []T) ([]T, error)) ([]T, error) {
+	page, err := hotPage(limit, offset)
+	if err != nil || len(page) >= limit {
+		return page, err
+	}
+	hot, hotCount := page, offset+len(page)
+	if len(page) == 0 {
+		if hot, err = hotAll(); err != nil {
+			return nil, err
+		}
+		hotCount = len(hot)
+	}
+	archived, err := older(hot)
+	if err != nil {
+		return nil, err
+	}
+	skip := max(0, offset-hotCount)
+	if skip >= len(archived) {
+		return page, nil
+	}
+	archived = archived[skip:]
+	if need := limit - len(page); len(archived) > need {
+		archived = archived[:need]
+	}
+	return append(page, archived...), nil
+}
+
+func oldestSlot(hot []*storage.ValidatorSnapshot) *uint64 {
+	if len(hot) == 0 {
+		return nil
+	}
+	return &hot[len(hot)-1].Slot
+}
+
+func oldestRewardEpoch(hot []*storage.AttestationReward) *uint64 {
+	if len(hot) == 0 {
+		return nil
+	}
+	return &hot[len(hot)-1].Epoch
+}
+
+func deref(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,81 @@
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+// hotRewardsRepo holds validator 5's rewards for epochs 10-14 in the hot store.
+type hotRewardsRepo struct {
+	*noop.Repository
+}
+
+func (hotRewardsRepo) GetAttestationRewards(_ context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.AttestationReward, error) {
+	var out []*storage.AttestationReward
+	for epoch := min(toEpoch, 14); epoch >= max(fromEpoch, 10); epoch-- {
+		out = append(out, &storage.AttestationReward{ValidatorIndex: validatorIndex, Epoch: epoch, HeadReward: 1})
+	}
+	return out, nil
+}
+
+func (r hotRewardsRepo) ListAttestationRewards(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*storage.AttestationReward, error) {
+	all, _ := r.GetAttestationRewards(ctx, *validatorIndex, fromEpoch, toEpoch)
+	if offset >= len(all) {
+		return nil, nil
+	}
+	return all[offset:min(len(all), offset+limit)], nil
+}
+
+func (hotRewardsRepo) GetEffectiveBalanceSeries(_ context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.EffectiveBalancePoint, error) {
+	indexed := map[uint64]*storage.EffectiveBalancePoint{}
+	for epoch := uint64(10); epoch <= 14; epoch++ {
+		indexed[epoch] = &storage.EffectiveBalancePoint{ValidatorIndex: validatorIndex, Epoch: epoch, Source: storage.EffectiveBalanceIndexed}
+	}
+	return storage.EffectiveBalanceSeries(validatorIndex, fromEpoch, toEpoch, indexed), nil
+}
+
+func TestRepository_fallsBackToArchive(t *testing.T) {
+	objects := memoryStore{}
+	var archived []*storage.ValidatorEpochRecord
+	for epoch := uint64(0); epoch < 12; epoch++ { // overlaps the hot store at 10 and 11
+		reward := int64(2)
+		archived = append(archived, &storage.ValidatorEpochRecord{ValidatorIndex: 5, Epoch: epoch, EffectiveBalance: 32, HeadReward: &reward, TotalReward: &reward})
+	}
+	body, err := encodeJSONLines(archived)
+	require.NoError(t, err)
+	objects[recordsKey("cold", 5, "2026-01", 0, 11)] = body
+	repo := NewRepository(hotRewardsRepo{noop.NewRepository()}, NewReader(objects, "cold"))
+	ctx := context.Background()
+	v := uint64(5)
+
+	epochs := func(rows []*storage.AttestationReward) []uint64 {
+		var out []uint64
+		for _, r := range rows {
+			out = append(out, r.Epoch)
+		}
+		return out
+	}
+	page, err := repo.ListAttestationRewards(ctx, &v, 0, 20, 4, 3)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11, 10, 9, 8}, epochs(page), "archived rows follow the hot rows")
+	require.Equal(t, int64(1), page[1].HeadReward, "hot rows win where both hold an epoch")
+	require.Equal(t, int64(2), page[2].HeadReward)
+
+	page, err = repo.ListAttestationRewards(ctx, &v, 0, 20, 4, 7)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7, 6, 5, 4}, epochs(page))
+
+	all, err := repo.GetAttestationRewards(ctx, v, 12, 14)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{14, 13, 12}, epochs(all), "ranges inside the hot store do not read the archive")
+
+	series, err := repo.GetEffectiveBalanceSeries(ctx, v, 8, 12)
+	require.NoError(t, err)
+	require.Len(t, series, 5)
+	require.Equal(t, storage.EffectiveBalanceIndexed, series[0].Source)
+	require.Equal(t, uint64(32), series[1].EffectiveBalance)
+}
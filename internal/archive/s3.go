@@ -0,0 +1,263 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tharun/pauli/internal/config"
+)
+
+// S3Options configures an S3-compatible bucket (AWS S3, MinIO, Cloudflare R2, Ceph, ...).
+type S3Options struct {
+	// Endpoint is the service URL; empty means https://s3.<region>.amazonaws.com.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle addresses objects as <endpoint>/<bucket>/<key> instead of <bucket>.<endpoint host>/<key>.
+	PathStyle bool
+	// Timeout bounds each upload (default one minute).
+	Timeout time.Duration
+}
+
+// S3Client uploads objects with AWS Signature Version 4.
+type S3Client struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Client validates the endpoint and returns a client for opts.Bucket.
+func NewS3Client(opts S3Options) (*S3Client, error) {
+	raw := opts.Endpoint
+	if raw == "" {
+		raw = "https://s3." + opts.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", raw)
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	return &S3Client{opts: opts, endpoint: u, client: &http.Client{Timeout: timeout}, now: time.Now}, nil
+}
+
+// NewS3ClientFromConfig returns a client for the archive config block.
+func NewS3ClientFromConfig(ac config.ArchiveConf) (*S3Client, error) {
+	return NewS3Client(S3Options{
+		Endpoint:        ac.Endpoint,
+		Region:          ac.Region,
+		Bucket:          ac.Bucket,
+		AccessKeyID:     ac.AccessKeyID,
+		SecretAccessKey: ac.SecretAccessKey,
+		PathStyle:       ac.PathStyle,
+	})
+}
+
+// PutObject uploads body under key, replacing any object already there.
+func (c *S3Client) PutObject(ctx context.Context, key string, body []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", contentType)
+	sum := sha256.Sum256(body)
+	c.sign(req, hex.EncodeToString(sum[:]), c.now().UTC())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("put s3://%s/%s: %w", c.opts.Bucket, key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("put s3://%s/%s: HTTP %d: %s", c.opts.Bucket, key, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// emptyPayloadHash is the SHA-256 of an empty body, signed for GET requests.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// GetObject opens key for streaming; the caller closes the body.
+func (c *S3Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, c.objectURL(key))
+	if err != nil {
+		return nil, fmt.Errorf("get s3://%s/%s: %w", c.opts.Bucket, key, err)
+	}
+	return resp.Body, nil
+}
+
+// ListObjects returns every key under prefix (ListObjectsV2, following continuation tokens).
+func (c *S3Client) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := c.do(ctx, c.objectURL("")+"?"+canonicalQuery(query))
+		if err != nil {
+			return nil, fmt.Errorf("list s3://%s/%s: %w", c.opts.Bucket, prefix, err)
+		}
+		var page struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("list s3://%s/%s: decode: %w", c.opts.Bucket, prefix, err)
+		}
+		for _, obj := range page.Contents {
+			keys = append(keys, obj.Key)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// do sends a signed GET and returns the response if it is 2xx.
+func (c *S3Client) do(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.sign(req, emptyPayloadHash, c.now().UTC())
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+func (c *S3Client) objectURL(key string) string {
+	u := *c.endpoint
+	path := strings.TrimSuffix(u.Path, "/") + "/"
+	if c.opts.PathStyle {
+		path += c.opts.Bucket + "/"
+	} else {
+		u.Host = c.opts.Bucket + "." + u.Host
+	}
+	path += key
+	u.Path = path
+	u.RawPath = s3EscapePath(path)
+	return u.String()
+}
+
+// sign adds the x-amz-date, x-amz-content-sha256 and Authorization headers. Host and every header
+// already set on req are signed.
+func (c *S3Client) sign(req *http.Request, payloadHash string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + c.opts.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, c.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes query sorted by name with SigV4 escaping; requests use it as their raw query
+// too, so the signed and sent forms match.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parts []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(name, true)+"="+s3Escape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath percent-encodes everything but unreserved characters and '/', as SigV4 requires for S3.
+func s3EscapePath(path string) string {
+	return s3Escape(path, false)
+}
+
+// s3Escape percent-encodes everything but unreserved characters, and '/' too when encodeSlash is set.
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' || ch == '/' && !encodeSlash {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
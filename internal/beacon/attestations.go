@@ -0,0 +1,151 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BlockAttestationData is the subset of AttestationData needed to match a duty.
+type BlockAttestationData struct {
+	Slot  Uint64Str `json:"slot"`
+	Index Uint64Str `json:"index"`
+}
+
+// BlockAttestation is one aggregate attestation included in a beacon block body.
+// CommitteeBits is empty before Electra; from Electra on, Data.Index is 0 and AggregationBits
+// spans every committee set in CommitteeBits (EIP-7549).
+type BlockAttestation struct {
+	AggregationBits string               `json:"aggregation_bits"`
+	Data            BlockAttestationData `json:"data"`
+	CommitteeBits   string               `json:"committee_bits,omitempty"`
+}
+
+// blockV2AttestationsJSON unmarshals only body.attestations from GET /eth/v2/beacon/blocks/{block_id}.
+type blockV2AttestationsJSON struct {
+	Data struct {
+		Message struct {
+			Body struct {
+				Attestations []BlockAttestation `json:"attestations"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// BeaconCommittee is one committee from GET /eth/v1/beacon/states/{state_id}/committees.
+type BeaconCommittee struct {
+	Index      Uint64Str   `json:"index"`
+	Slot       Uint64Str   `json:"slot"`
+	Validators []Uint64Str `json:"validators"`
+}
+
+// BeaconCommitteesResponse is the response from /eth/v1/beacon/states/{state_id}/committees.
+type BeaconCommitteesResponse = APIResponse[[]BeaconCommittee]
+
+// GetBlockAttestations returns the attestations included in a beacon block.
+// blockID may be a slot string, "head", "finalized", genesis, or a block root (0x-prefixed hex).
+// Missed slots surface as a 404 (see IsNotFound).
+func (c *Client) GetBlockAttestations(ctx context.Context, blockID string) ([]BlockAttestation, error) {
+	path := fmt.Sprintf("/eth/v2/beacon/blocks/%s", url.PathEscape(blockID))
+
+	var raw blockV2AttestationsJSON
+	if err := c.get(ctx, path, &raw); err != nil {
+		return nil, requestError("get block attestations", err).at(blockID)
+	}
+	return raw.Data.Message.Body.Attestations, nil
+}
+
+// CommitteeFilter narrows GetBeaconCommittees to one slot and/or committee index. Nil fields are not sent.
+type CommitteeFilter struct {
+	Slot  *uint64
+	Index *uint64
+}
+
+// GetBeaconCommittees returns the committees of epoch read from stateID, narrowed by filter.
+// With an empty filter every committee of the epoch is returned, independent of the duties endpoint.
+func (c *Client) GetBeaconCommittees(ctx context.Context, stateID string, epoch uint64, filter CommitteeFilter) ([]BeaconCommittee, error) {
+	q := url.Values{}
+	q.Set("epoch", strconv.FormatUint(epoch, 10))
+	if filter.Slot != nil {
+		q.Set("slot", strconv.FormatUint(*filter.Slot, 10))
+	}
+	if filter.Index != nil {
+		q.Set("index", strconv.FormatUint(*filter.Index, 10))
+	}
+	path := fmt.Sprintf("/eth/v1/beacon/states/%s/committees?%s", url.PathEscape(stateID), q.Encode())
+
+	var resp BeaconCommitteesResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, requestError("get beacon committees", err).epoch(epoch).at(stateID)
+	}
+	return resp.Data, nil
+}
+
+// decodeBits decodes a 0x-prefixed SSZ bitlist/bitvector hex string (little-endian bit order).
+func decodeBits(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode bits %q: %w", s, err)
+	}
+	return b, nil
+}
+
+func bitSet(b []byte, i uint64) bool {
+	if i/8 >= uint64(len(b)) {
+		return false
+	}
+	return b[i/8]&(1<<(i%8)) != 0
+}
+
+// CommitteeIndices returns the committee indices covered by the attestation in ascending order:
+// the set bits of CommitteeBits (Electra+), or Data.Index for pre-Electra attestations.
+func (a *BlockAttestation) CommitteeIndices() ([]uint64, error) {
+	if a.CommitteeBits == "" {
+		return []uint64{a.Data.Index.Uint64()}, nil
+	}
+	bits, err := decodeBits(a.CommitteeBits)
+	if err != nil {
+		return nil, err
+	}
+	var out []uint64
+	for i := uint64(0); i < uint64(len(bits))*8; i++ {
+		if bitSet(bits, i) {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+// HasAttester reports whether the attester at committeePosition of committeeIndex is set in the
+// aggregation bits. committeeLength returns the size of each committee in CommitteeIndices; it is only
+// consulted for Electra aggregates, whose bits are the concatenation of all covered committees.
+func (a *BlockAttestation) HasAttester(committeeIndex, committeePosition uint64, committeeLength func(uint64) (uint64, error)) (bool, error) {
+	committees, err := a.CommitteeIndices()
+	if err != nil {
+		return false, err
+	}
+	var offset uint64
+	found := false
+	for _, ci := range committees {
+		if ci == committeeIndex {
+			found = true
+			break
+		}
+		n, err := committeeLength(ci)
+		if err != nil {
+			return false, err
+		}
+		offset += n
+	}
+	if !found {
+		return false, nil
+	}
+	bits, err := decodeBits(a.AggregationBits)
+	if err != nil {
+		return false, err
+	}
+	return bitSet(bits, offset+committeePosition), nil
+}
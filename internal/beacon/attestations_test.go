@@ -0,0 +1,66 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockAttestation_HasAttester(t *testing.T) {
+	t.Parallel()
+
+	lengths := map[uint64]uint64{0: 4, 2: 5, 5: 3}
+	lengthOf := func(ci uint64) (uint64, error) { return lengths[ci], nil }
+
+	t.Run("pre-electra uses data.index", func(t *testing.T) {
+		// bits 1 and 3 set, delimiter at bit 4 (committee length 4).
+		a := BlockAttestation{AggregationBits: "0x1a", Data: BlockAttestationData{Slot: 10, Index: 2}}
+		ok, err := a.HasAttester(2, 1, lengthOf)
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = a.HasAttester(2, 2, lengthOf)
+		require.NoError(t, err)
+		require.False(t, ok)
+		ok, err = a.HasAttester(0, 1, lengthOf)
+		require.NoError(t, err)
+		require.False(t, ok, "other committee")
+	})
+
+	t.Run("electra offsets by preceding committees", func(t *testing.T) {
+		// committee_bits: committees 0, 2, 5 (0b00100101). Aggregation bits span 4+5+3 = 12 bits;
+		// set bit 4+2 = 6 (committee 2, position 2) and bit 9+1 = 10 (committee 5, position 1).
+		a := BlockAttestation{AggregationBits: "0x4014", CommitteeBits: "0x2500000000000000"}
+		ok, err := a.HasAttester(2, 2, lengthOf)
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = a.HasAttester(5, 1, lengthOf)
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = a.HasAttester(0, 2, lengthOf)
+		require.NoError(t, err)
+		require.False(t, ok)
+		ok, err = a.HasAttester(3, 0, lengthOf)
+		require.NoError(t, err)
+		require.False(t, ok, "committee not covered")
+	})
+}
+
+func TestClient_GetBeaconCommitteesQuery(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","slot":"65","validators":["4","9"]}]}`))
+	})
+
+	committees, err := c.GetBeaconCommittees(context.Background(), "head", 2, CommitteeFilter{})
+	require.NoError(t, err)
+	require.Len(t, committees, 1)
+	require.Equal(t, uint64(9), committees[0].Validators[1].Uint64())
+
+	slot, index := uint64(65), uint64(1)
+	_, err = c.GetBeaconCommittees(context.Background(), "head", 2, CommitteeFilter{Slot: &slot, Index: &index})
+	require.NoError(t, err)
+	require.Equal(t, []string{"epoch=2", "epoch=2&index=1&slot=65"}, queries)
+}
@@ -0,0 +1,146 @@
+package beacon
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tharun/pauli/internal/config"
+)
+
+// maxCacheEntries bounds the response cache; expired entries are swept when it is reached.
+const maxCacheEntries = 256
+
+// responseCache keeps successful GET responses of slowly-changing endpoints (http.cache): genesis,
+// spec and finality checkpoints. A negative TTL disables caching for that endpoint.
+type responseCache struct {
+	genesisTTL  time.Duration // 0 = whole run
+	specTTL     time.Duration
+	finalityTTL time.Duration // 0 = until the next slot starts
+	slot        time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	genesis time.Time // learnt from the genesis response, for slot boundaries
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	resp    *response
+	expires time.Time // zero = never
+}
+
+// newResponseCache returns nil when caching is disabled.
+func newResponseCache(cfg *config.Config) *responseCache {
+	cc := cfg.HTTP.Cache
+	if cc.Disabled {
+		return nil
+	}
+	return &responseCache{
+		genesisTTL:  time.Duration(cc.GenesisSeconds) * time.Second,
+		specTTL:     time.Duration(cc.SpecSeconds) * time.Second,
+		finalityTTL: time.Duration(cc.FinalitySeconds) * time.Second,
+		slot:        cfg.SlotDuration(),
+		now:         time.Now,
+		entries:     make(map[string]cacheEntry),
+	}
+}
+
+// get returns an unexpired cached response for path.
+func (c *responseCache) get(path string) (*response, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		delete(c.entries, path)
+		return nil, false
+	}
+	return e.resp, true
+}
+
+// put caches resp if path is a cached endpoint.
+func (c *responseCache) put(path string, resp *response) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires, ok := c.expiry(path)
+	if !ok {
+		return
+	}
+	if len(c.entries) >= maxCacheEntries {
+		now := c.now()
+		for k, e := range c.entries {
+			if !e.expires.IsZero() && !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[path] = cacheEntry{resp: resp, expires: expires}
+}
+
+// drop forgets path, e.g. after a cached response failed validation.
+func (c *responseCache) drop(path string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, path)
+	c.mu.Unlock()
+}
+
+// setGenesis records the chain genesis time so finality checkpoints expire on slot boundaries.
+func (c *responseCache) setGenesis(t time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.genesis = t
+	c.mu.Unlock()
+}
+
+// expiry returns when a response for path expires (zero = never); ok is false for uncached paths.
+// The caller holds mu.
+func (c *responseCache) expiry(path string) (expires time.Time, ok bool) {
+	now := c.now()
+	var ttl time.Duration
+	switch {
+	case path == "/eth/v1/beacon/genesis":
+		ttl = c.genesisTTL
+		if ttl == 0 {
+			return time.Time{}, true
+		}
+	case path == "/eth/v1/config/spec":
+		ttl = c.specTTL
+	case strings.HasPrefix(path, "/eth/v1/beacon/states/") && strings.HasSuffix(path, "/finality_checkpoints"):
+		ttl = c.finalityTTL
+		if ttl == 0 {
+			return c.nextSlot(now), true
+		}
+	default:
+		return time.Time{}, false
+	}
+	if ttl < 0 {
+		return time.Time{}, false
+	}
+	return now.Add(ttl), true
+}
+
+// nextSlot returns the start of the slot after now, or one slot duration from now before genesis is known.
+func (c *responseCache) nextSlot(now time.Time) time.Time {
+	if c.genesis.IsZero() || now.Before(c.genesis) || c.slot <= 0 {
+		return now.Add(c.slot)
+	}
+	slots := now.Sub(c.genesis) / c.slot
+	return c.genesis.Add((slots + 1) * c.slot)
+}
@@ -0,0 +1,23 @@
+package beacon
+
+// MaxValidatorIDsPerGetValidators is the default of beacon_api.max_validators_per_request: how many
+// validator indices are sent in one request, keeping GET URLs and POST bodies within what nodes and
+// gateways accept.
+const MaxValidatorIDsPerGetValidators = 100
+
+// chunks splits validator indices into consecutive slices of at most c.maxValidators. An empty list
+// yields one empty chunk, so "all validators" requests still go out once.
+func (c *Client) chunks(ids []uint64) [][]uint64 {
+	size := c.maxValidators
+	if size <= 0 {
+		size = MaxValidatorIDsPerGetValidators
+	}
+	if len(ids) <= size {
+		return [][]uint64{ids}
+	}
+	out := make([][]uint64, 0, (len(ids)+size-1)/size)
+	for i := 0; i < len(ids); i += size {
+		out = append(out, ids[i:min(i+size, len(ids))])
+	}
+	return out
+}
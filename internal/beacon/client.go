@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/tharun/pauli/internal/config"
 	"github.com/tharun/pauli/pkg/backoff"
+	"golang.org/x/sync/singleflight"
 	"golang.org/x/time/rate"
 )
 
@@ -25,10 +27,27 @@ func min(a, b int) int {
 // Client is an HTTP client for the Beacon Node API.
 type Client struct {
 	baseURL    string
+	paths      pathRewriter
 	apiKey     string
 	httpClient *http.Client
 	limiter    *rate.Limiter
-	maxRetries int
+	// weights is how many limiter tokens each endpoint class takes (rate_limit.weights).
+	weights endpointWeights
+	// maxValidators caps validator indices per request (beacon_api.max_validators_per_request).
+	maxValidators int
+	maxRetries    int
+	// backoff paces retries; tests replace its Rand and Sleep.
+	backoff backoff.Config
+	// errorBodyMax caps response body snippets kept in HTTPResponseError and DecodeError.
+	errorBodyMax int
+	// maxResponseBytes caps how much of any response body is read; <= 0 means unlimited.
+	maxResponseBytes int64
+	// inflight coalesces concurrent identical requests into one network call.
+	inflight singleflight.Group
+	// recordRaw receives audited raw responses (see RecordRawResponses); nil records nothing.
+	recordRaw func(RawResponse)
+	// cache holds genesis, spec and finality checkpoint responses (http.cache); nil when disabled.
+	cache *responseCache
 }
 
 // NewClient creates a new Beacon API client with rate limiting and connection pooling.
@@ -52,18 +71,33 @@ func NewClient(cfg *config.Config) *Client {
 	)
 
 	return &Client{
-		baseURL:    cfg.BeaconNodeURL,
-		apiKey:     cfg.BeaconAPIKey,
-		httpClient: httpClient,
-		limiter:    limiter,
-		maxRetries: cfg.HTTP.MaxRetries,
+		baseURL:          cfg.BeaconNodeURL,
+		paths:            newPathRewriter(cfg.BeaconAPI),
+		apiKey:           cfg.BeaconAPIKey,
+		httpClient:       httpClient,
+		limiter:          limiter,
+		weights:          newEndpointWeights(cfg.RateLimit.Weights, cfg.RateLimit.Burst),
+		maxValidators:    cfg.BeaconAPI.MaxValidatorsPerRequest,
+		maxRetries:       cfg.HTTP.MaxRetries,
+		backoff:          backoff.DefaultConfig(),
+		errorBodyMax:     cfg.HTTP.ErrorBodyMaxBytes,
+		maxResponseBytes: cfg.HTTP.MaxResponseBytes,
+		cache:            newResponseCache(cfg),
 	}
 }
 
-// doRequest performs an HTTP request with rate limiting and retries.
+// doRequest performs an HTTP request with rate limiting and retries, and decodes the response into result.
 // body is JSON-encoded once and re-read per attempt so retries are safe. Pass nil for GET.
+// Concurrent identical requests (same method, path and body) share one network call; each caller
+// decodes the shared response into its own result. GETs of cached endpoints are answered from the cache.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	url := c.baseURL + path
+	cacheable := method == http.MethodGet && result != nil
+	if cacheable {
+		if resp, ok := c.cache.get(path); ok {
+			log.Debug().Str("path", path).Msg("beacon response served from cache")
+			return c.decode(resp, method, path, result)
+		}
+	}
 
 	var bodyJSON []byte
 	if body != nil {
@@ -74,20 +108,75 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		}
 	}
 
+	resp, err := c.coalesce(ctx, method, path, bodyJSON, result != nil)
+	if err != nil || result == nil {
+		return err
+	}
+	if err := c.decode(resp, method, path, result); err != nil {
+		return err
+	}
+	if cacheable {
+		c.cache.put(path, resp)
+	}
+	return nil
+}
+
+// response is a successful (HTTP 200) response body with the metadata decode errors report.
+type response struct {
+	statusCode  int
+	contentType string
+	body        []byte
+}
+
+// coalesce runs fetch for the request, or waits for an identical one already in flight. If the
+// in-flight call was cancelled by its own caller while ctx is still live, the request is issued again.
+func (c *Client) coalesce(ctx context.Context, method, path string, bodyJSON []byte, wantBody bool) (*response, error) {
+	key := method + " " + path + "\n" + string(bodyJSON)
+	if !wantBody {
+		key = "nobody " + key
+	}
+	for {
+		ch := c.inflight.DoChan(key, func() (interface{}, error) {
+			return c.fetch(ctx, method, path, bodyJSON, wantBody)
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case res := <-ch:
+			if res.Err != nil {
+				if res.Shared && ctx.Err() == nil && (errors.Is(res.Err, context.Canceled) || errors.Is(res.Err, context.DeadlineExceeded)) {
+					continue
+				}
+				return nil, res.Err
+			}
+			if res.Shared {
+				log.Debug().Str("method", method).Str("path", path).Msg("beacon request coalesced with an identical in-flight request")
+			}
+			return res.Val.(*response), nil
+		}
+	}
+}
+
+// fetch sends the request with rate limiting and retries and returns the successful response.
+func (c *Client) fetch(ctx context.Context, method, path string, bodyJSON []byte, wantBody bool) (*response, error) {
+	url := c.baseURL + c.paths.rewrite(path)
+
+	weight := c.weights.weight(path)
+
 	var lastErr error
-	b := backoff.NewDefault()
+	b := backoff.New(c.backoff)
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		// Wait for rate limiter with timeout
 		// Use a shorter timeout to avoid context deadline issues
 		limiterCtx, limiterCancel := context.WithTimeout(ctx, 15*time.Second)
-		err := c.limiter.Wait(limiterCtx)
+		err := c.limiter.WaitN(limiterCtx, weight)
 		limiterCancel()
 		if err != nil {
 			if ctx.Err() != nil {
-				return fmt.Errorf("rate limiter error: context cancelled: %w", err)
+				return nil, fmt.Errorf("rate limiter error: context cancelled: %w", err)
 			}
-			return fmt.Errorf("rate limiter error: rate: Wait(n=1) exceeded timeout: %w", err)
+			return nil, fmt.Errorf("rate limiter error: rate: Wait(n=%d) exceeded timeout: %w", weight, err)
 		}
 
 		var reqBody io.Reader
@@ -97,7 +186,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		req.Header.Set("Accept", "application/json")
@@ -122,15 +211,15 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 			if attempt < c.maxRetries {
 				log.Debug().Err(err).Str("url", url).Int("attempt", attempt+1).Msg("request failed, retrying")
 				if !b.Wait(ctx) {
-					return ctx.Err()
+					return nil, ctx.Err()
 				}
 				continue
 			}
 			log.Error().Err(err).Str("url", url).Int("attempts", attempt+1).Msg("beacon request failed after retries")
-			return fmt.Errorf("request failed after %d attempts: %w", attempt+1, err)
+			return nil, fmt.Errorf("request failed after %d attempts: %w", attempt+1, err)
 		}
 
-		retry, err := c.readDoRequestResponse(resp, method, path, result)
+		out, retry, err := c.readDoRequestResponse(resp, method, path, wantBody)
 		if retry {
 			lastErr = err
 			log.Debug().
@@ -140,45 +229,44 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 				Msg("retryable HTTP error, backing off")
 			if attempt < c.maxRetries {
 				if !b.Wait(ctx) {
-					return ctx.Err()
+					return nil, ctx.Err()
 				}
 				continue
 			}
 			log.Error().Err(err).Str("url", url).Int("status", resp.StatusCode).Msg("beacon retryable error, retries exhausted")
-			return err
+			return nil, err
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
-		return nil
+		c.recordRawResponse(method, path, bodyJSON, out)
+		return out, nil
 	}
 
-	return lastErr
+	return nil, lastErr
 }
 
-// readDoRequestResponse reads and closes resp.Body exactly once. If retry is true, err is a *backoff.RetryableError and the caller may re-issue the request after backoff.
-func (c *Client) readDoRequestResponse(resp *http.Response, method, path string, result interface{}) (retry bool, err error) {
+// readDoRequestResponse reads and closes resp.Body exactly once. If retry is true, err is a *backoff.RetryableError
+// or a *DecodeError for an empty 200 body (when wantBody), and the caller may re-issue the request after backoff.
+func (c *Client) readDoRequestResponse(resp *http.Response, method, path string, wantBody bool) (out *response, retry bool, err error) {
 	defer resp.Body.Close()
 
 	if backoff.ShouldRetry(resp.StatusCode) {
-		b, _ := io.ReadAll(resp.Body)
-		return true, &backoff.RetryableError{
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, int64(c.errorBodyMax)))
+		return nil, true, &backoff.RetryableError{
 			StatusCode: resp.StatusCode,
 			Message:    fmt.Sprintf("HTTP %d: %s", resp.StatusCode, string(b)),
 		}
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	bodyBytes, err := c.readBody(resp.Body, path)
 	if err != nil {
 		log.Error().Err(err).Str("path", path).Msg("beacon response body read failed")
-		return false, fmt.Errorf("failed to read response body: %w", err)
+		return nil, false, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		bodyPreview := string(bodyBytes)
-		if len(bodyPreview) > 200 {
-			bodyPreview = bodyPreview[:200] + "..."
-		}
+		bodyPreview := truncateBody(bodyBytes, c.errorBodyMax)
 		httpErr := &HTTPResponseError{StatusCode: resp.StatusCode, Path: path, Body: bodyPreview}
 		if resp.StatusCode == http.StatusNotFound {
 			log.Warn().
@@ -193,7 +281,7 @@ func (c *Client) readDoRequestResponse(resp *http.Response, method, path string,
 				Str("body_preview", bodyPreview).
 				Msg("beacon API non-success status")
 		}
-		return false, httpErr
+		return nil, false, httpErr
 	}
 
 	log.Debug().
@@ -204,26 +292,67 @@ func (c *Client) readDoRequestResponse(resp *http.Response, method, path string,
 		Str("body_preview", string(bodyBytes[:min(200, len(bodyBytes))])).
 		Msg("Beacon API response received")
 
-	if result == nil {
-		return false, nil
+	out = &response{statusCode: resp.StatusCode, contentType: resp.Header.Get("Content-Type"), body: bodyBytes}
+	if wantBody && len(bytes.TrimSpace(bodyBytes)) == 0 {
+		// Seen behind proxies that drop the body on upstream resets; a retry usually succeeds.
+		return nil, true, &DecodeError{
+			Path:        path,
+			StatusCode:  out.statusCode,
+			ContentType: out.contentType,
+			Err:         io.ErrUnexpectedEOF,
+		}
 	}
+	return out, false, nil
+}
 
-	if err := json.Unmarshal(bodyBytes, result); err != nil {
+// decode unmarshals a successful response into result.
+func (c *Client) decode(resp *response, method, path string, result interface{}) error {
+	if err := json.Unmarshal(resp.body, result); err != nil {
+		decodeErr := &DecodeError{
+			Path:        path,
+			StatusCode:  resp.statusCode,
+			ContentType: resp.contentType,
+			BodySize:    len(resp.body),
+			Body:        truncateBody(resp.body, c.errorBodyMax),
+			Err:         err,
+		}
 		log.Error().
 			Err(err).
 			Str("path", path).
-			Str("body", string(bodyBytes[:min(500, len(bodyBytes))])).
+			Str("content_type", decodeErr.ContentType).
+			Int("body_size", decodeErr.BodySize).
+			Str("body", decodeErr.Body).
 			Msg("failed to decode beacon response")
-		return false, fmt.Errorf("failed to decode response: %w", err)
+		return decodeErr
 	}
 
 	log.Debug().
 		Str("method", method).
 		Str("path", path).
-		Int("status", resp.StatusCode).
+		Int("status", resp.statusCode).
 		Msg("Beacon API request successful and parsed")
 
-	return false, nil
+	return nil
+}
+
+// readBody reads r up to maxResponseBytes, returning a ResponseTooLargeError instead of
+// buffering a larger body.
+func (c *Client) readBody(r io.Reader, path string) ([]byte, error) {
+	if c.maxResponseBytes <= 0 {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return b, nil
+	}
+	b, err := io.ReadAll(io.LimitReader(r, c.maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(b)) > c.maxResponseBytes {
+		return nil, &ResponseTooLargeError{Path: path, Limit: c.maxResponseBytes}
+	}
+	return b, nil
 }
 
 // get performs a GET request.
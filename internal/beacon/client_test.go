@@ -0,0 +1,438 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 2, ErrorBodyMaxBytes: 16},
+	})
+}
+
+func TestClient_decodeErrorCapturesBody(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	})
+
+	_, err := c.GetHeadSlot(context.Background())
+	require.Error(t, err)
+	require.True(t, IsDecodeError(err))
+
+	var de *DecodeError
+	require.ErrorAs(t, err, &de)
+	require.Equal(t, "/eth/v1/beacon/headers/head", de.Path)
+	require.Equal(t, "text/html", de.ContentType)
+	require.Equal(t, "<html><body>502 ...", de.Body)
+	require.True(t, strings.Contains(err.Error(), "<html>"))
+}
+
+func TestClient_retriesEmptyBody(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"header":{"message":{"slot":"42"}}}}`))
+	})
+
+	slot, err := c.GetHeadSlot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), slot)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_coalescesIdenticalRequests(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(100 * time.Millisecond) // keep the first request in flight while the others join
+		_, _ = w.Write([]byte(`{"data":{"header":{"message":{"slot":"42"}}}}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slot, err := c.GetHeadSlot(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(42), slot)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+
+	_, err := c.GetHeadSlot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load(), "completed requests are not cached")
+}
+
+func TestClient_rejectsOversizedBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"header":{"message":{"slot":"42"}}},"padding":"` + strings.Repeat("x", 256) + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 2, ErrorBodyMaxBytes: 16, MaxResponseBytes: 128},
+	})
+
+	_, err := c.GetHeadSlot(context.Background())
+	require.Error(t, err)
+	require.True(t, IsResponseTooLarge(err))
+	require.Contains(t, err.Error(), "exceeds 128 bytes")
+}
+
+func TestClient_beaconAPIPathRewrite(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":{"header":{"message":{"slot":"7"}}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		BeaconAPI: config.BeaconAPIConf{
+			PathPrefix:       "/gw",
+			EndpointVersions: map[string]string{"beacon": "v3", "beacon/headers": "v2"},
+		},
+		RateLimit: config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:      config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 2, ErrorBodyMaxBytes: 16},
+	})
+
+	slot, err := c.GetHeadSlot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), slot)
+	require.Equal(t, []string{"/gw/eth/v2/beacon/headers/head"}, got)
+}
+
+func TestPathRewriter_defaultsUnchanged(t *testing.T) {
+	p := newPathRewriter(config.BeaconAPIConf{})
+	require.Equal(t, "/eth/v1/node/syncing", p.rewrite("/eth/v1/node/syncing"))
+
+	p = newPathRewriter(config.BeaconAPIConf{EndpointVersions: map[string]string{"node/sync": "v2"}})
+	require.Equal(t, "/eth/v1/node/syncing", p.rewrite("/eth/v1/node/syncing"))
+	require.Equal(t, "/eth/v2/node/sync?x=1", p.rewrite("/eth/v1/node/sync?x=1"))
+}
+
+func TestClient_malformedResponses(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		call func(c *Client) error
+	}{
+		{"genesis data null", `{"data":null}`, func(c *Client) error {
+			_, err := c.GetGenesis(context.Background())
+			return err
+		}},
+		{"genesis time missing", `{"data":{"genesis_validators_root":"0x01"}}`, func(c *Client) error {
+			_, err := c.GetGenesis(context.Background())
+			return err
+		}},
+		{"validator data null", `{"data":null}`, func(c *Client) error {
+			_, err := c.GetValidator(context.Background(), "head", 12)
+			return err
+		}},
+		{"validator index mismatch", `{"data":{"index":"13","validator":{"pubkey":"0xab"}}}`, func(c *Client) error {
+			_, err := c.GetValidator(context.Background(), "head", 12)
+			return err
+		}},
+		{"validators data null", `{"data":null}`, func(c *Client) error {
+			_, err := c.GetValidators(context.Background(), "head", []uint64{1})
+			return err
+		}},
+		{"rewards data empty", `{"data":{}}`, func(c *Client) error {
+			_, err := c.GetAttestationRewards(context.Background(), 5, []uint64{1})
+			return err
+		}},
+		{"duties data null", `{"dependent_root":"0x01","data":null}`, func(c *Client) error {
+			_, err := c.GetAttesterDuties(context.Background(), 5, []uint64{1})
+			return err
+		}},
+		{"liveness data null", `{"data":null}`, func(c *Client) error {
+			_, err := c.GetValidatorLiveness(context.Background(), 5, []uint64{1})
+			return err
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			})
+			err := tc.call(c)
+			require.Error(t, err)
+			require.True(t, IsMalformedResponse(err), "got %v", err)
+		})
+	}
+
+	t.Run("empty lists are valid", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		})
+		vals, err := c.GetValidators(context.Background(), "head", []uint64{1})
+		require.NoError(t, err)
+		require.Empty(t, vals)
+	})
+}
+
+func TestClient_statePruned(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"message":"NOT_FOUND: State not found"}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1, ErrorBodyMaxBytes: 512},
+	})
+
+	_, err := c.GetValidatorsResponse(context.Background(), "3200", nil)
+	require.True(t, IsStatePruned(err))
+	require.True(t, IsNotFound(err), "the HTTP error stays reachable")
+	require.ErrorContains(t, err, "archive")
+
+	_, err = c.GetValidatorsResponse(context.Background(), "head", nil)
+	require.True(t, IsNotFound(err))
+	require.False(t, IsStatePruned(err), "head is never pruned")
+}
+
+func TestClient_recordsRawResponses(t *testing.T) {
+	body := `{"execution_optimistic":false,"finalized":true,"data":{"ideal_rewards":[],"total_rewards":[]}}`
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/rewards/") {
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"header":{"message":{"slot":"42"}}}}`))
+	})
+	var got []RawResponse
+	c.RecordRawResponses(func(raw RawResponse) { got = append(got, raw) })
+
+	_, err := c.GetAttestationRewards(context.Background(), 12, []uint64{1, 2})
+	require.NoError(t, err)
+	_, err = c.GetHeadSlot(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, got, 1, "only reward and validator status responses are recorded")
+	require.Equal(t, "/eth/v1/beacon/rewards/attestations/{epoch}", got[0].Endpoint)
+	require.Equal(t, uint64(12), *got[0].Epoch)
+	require.JSONEq(t, `["1","2"]`, string(got[0].Request))
+	require.Equal(t, body, string(got[0].Body))
+}
+
+func TestNewRawResponse(t *testing.T) {
+	raw, ok := newRawResponse(http.MethodGet, "/eth/v1/beacon/states/320/validators?id=1,2")
+	require.True(t, ok)
+	require.Equal(t, "/eth/v1/beacon/states/{state_id}/validators", raw.Endpoint)
+	require.Equal(t, uint64(320), *raw.Slot)
+	require.Nil(t, raw.Epoch)
+
+	raw, ok = newRawResponse(http.MethodGet, "/eth/v1/beacon/states/head/validators/7")
+	require.True(t, ok)
+	require.Equal(t, "/eth/v1/beacon/states/{state_id}/validators/{validator_id}", raw.Endpoint)
+	require.Nil(t, raw.Slot)
+
+	_, ok = newRawResponse(http.MethodGet, "/eth/v1/beacon/states/head/finality_checkpoints")
+	require.False(t, ok)
+}
+
+func TestClient_cachesSlowEndpoints(t *testing.T) {
+	var genesisCalls, finalityCalls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			genesisCalls.Add(1)
+			_, _ = w.Write([]byte(`{"data":{"genesis_time":"1000","genesis_validators_root":"0x01","genesis_fork_version":"0x00"}}`))
+		default:
+			finalityCalls.Add(1)
+			_, _ = w.Write([]byte(`{"data":{"finalized":{"epoch":"7","root":"0x02"}}}`))
+		}
+	})
+	now := time.Unix(1000+12*5+3, 0) // 3s into slot 5
+	c.cache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetGenesis(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), genesisCalls.Load())
+
+	for i := 0; i < 2; i++ {
+		epoch, err := c.FinalizedEpoch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), epoch)
+	}
+	require.Equal(t, int32(1), finalityCalls.Load())
+
+	now = time.Unix(1000+12*6, 0) // slot 6 starts
+	_, err := c.FinalizedEpoch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(2), finalityCalls.Load(), "finality checkpoints expire at the next slot")
+}
+
+func TestClient_cacheDisabled(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"CONFIG_NAME":"mainnet"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, Cache: config.BeaconCacheConf{Disabled: true}},
+	})
+
+	for i := 0; i < 2; i++ {
+		spec, err := c.GetSpec(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "mainnet", spec["CONFIG_NAME"])
+	}
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestEndpointWeights(t *testing.T) {
+	w := newEndpointWeights(map[string]int{EndpointRewards: 3, EndpointValidators: 50, "bogus": 9}, 20)
+	for path, want := range map[string]int{
+		"/eth/v1/beacon/states/head/validators":           20, // override capped at burst
+		"/eth/v1/beacon/states/head/validators/7":         1,
+		"/eth/v1/beacon/states/320/committees?epoch=10":   5,
+		"/eth/v1/beacon/rewards/attestations/10":          3,
+		"/eth/v2/beacon/blocks/head":                      2,
+		"/eth/v1/validator/duties/attester/10":            2,
+		"/eth/v1/validator/liveness/10":                   2,
+		"/eth/v1/beacon/genesis":                          1,
+		"/eth/v1/beacon/states/head/finality_checkpoints": 1,
+		"/eth/v1/node/syncing":                            1,
+	} {
+		require.Equal(t, want, w.weight(path), path)
+	}
+}
+
+func TestClient_requestErrorContext(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":400,"message":"bad epoch"}`))
+	})
+
+	_, err := c.GetAttestationRewards(context.Background(), 5, []uint64{1, 2})
+	require.Error(t, err)
+	var re *RequestError
+	require.ErrorAs(t, err, &re)
+	require.Equal(t, uint64(5), *re.Epoch)
+	require.Equal(t, 2, re.Validators)
+	require.Contains(t, err.Error(), "failed to get attestation rewards (epoch 5, 2 validators): unexpected status 400")
+
+	_, err = c.GetValidator(context.Background(), "640", 7)
+	require.ErrorAs(t, err, &re)
+	require.Equal(t, uint64(640), *re.Slot)
+	require.Equal(t, uint64(7), *re.Validator)
+
+	var buf strings.Builder
+	logger := zerolog.New(&buf)
+	LogErrorContext(logger.Error().Err(err), err).Msg("failed")
+	require.Contains(t, buf.String(), `"slot":640`)
+	require.Contains(t, buf.String(), `"validator_index":7`)
+	require.Contains(t, buf.String(), `"beacon_op":"get validator"`)
+}
+
+func TestClient_retryDelays(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"header":{"message":{"slot":"42"}}}}`))
+	})
+	var delays []time.Duration
+	c.backoff.Rand = func() float64 { return 0.5 }
+	c.backoff.Sleep = func(ctx context.Context, d time.Duration) bool {
+		delays = append(delays, d)
+		return true
+	}
+
+	slot, err := c.GetHeadSlot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), slot)
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, delays)
+}
+
+func TestClient_chunksValidatorRequests(t *testing.T) {
+	var mu sync.Mutex
+	var batches []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var ids []string
+		if r.Method == http.MethodPost {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&ids))
+		} else {
+			ids = strings.Split(r.URL.Query().Get("id"), ",")
+		}
+		mu.Lock()
+		batches = append(batches, r.Method+" "+strings.Join(ids, ","))
+		mu.Unlock()
+
+		rows := make([]string, len(ids))
+		if r.Method == http.MethodPost {
+			for i, id := range ids {
+				rows[i] = fmt.Sprintf(`{"validator_index":"%s","head":"1","target":"2","source":"3"}`, id)
+			}
+			_, _ = fmt.Fprintf(w, `{"execution_optimistic":%t,"data":{"ideal_rewards":[{"head":"9"}],"total_rewards":[%s]}}`,
+				ids[0] == "5", strings.Join(rows, ","))
+			return
+		}
+		for i, id := range ids {
+			rows[i] = fmt.Sprintf(`{"index":"%s","balance":"1","status":"active_ongoing","validator":{"pubkey":"0x%s"}}`, id, id)
+		}
+		_, _ = fmt.Fprintf(w, `{"finalized":true,"data":[%s]}`, strings.Join(rows, ","))
+	})
+	c.maxValidators = 2
+	validators := []uint64{1, 2, 3, 4, 5}
+
+	rewards, err := c.GetAttestationRewardsMap(context.Background(), 10, validators)
+	require.NoError(t, err)
+	require.Len(t, rewards, 5)
+	for _, v := range validators {
+		require.Equal(t, v, rewards[v].ValidatorIndex.Uint64())
+	}
+	resp, err := c.GetAttestationRewards(context.Background(), 11, validators)
+	require.NoError(t, err)
+	require.Len(t, resp.Data.IdealRewards, 1)
+	require.True(t, resp.ExecutionOptimistic, "any optimistic chunk makes the merged response optimistic")
+
+	vals, err := c.GetValidatorsAtSlot(context.Background(), 64, validators)
+	require.NoError(t, err)
+	require.Len(t, vals, 5)
+	for i, v := range vals {
+		require.Equal(t, validators[i], v.Index.Uint64())
+	}
+
+	require.ElementsMatch(t, []string{
+		"POST 1,2", "POST 3,4", "POST 5",
+		"POST 1,2", "POST 3,4", "POST 5",
+		"GET 1,2", "GET 3,4", "GET 5",
+	}, batches)
+}
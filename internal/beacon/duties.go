@@ -6,8 +6,31 @@ import (
 )
 
 // GetAttesterDuties fetches attestation duties for validators in an epoch.
-// The request body contains the list of validator indices.
+// The request body contains the list of validator indices, sent in chunks of
+// beacon_api.max_validators_per_request. Chunks answered across a reorg that changed the
+// dependent_root would mix two shufflings, so that is an error.
 func (c *Client) GetAttesterDuties(ctx context.Context, epoch uint64, validatorIndices []uint64) (*AttesterDutiesResponse, error) {
+	var out *AttesterDutiesResponse
+	for _, ids := range c.chunks(validatorIndices) {
+		resp, err := c.getAttesterDuties(ctx, epoch, ids)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = resp
+			continue
+		}
+		if resp.DependentRoot != out.DependentRoot {
+			err := fmt.Errorf("dependent_root changed between chunks (%s, %s)", out.DependentRoot, resp.DependentRoot)
+			return nil, requestError("get attester duties", err).epoch(epoch).validators(len(validatorIndices))
+		}
+		out.Data = append(out.Data, resp.Data...)
+		out.ExecutionOptimistic = out.ExecutionOptimistic || resp.ExecutionOptimistic
+	}
+	return out, nil
+}
+
+func (c *Client) getAttesterDuties(ctx context.Context, epoch uint64, validatorIndices []uint64) (*AttesterDutiesResponse, error) {
 	path := fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch)
 
 	// Convert to string slice for JSON encoding
@@ -18,7 +41,10 @@ func (c *Client) GetAttesterDuties(ctx context.Context, epoch uint64, validatorI
 
 	var resp AttesterDutiesResponse
 	if err := c.post(ctx, path, indices, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get attester duties for epoch %d: %w", epoch, err)
+		return nil, requestError("get attester duties", err).epoch(epoch).validators(len(validatorIndices))
+	}
+	if resp.Data == nil {
+		return nil, &MalformedResponseError{Path: path, Reason: "data is null"}
 	}
 
 	return &resp, nil
@@ -40,6 +66,56 @@ func (c *Client) GetAttesterDutiesMap(ctx context.Context, epoch uint64, validat
 	return duties, nil
 }
 
+// GetProposerDuties fetches the block proposers of every slot in epoch. Nodes serve the current epoch
+// (and some the next); the dependent_root changes when a reorg reshuffles the proposers.
+func (c *Client) GetProposerDuties(ctx context.Context, epoch uint64) (*ProposerDutiesResponse, error) {
+	path := fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch)
+
+	var resp ProposerDutiesResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, requestError("get proposer duties", err).epoch(epoch)
+	}
+	if resp.Data == nil {
+		return nil, &MalformedResponseError{Path: path, Reason: "data is null"}
+	}
+
+	return &resp, nil
+}
+
+// GetValidatorLiveness reports whether each validator was seen live (attested or proposed) in epoch.
+// Nodes only serve the current and previous epoch, so callers must query promptly.
+// Indices are sent in chunks of beacon_api.max_validators_per_request.
+func (c *Client) GetValidatorLiveness(ctx context.Context, epoch uint64, validatorIndices []uint64) ([]ValidatorLiveness, error) {
+	var out []ValidatorLiveness
+	for _, ids := range c.chunks(validatorIndices) {
+		part, err := c.getValidatorLiveness(ctx, epoch, ids)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, part...)
+	}
+	return out, nil
+}
+
+func (c *Client) getValidatorLiveness(ctx context.Context, epoch uint64, validatorIndices []uint64) ([]ValidatorLiveness, error) {
+	path := fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch)
+
+	indices := make([]string, len(validatorIndices))
+	for i, idx := range validatorIndices {
+		indices[i] = fmt.Sprintf("%d", idx)
+	}
+
+	var resp ValidatorLivenessResponse
+	if err := c.post(ctx, path, indices, &resp); err != nil {
+		return nil, requestError("get validator liveness", err).epoch(epoch).validators(len(validatorIndices))
+	}
+	if resp.Data == nil {
+		return nil, &MalformedResponseError{Path: path, Reason: "data is null"}
+	}
+
+	return resp.Data, nil
+}
+
 // SlotToEpoch converts a slot number to an epoch number.
 // There are 32 slots per epoch.
 func SlotToEpoch(slot uint64) uint64 {
@@ -0,0 +1,171 @@
+package beacon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/pkg/backoff"
+)
+
+// Event topics of GET /eth/v1/events consumed by pauli.
+const (
+	TopicBlock             = "block"
+	TopicAttestation       = "attestation"
+	TopicSingleAttestation = "single_attestation"
+	TopicChainReorg        = "chain_reorg"
+)
+
+// Event is one server-sent event from the node's event stream; Data is the topic's JSON payload.
+type Event struct {
+	Topic string
+	Data  json.RawMessage
+}
+
+// BlockEvent is the data of a "block" event (a block was imported, not yet finalized).
+type BlockEvent struct {
+	Slot                Uint64Str `json:"slot"`
+	Block               string    `json:"block"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
+// ChainReorgEvent is the data of a "chain_reorg" event (the node's head moved to another branch).
+type ChainReorgEvent struct {
+	Slot         Uint64Str `json:"slot"`
+	Depth        Uint64Str `json:"depth"`
+	OldHeadBlock string    `json:"old_head_block"`
+	NewHeadBlock string    `json:"new_head_block"`
+	Epoch        Uint64Str `json:"epoch"`
+}
+
+// SingleAttestation is the data of a "single_attestation" event (Electra+ unaggregated gossip attestation).
+type SingleAttestation struct {
+	CommitteeIndex Uint64Str            `json:"committee_index"`
+	AttesterIndex  Uint64Str            `json:"attester_index"`
+	Data           BlockAttestationData `json:"data"`
+}
+
+// SubscribeEvents streams GET /eth/v1/events?topics=... and calls handle for every event until the stream
+// ends or ctx is done. It always returns a non-nil error (ctx.Err() on cancellation). The stream has no
+// request timeout and bypasses the rate limiter; reconnecting is up to the caller (see EventStream).
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string, handle func(Event)) error {
+	path := "/eth/v1/events?topics=" + url.QueryEscape(strings.Join(topics, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+c.paths.rewrite(path), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create event stream request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.apiKey != "" {
+		req.Header.Set("x-api-key", c.apiKey)
+	}
+
+	stream := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := stream.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("event stream request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := c.readBody(io.LimitReader(resp.Body, int64(c.errorBodyMax)), path)
+		return &HTTPResponseError{StatusCode: resp.StatusCode, Path: path, Body: string(body)}
+	}
+
+	err = readEvents(resp.Body, handle)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	return fmt.Errorf("event stream ended: %w", err)
+}
+
+// readEvents parses a text/event-stream body, dispatching an Event at each blank line.
+func readEvents(r io.Reader, handle func(Event)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	var topic string
+	var data strings.Builder
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if topic != "" && data.Len() > 0 {
+				handle(Event{Topic: topic, Data: json.RawMessage(data.String())})
+			}
+			topic = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment / keep-alive.
+		case strings.HasPrefix(line, "event:"):
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return sc.Err()
+}
+
+// EventStream keeps an event subscription open, reconnecting with exponential backoff. Events seen while
+// disconnected are lost; OnReconnect lets the consumer fill that gap (e.g. by indexing skipped slots).
+type EventStream struct {
+	Client *Client
+	Topics []string
+	Log    zerolog.Logger
+	// OnReconnect, if set, runs after every successful reconnect (not the first connect), before events flow.
+	OnReconnect func(ctx context.Context)
+	// Backoff between attempts; zero values use 1s initial and 1m max.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Run subscribes and dispatches events to handle until ctx is done.
+func (s *EventStream) Run(ctx context.Context, handle func(Event)) {
+	cfg := backoff.DefaultConfig()
+	cfg.InitialDelay, cfg.MaxDelay = time.Second, time.Minute
+	if s.MinBackoff > 0 {
+		cfg.InitialDelay = s.MinBackoff
+	}
+	if s.MaxBackoff > 0 {
+		cfg.MaxDelay = s.MaxBackoff
+	}
+	b := backoff.New(cfg)
+
+	connected := false
+	for {
+		received := false
+		reconnect := connected
+		err := s.Client.SubscribeEvents(ctx, s.Topics, func(ev Event) {
+			if !received {
+				received = true
+				b.Reset()
+				if reconnect && s.OnReconnect != nil {
+					s.OnReconnect(ctx)
+				}
+			}
+			handle(ev)
+		})
+		if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+			return
+		}
+		connected = connected || received
+		s.Log.Warn().Err(err).Strs("topics", s.Topics).Msg("beacon event stream disconnected; reconnecting")
+		if !b.Wait(ctx) {
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadEvents_ParsesStream(t *testing.T) {
+	stream := ": keep-alive\n\n" +
+		"event: block\ndata: {\"slot\":\"10\",\"block\":\"0xab\",\"execution_optimistic\":false}\n\n" +
+		"event: attestation\ndata: {\"aggregation_bits\":\"0x03\",\n" +
+		"data: \"data\":{\"slot\":\"9\",\"index\":\"1\"}}\n\n" +
+		"event: block\n\n"
+	var got []Event
+	require.NoError(t, readEvents(strings.NewReader(stream), func(ev Event) { got = append(got, ev) }))
+	require.Len(t, got, 2)
+	require.Equal(t, TopicBlock, got[0].Topic)
+	require.JSONEq(t, `{"slot":"10","block":"0xab","execution_optimistic":false}`, string(got[0].Data))
+	require.Equal(t, TopicAttestation, got[1].Topic)
+	require.JSONEq(t, `{"aggregation_bits":"0x03","data":{"slot":"9","index":"1"}}`, string(got[1].Data))
+}
+
+func TestEventStream_ReconnectsAndReportsGap(t *testing.T) {
+	var conns atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/events", r.URL.Path)
+		require.Equal(t, "block", r.URL.Query().Get("topics"))
+		n := conns.Add(1)
+		if n == 2 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: block\ndata: {\"slot\":\"%d\",\"block\":\"0x00\"}\n\n", n)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var reconnects atomic.Int32
+	slots := make(chan uint64, 8)
+	s := &EventStream{
+		Client:      c,
+		Topics:      []string{TopicBlock},
+		Log:         zerolog.Nop(),
+		OnReconnect: func(context.Context) { reconnects.Add(1) },
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx, func(ev Event) {
+			var b BlockEvent
+			require.NoError(t, json.Unmarshal(ev.Data, &b))
+			slots <- b.Slot.Uint64()
+		})
+	}()
+
+	require.Equal(t, uint64(1), <-slots)
+	require.Equal(t, uint64(3), <-slots, "failed attempt is retried")
+	cancel()
+	<-done
+	require.GreaterOrEqual(t, reconnects.Load(), int32(1))
+}
@@ -4,6 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
 )
 
 // HTTPResponseError is returned for Beacon API responses that are not HTTP 200
@@ -21,8 +25,253 @@ func (e *HTTPResponseError) Error() string {
 	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
 }
 
+// DecodeError is returned when a 200 response body cannot be decoded into the expected type
+// (truncated JSON, an HTML error page from a proxy, an empty body). Body holds the start of the raw
+// response, capped at http.error_body_max_bytes.
+type DecodeError struct {
+	Path        string
+	StatusCode  int
+	ContentType string
+	BodySize    int
+	Body        string
+	Err         error
+}
+
+func (e *DecodeError) Error() string {
+	if e == nil {
+		return ""
+	}
+	if e.BodySize == 0 {
+		return fmt.Sprintf("decode %s: empty response body (status %d)", e.Path, e.StatusCode)
+	}
+	return fmt.Sprintf("decode %s: %v (status %d, content-type %q, %d bytes): %s",
+		e.Path, e.Err, e.StatusCode, e.ContentType, e.BodySize, e.Body)
+}
+
+func (e *DecodeError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// MalformedResponseError is returned when a 200 response decodes but lacks data the caller cannot do
+// without (data: null, zero genesis time, a validator other than the one requested), instead of
+// continuing with zero values.
+type MalformedResponseError struct {
+	Path   string
+	Reason string
+}
+
+func (e *MalformedResponseError) Error() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("malformed response from %s: %s", e.Path, e.Reason)
+}
+
+// IsMalformedResponse reports whether err is or wraps a MalformedResponseError.
+func IsMalformedResponse(err error) bool {
+	var me *MalformedResponseError
+	return errors.As(err, &me)
+}
+
+// ResponseTooLargeError is returned when a response body exceeds http.max_response_bytes.
+type ResponseTooLargeError struct {
+	Path  string
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("response from %s exceeds %d bytes (http.max_response_bytes)", e.Path, e.Limit)
+}
+
+// IsResponseTooLarge reports whether err is or wraps a ResponseTooLargeError.
+func IsResponseTooLarge(err error) bool {
+	var te *ResponseTooLargeError
+	return errors.As(err, &te)
+}
+
+// IsDecodeError reports whether err is or wraps a DecodeError.
+func IsDecodeError(err error) bool {
+	var de *DecodeError
+	return errors.As(err, &de)
+}
+
+// truncateBody returns at most max bytes of b as a string, marking truncation with "...".
+func truncateBody(b []byte, max int) string {
+	if max <= 0 || len(b) <= max {
+		return string(b)
+	}
+	return string(b[:max]) + "..."
+}
+
 // IsNotFound reports whether err is or wraps an HTTPResponseError with status 404.
 func IsNotFound(err error) bool {
 	var he *HTTPResponseError
 	return errors.As(err, &he) && he.StatusCode == http.StatusNotFound
 }
+
+// ErrStatePruned is matched (errors.Is) by errors for historical states the node no longer keeps.
+var ErrStatePruned = errors.New("beacon node has pruned this historical state; serving it needs an archive node " +
+	"(e.g. Lighthouse --reconstruct-historic-states, Teku --data-storage-mode=archive, Nimbus --history=archive, " +
+	"Prysm --slots-per-archive-point=32)")
+
+// StatePrunedError is returned when a request for a historical state fails because the node pruned it.
+// It matches ErrStatePruned and wraps the underlying HTTPResponseError.
+type StatePrunedError struct {
+	StateID string
+	Err     error
+}
+
+func (e *StatePrunedError) Error() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("state %s: %v (%v)", e.StateID, ErrStatePruned, e.Err)
+}
+
+func (e *StatePrunedError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	return []error{ErrStatePruned, e.Err}
+}
+
+// IsStatePruned reports whether err is or wraps ErrStatePruned.
+func IsStatePruned(err error) bool {
+	return errors.Is(err, ErrStatePruned)
+}
+
+// asStatePruned returns a StatePrunedError for err when it is a missing-state response to a request for
+// a historical stateID (a slot or state root), and err unchanged otherwise. Clients word it differently
+// ("State not found", "beacon state ... pruned", "missing state") and use 404, 400 or 500.
+func asStatePruned(stateID string, err error) error {
+	switch stateID {
+	case "head", "finalized", "justified", "genesis":
+		return err
+	}
+	var he *HTTPResponseError
+	if !errors.As(err, &he) {
+		return err
+	}
+	switch he.StatusCode {
+	case http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError:
+	default:
+		return err
+	}
+	body := strings.ToLower(he.Body)
+	if !strings.Contains(body, "state") {
+		return err
+	}
+	for _, hint := range []string{"not found", "pruned", "missing", "not available", "unavailable", "historic"} {
+		if strings.Contains(body, hint) {
+			return &StatePrunedError{StateID: stateID, Err: err}
+		}
+	}
+	return err
+}
+
+// RequestError names what a failed beacon call was about (validator, epoch, slot or block/state ID), so
+// a logged error identifies the failing work without cross-referencing the job. Unset fields are nil,
+// zero or empty. Use LogErrorContext to add the fields to a log event.
+type RequestError struct {
+	Op         string // e.g. "get attestation rewards"
+	Validator  *uint64
+	Validators int // validators in a bulk request
+	Epoch      *uint64
+	Slot       *uint64
+	ID         string // non-numeric state or block ID: head, finalized, a root
+	Err        error
+}
+
+func (e *RequestError) Error() string {
+	if e == nil {
+		return ""
+	}
+	var fields []string
+	if e.Epoch != nil {
+		fields = append(fields, fmt.Sprintf("epoch %d", *e.Epoch))
+	}
+	if e.Slot != nil {
+		fields = append(fields, fmt.Sprintf("slot %d", *e.Slot))
+	}
+	if e.ID != "" {
+		fields = append(fields, e.ID)
+	}
+	if e.Validator != nil {
+		fields = append(fields, fmt.Sprintf("validator %d", *e.Validator))
+	}
+	if e.Validators > 0 {
+		fields = append(fields, fmt.Sprintf("%d validators", e.Validators))
+	}
+	if len(fields) == 0 {
+		return fmt.Sprintf("failed to %s: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("failed to %s (%s): %v", e.Op, strings.Join(fields, ", "), e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// requestError starts a RequestError for op; chain epoch, at, validator and validators to fill it.
+func requestError(op string, err error) *RequestError {
+	return &RequestError{Op: op, Err: err}
+}
+
+func (e *RequestError) epoch(epoch uint64) *RequestError {
+	e.Epoch = &epoch
+	return e
+}
+
+// at records a state or block ID: a slot number as Slot, anything else as ID.
+func (e *RequestError) at(id string) *RequestError {
+	if slot, err := strconv.ParseUint(id, 10, 64); err == nil {
+		e.Slot = &slot
+	} else {
+		e.ID = id
+	}
+	return e
+}
+
+func (e *RequestError) validator(index uint64) *RequestError {
+	e.Validator = &index
+	return e
+}
+
+func (e *RequestError) validators(n int) *RequestError {
+	e.Validators = n
+	return e
+}
+
+// LogErrorContext adds the fields of the RequestError in err's chain, if any, to ev.
+func LogErrorContext(ev *zerolog.Event, err error) *zerolog.Event {
+	var re *RequestError
+	if !errors.As(err, &re) {
+		return ev
+	}
+	ev = ev.Str("beacon_op", re.Op)
+	if re.Epoch != nil {
+		ev = ev.Uint64("epoch", *re.Epoch)
+	}
+	if re.Slot != nil {
+		ev = ev.Uint64("slot", *re.Slot)
+	}
+	if re.ID != "" {
+		ev = ev.Str("beacon_id", re.ID)
+	}
+	if re.Validator != nil {
+		ev = ev.Uint64("validator_index", *re.Validator)
+	}
+	if re.Validators > 0 {
+		ev = ev.Int("validators", re.Validators)
+	}
+	return ev
+}
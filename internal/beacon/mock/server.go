@@ -0,0 +1,367 @@
+// Package mock serves a fake Beacon Node API on an httptest.Server for tests: configurable genesis,
+// sync status, head, finality, validators, attester duties and attestation rewards, plus scripted
+// responses (429s, 503s, malformed bodies, any status) queued per route ahead of the normal answer.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+)
+
+// Route names one endpoint family for Script and Requests.
+type Route string
+
+const (
+	RouteGenesis             Route = "genesis"              // GET /eth/v1/beacon/genesis
+	RouteSyncing             Route = "syncing"              // GET /eth/v1/node/syncing
+	RouteHeaders             Route = "headers"              // GET /eth/v1/beacon/headers/{block_id}
+	RouteFinalityCheckpoints Route = "finality_checkpoints" // GET /eth/v1/beacon/states/{state_id}/finality_checkpoints
+	RouteValidators          Route = "validators"           // GET /eth/v1/beacon/states/{state_id}/validators[/{id}]
+	RouteAttesterDuties      Route = "attester_duties"      // POST /eth/v1/validator/duties/attester/{epoch}
+	RouteAttestationRewards  Route = "attestation_rewards"  // POST /eth/v1/beacon/rewards/attestations/{epoch}
+)
+
+// Response is one scripted reply.
+type Response struct {
+	Status int
+	Body   string
+	Header http.Header
+}
+
+// TooManyRequests is a 429, which the client retries with backoff.
+func TooManyRequests() Response {
+	return Response{Status: http.StatusTooManyRequests, Body: `{"code":429,"message":"Too Many Requests"}`}
+}
+
+// Unavailable is a 503, which the client retries with backoff.
+func Unavailable() Response {
+	return Response{Status: http.StatusServiceUnavailable, Body: `{"code":503,"message":"Service Unavailable"}`}
+}
+
+// Malformed is a 200 whose body is truncated JSON.
+func Malformed() Response {
+	return Response{Status: http.StatusOK, Body: `{"data":[{"index":"1","bal`}
+}
+
+// NotFound is a 404 with a Beacon API error body.
+func NotFound(message string) Response {
+	body, _ := json.Marshal(map[string]any{"code": http.StatusNotFound, "message": message})
+	return Response{Status: http.StatusNotFound, Body: string(body)}
+}
+
+// JSON is a 200 with v encoded as the body.
+func JSON(v any) Response {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("mock: encode response: %v", err))
+	}
+	return Response{Status: http.StatusOK, Body: string(body)}
+}
+
+type duties struct {
+	dependentRoot string
+	duties        []beacon.AttesterDuty
+}
+
+// Server is a fake beacon node. The zero state is a synced node at slot 0 with mainnet genesis,
+// no validators, no duties and no finalized rewards. All methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	genesisTime time.Time
+	syncing     beacon.SyncingResponse
+	headSlot    uint64
+	finalized   uint64
+	validators  []beacon.Validator
+	duties      map[uint64]duties
+	rewards     map[uint64][]beacon.AttestationReward
+	scripts     map[Route][]Response
+	requests    map[Route]int
+}
+
+// New starts a Server that is closed when t finishes.
+func New(t testing.TB) *Server {
+	s := &Server{
+		genesisTime: time.Unix(1606824023, 0).UTC(),
+		duties:      make(map[uint64]duties),
+		rewards:     make(map[uint64][]beacon.AttestationReward),
+		scripts:     make(map[Route][]Response),
+		requests:    make(map[Route]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Config returns a configuration pointing at s with rate limiting out of the way, one retry and
+// response caching off, so every scripted response is requested.
+func (s *Server) Config() *config.Config {
+	return &config.Config{
+		BeaconNodeURL: s.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP: config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1, ErrorBodyMaxBytes: 512,
+			Cache: config.BeaconCacheConf{Disabled: true}},
+	}
+}
+
+// Client returns a beacon client for s built from Config.
+func (s *Server) Client() *beacon.Client {
+	return beacon.NewClient(s.Config())
+}
+
+// SetGenesis sets the genesis time served by /eth/v1/beacon/genesis.
+func (s *Server) SetGenesis(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.genesisTime = t
+}
+
+// SetSyncing sets the node's /eth/v1/node/syncing answer.
+func (s *Server) SetSyncing(headSlot, syncDistance uint64, isSyncing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncing.Data.HeadSlot = beacon.Uint64Str(headSlot)
+	s.syncing.Data.SyncDistance = beacon.Uint64Str(syncDistance)
+	s.syncing.Data.IsSyncing = isSyncing
+}
+
+// SetHead sets the head slot returned for the "head" block header.
+func (s *Server) SetHead(slot uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headSlot = slot
+}
+
+// SetFinalizedEpoch sets the finalized checkpoint epoch.
+func (s *Server) SetFinalizedEpoch(epoch uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finalized = epoch
+}
+
+// SetValidators replaces the validator set served for every state.
+func (s *Server) SetValidators(validators ...beacon.Validator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validators = slices.Clone(validators)
+}
+
+// SetAttesterDuties sets epoch's attester duties and dependent root. Calling it again for an epoch
+// with another root simulates a reorg that reshuffled the duties.
+func (s *Server) SetAttesterDuties(epoch uint64, dependentRoot string, d ...beacon.AttesterDuty) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.duties[epoch] = duties{dependentRoot: dependentRoot, duties: slices.Clone(d)}
+}
+
+// SetAttestationRewards sets epoch's total attestation rewards. Epochs without rewards answer 404
+// "missing state", like a node asked for an epoch that is not finalized yet.
+func (s *Server) SetAttestationRewards(epoch uint64, rewards ...beacon.AttestationReward) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rewards[epoch] = slices.Clone(rewards)
+}
+
+// Script queues responses for route; each request to it consumes the next one before the
+// configured state is consulted.
+func (s *Server) Script(route Route, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[route] = append(s.scripts[route], responses...)
+}
+
+// Requests returns how many requests route has received, scripted ones included.
+func (s *Server) Requests(route Route) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[route]
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	route, param, ok := match(r.URL.Path)
+	if !ok {
+		write(w, NotFound("NOT_FOUND: route not served by mock"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[route]++
+	if queue := s.scripts[route]; len(queue) > 0 {
+		s.scripts[route] = queue[1:]
+		write(w, queue[0])
+		return
+	}
+
+	switch route {
+	case RouteGenesis:
+		var resp beacon.GenesisResponse
+		resp.Data.GenesisTime = beacon.Uint64Str(s.genesisTime.Unix())
+		resp.Data.GenesisForkVersion = "0x00000000"
+		write(w, JSON(resp))
+	case RouteSyncing:
+		write(w, JSON(s.syncing))
+	case RouteHeaders:
+		slot := s.headSlot
+		if param != "head" {
+			n, err := strconv.ParseUint(param, 10, 64)
+			if err != nil || n > s.headSlot {
+				write(w, NotFound("NOT_FOUND: beacon block "+param))
+				return
+			}
+			slot = n
+		}
+		var resp beacon.BlockHeaderResponse
+		resp.Data.Root = fmt.Sprintf("0x%064x", slot)
+		resp.Data.Canonical = true
+		resp.Data.Header.Message.Slot = beacon.Uint64Str(slot)
+		write(w, JSON(resp))
+	case RouteFinalityCheckpoints:
+		var resp beacon.FinalityCheckpointsResponse
+		resp.Data.Finalized.Epoch = beacon.Uint64Str(s.finalized)
+		write(w, JSON(resp))
+	case RouteValidators:
+		s.serveValidators(w, r, param)
+	case RouteAttesterDuties:
+		epoch, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			write(w, Response{Status: http.StatusBadRequest, Body: `{"code":400,"message":"invalid epoch"}`})
+			return
+		}
+		d := s.duties[epoch]
+		want := requestedIndices(r)
+		out := make([]beacon.AttesterDuty, 0, len(d.duties))
+		for _, duty := range d.duties {
+			if want == nil || want[duty.ValidatorIndex.Uint64()] {
+				out = append(out, duty)
+			}
+		}
+		write(w, JSON(beacon.AttesterDutiesResponse{DependentRoot: d.dependentRoot, Data: out}))
+	case RouteAttestationRewards:
+		epoch, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			write(w, Response{Status: http.StatusBadRequest, Body: `{"code":400,"message":"invalid epoch"}`})
+			return
+		}
+		rewards, ok := s.rewards[epoch]
+		if !ok {
+			write(w, NotFound(fmt.Sprintf("NOT_FOUND: missing state for epoch %d", epoch)))
+			return
+		}
+		want := requestedIndices(r)
+		out := make([]beacon.AttestationReward, 0, len(rewards))
+		for _, rw := range rewards {
+			if want == nil || want[rw.ValidatorIndex.Uint64()] {
+				out = append(out, rw)
+			}
+		}
+		write(w, JSON(beacon.AttestationRewardsResponse{Data: beacon.AttestationRewardsData{
+			IdealRewards: []beacon.AttestationReward{},
+			TotalRewards: out,
+		}}))
+	}
+}
+
+// serveValidators answers the validators list (filtered by ?id= and ?status=) or, with id set, one validator.
+func (s *Server) serveValidators(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" {
+		for _, v := range s.validators {
+			if strconv.FormatUint(v.Index.Uint64(), 10) == id || v.Validator.Pubkey == id {
+				write(w, JSON(beacon.ValidatorResponse{Data: v}))
+				return
+			}
+		}
+		write(w, NotFound("NOT_FOUND: validator "+id))
+		return
+	}
+	ids := listParam(r, "id")
+	statuses := listParam(r, "status")
+	out := make([]beacon.Validator, 0, len(s.validators))
+	for _, v := range s.validators {
+		if ids != nil && !slices.Contains(ids, strconv.FormatUint(v.Index.Uint64(), 10)) && !slices.Contains(ids, v.Validator.Pubkey) {
+			continue
+		}
+		if statuses != nil && !slices.Contains(statuses, v.Status) {
+			continue
+		}
+		out = append(out, v)
+	}
+	write(w, JSON(beacon.ValidatorsResponse{Data: out}))
+}
+
+// match maps a request path to its route and the trailing path parameter (block id, epoch or validator id).
+func match(path string) (Route, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case path == "/eth/v1/beacon/genesis":
+		return RouteGenesis, "", true
+	case path == "/eth/v1/node/syncing":
+		return RouteSyncing, "", true
+	case len(parts) == 5 && strings.HasPrefix(path, "/eth/v1/beacon/headers/"):
+		return RouteHeaders, parts[4], true
+	case len(parts) == 6 && strings.HasPrefix(path, "/eth/v1/beacon/states/") && parts[5] == "finality_checkpoints":
+		return RouteFinalityCheckpoints, "", true
+	case len(parts) == 6 && strings.HasPrefix(path, "/eth/v1/beacon/states/") && parts[5] == "validators":
+		return RouteValidators, "", true
+	case len(parts) == 7 && strings.HasPrefix(path, "/eth/v1/beacon/states/") && parts[5] == "validators":
+		return RouteValidators, parts[6], true
+	case len(parts) == 6 && strings.HasPrefix(path, "/eth/v1/validator/duties/attester/"):
+		return RouteAttesterDuties, parts[5], true
+	case len(parts) == 6 && strings.HasPrefix(path, "/eth/v1/beacon/rewards/attestations/"):
+		return RouteAttestationRewards, parts[5], true
+	}
+	return "", "", false
+}
+
+// requestedIndices decodes a POST body of validator indices ([] or no body means all, returned as nil).
+func requestedIndices(r *http.Request) map[uint64]bool {
+	body, _ := io.ReadAll(r.Body)
+	var ids []beacon.Uint64Str
+	if len(body) == 0 || json.Unmarshal(body, &ids) != nil || len(ids) == 0 {
+		return nil
+	}
+	out := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		out[id.Uint64()] = true
+	}
+	return out
+}
+
+// listParam splits a comma-separated (or repeated) query parameter; nil when absent.
+func listParam(r *http.Request, name string) []string {
+	var out []string
+	for _, v := range r.URL.Query()[name] {
+		out = append(out, strings.Split(v, ",")...)
+	}
+	return out
+}
+
+func write(w http.ResponseWriter, resp Response) {
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, resp.Body)
+}
@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+)
+
+func TestServer_scriptedRetries(t *testing.T) {
+	s := New(t)
+	s.SetGenesis(time.Unix(1700000000, 0))
+	client := s.Client()
+
+	s.Script(RouteGenesis, TooManyRequests())
+	g, err := client.GetGenesis(context.Background())
+	require.NoError(t, err, "one 429 is retried")
+	require.Equal(t, uint64(1700000000), g.Data.GenesisTime.Uint64())
+	require.Equal(t, 2, s.Requests(RouteGenesis))
+
+	s.Script(RouteGenesis, Unavailable(), Unavailable())
+	_, err = client.GetGenesis(context.Background())
+	require.Error(t, err, "retries exhausted")
+
+	s.Script(RouteGenesis, Malformed())
+	_, err = client.GetGenesis(context.Background())
+	require.True(t, beacon.IsDecodeError(err))
+
+	_, err = client.GetGenesis(context.Background())
+	require.NoError(t, err, "script drained")
+}
+
+func TestServer_state(t *testing.T) {
+	s := New(t)
+	client := s.Client()
+	ctx := context.Background()
+
+	s.SetHead(321)
+	s.SetFinalizedEpoch(8)
+	s.SetSyncing(321, 0, false)
+	head, err := client.GetHeadSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(321), head)
+	finalized, err := client.FinalizedEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), finalized)
+	synced, err := client.IsNodeSynced(ctx)
+	require.NoError(t, err)
+	require.True(t, synced)
+
+	var v1, v2 beacon.Validator
+	v1.Index, v1.Status = 1, "active_ongoing"
+	v2.Index, v2.Status = 2, "exited_unslashed"
+	s.SetValidators(v1, v2)
+	vals, err := client.GetValidators(ctx, "head", []uint64{2})
+	require.NoError(t, err)
+	require.Len(t, vals, 1)
+	require.Equal(t, "exited_unslashed", vals[0].Status)
+	active, err := client.GetValidatorsByStatus(ctx, "head", []string{"active_ongoing"})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+
+	s.SetAttesterDuties(9, "0xaa", beacon.AttesterDuty{ValidatorIndex: 1, Slot: 290}, beacon.AttesterDuty{ValidatorIndex: 2, Slot: 291})
+	duties, err := client.GetAttesterDuties(ctx, 9, []uint64{2})
+	require.NoError(t, err)
+	require.Equal(t, "0xaa", duties.DependentRoot)
+	require.Len(t, duties.Data, 1)
+	s.SetAttesterDuties(9, "0xbb", beacon.AttesterDuty{ValidatorIndex: 2, Slot: 295})
+	duties, err = client.GetAttesterDuties(ctx, 9, []uint64{2})
+	require.NoError(t, err)
+	require.Equal(t, "0xbb", duties.DependentRoot, "reorged root")
+	require.Equal(t, uint64(295), duties.Data[0].Slot.Uint64())
+
+	_, err = client.GetAttestationRewards(ctx, 9, nil)
+	require.True(t, beacon.IsNotFound(err), "rewards before finalization")
+	s.SetAttestationRewards(9, beacon.AttestationReward{ValidatorIndex: 1, Head: 10})
+	rewards, err := client.GetAttestationRewards(ctx, 9, nil)
+	require.NoError(t, err)
+	require.Len(t, rewards.Data.TotalRewards, 1)
+}
@@ -0,0 +1,46 @@
+package beacon
+
+import (
+	"strings"
+
+	"github.com/tharun/pauli/internal/config"
+)
+
+// pathRewriter maps the standard /eth/vN/... paths used by the client onto a gateway's layout
+// (beacon_api.path_prefix and beacon_api.endpoint_versions).
+type pathRewriter struct {
+	prefix   string
+	versions map[string]string
+}
+
+func newPathRewriter(cfg config.BeaconAPIConf) pathRewriter {
+	versions := make(map[string]string, len(cfg.EndpointVersions))
+	for endpoint, v := range cfg.EndpointVersions {
+		versions[strings.Trim(endpoint, "/")] = v
+	}
+	return pathRewriter{prefix: cfg.PathPrefix, versions: versions}
+}
+
+// rewrite returns path with the configured prefix and, when an override matches, the endpoint's version.
+// Paths not of the form /eth/vN/... only get the prefix.
+func (p pathRewriter) rewrite(path string) string {
+	if len(p.versions) == 0 {
+		return p.prefix + path
+	}
+	rest, ok := strings.CutPrefix(path, "/eth/")
+	if !ok {
+		return p.prefix + path
+	}
+	version, endpoint, ok := strings.Cut(rest, "/")
+	if !ok {
+		return p.prefix + path
+	}
+	endpointPath, _, _ := strings.Cut(endpoint, "?")
+	best := -1
+	for key, v := range p.versions {
+		if len(key) > best && (endpointPath == key || strings.HasPrefix(endpointPath, key+"/")) {
+			best, version = len(key), v
+		}
+	}
+	return p.prefix + "/eth/" + version + "/" + endpoint
+}
@@ -0,0 +1,87 @@
+package beacon
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RawResponse is a successful reward or validator status response body as the node returned it,
+// before decoding (see RecordRawResponses).
+type RawResponse struct {
+	Method string
+	Path   string
+	// Endpoint is Path without the query and with the epoch, block or state id replaced by a
+	// placeholder, e.g. /eth/v1/beacon/rewards/attestations/{epoch}.
+	Endpoint string
+	// Epoch is set for attestation rewards; Slot is set when the block or state id is a slot number.
+	Epoch *uint64
+	Slot  *uint64
+	// Request is the JSON request body (the validator indices of a POST), nil for GET.
+	Request   []byte
+	Body      []byte
+	FetchedAt time.Time
+}
+
+// RecordRawResponses calls record with every successful reward and validator status response, once per
+// network call (coalesced callers share one). record runs on the requesting goroutine, so it must not
+// block; it may keep the slices. Call it before the client is used.
+func (c *Client) RecordRawResponses(record func(RawResponse)) {
+	c.recordRaw = record
+}
+
+// recordRawResponse passes a successful response to the raw response recorder, if any and if path is audited.
+func (c *Client) recordRawResponse(method, path string, request []byte, out *response) {
+	if c.recordRaw == nil {
+		return
+	}
+	raw, ok := newRawResponse(method, path)
+	if !ok {
+		return
+	}
+	raw.Request = request
+	raw.Body = out.body
+	raw.FetchedAt = time.Now().UTC()
+	c.recordRaw(raw)
+}
+
+// newRawResponse classifies path; ok is false for endpoints that are not recorded.
+func newRawResponse(method, path string) (raw RawResponse, ok bool) {
+	raw = RawResponse{Method: method, Path: path}
+	p, _, _ := strings.Cut(path, "?")
+	switch {
+	case strings.HasPrefix(p, "/eth/v1/beacon/rewards/attestations/"):
+		id := strings.TrimPrefix(p, "/eth/v1/beacon/rewards/attestations/")
+		raw.Endpoint = "/eth/v1/beacon/rewards/attestations/{epoch}"
+		raw.Epoch = parseID(id)
+	case strings.HasPrefix(p, "/eth/v1/beacon/rewards/blocks/"):
+		raw.Endpoint = "/eth/v1/beacon/rewards/blocks/{block_id}"
+		raw.Slot = parseID(strings.TrimPrefix(p, "/eth/v1/beacon/rewards/blocks/"))
+	case strings.HasPrefix(p, "/eth/v1/beacon/rewards/sync_committee/"):
+		raw.Endpoint = "/eth/v1/beacon/rewards/sync_committee/{block_id}"
+		raw.Slot = parseID(strings.TrimPrefix(p, "/eth/v1/beacon/rewards/sync_committee/"))
+	case strings.HasPrefix(p, "/eth/v1/beacon/states/"):
+		id, rest, _ := strings.Cut(strings.TrimPrefix(p, "/eth/v1/beacon/states/"), "/")
+		switch {
+		case rest == "validators":
+			raw.Endpoint = "/eth/v1/beacon/states/{state_id}/validators"
+		case strings.HasPrefix(rest, "validators/"):
+			raw.Endpoint = "/eth/v1/beacon/states/{state_id}/validators/{validator_id}"
+		default:
+			return raw, false
+		}
+		raw.Slot = parseID(id)
+	default:
+		return raw, false
+	}
+	return raw, true
+}
+
+// parseID returns a numeric epoch, slot or state id, or nil for names such as head and roots.
+func parseID(id string) *uint64 {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
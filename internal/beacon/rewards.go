@@ -9,7 +9,31 @@ import (
 // GetAttestationRewards fetches attestation rewards for validators in an epoch.
 // Nodes typically require the epoch to be finalized (past fork-choice finalized checkpoint)
 // before state is available; callers should gate on finalized epoch where appropriate.
-func (c *Client) GetAttestationRewards(ctx context.Context, epoch uint64, validatorIndices []uint64) (*AttestationRewardsData, error) {
+// The full envelope is returned so callers can inspect execution_optimistic.
+// Indices are sent in chunks of beacon_api.max_validators_per_request; total rewards are merged and
+// the ideal rewards (per effective balance, the same in every chunk) are kept from the first chunk.
+func (c *Client) GetAttestationRewards(ctx context.Context, epoch uint64, validatorIndices []uint64) (*AttestationRewardsResponse, error) {
+	var out *AttestationRewardsResponse
+	for _, ids := range c.chunks(validatorIndices) {
+		resp, err := c.getAttestationRewards(ctx, epoch, ids)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = resp
+			continue
+		}
+		out.Data.TotalRewards = append(out.Data.TotalRewards, resp.Data.TotalRewards...)
+		if out.Data.IdealRewards == nil {
+			out.Data.IdealRewards = resp.Data.IdealRewards
+		}
+		out.ExecutionOptimistic = out.ExecutionOptimistic || resp.ExecutionOptimistic
+		out.Finalized = out.Finalized && resp.Finalized
+	}
+	return out, nil
+}
+
+func (c *Client) getAttestationRewards(ctx context.Context, epoch uint64, validatorIndices []uint64) (*AttestationRewardsResponse, error) {
 	path := fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch)
 
 	// Convert to string slice for JSON encoding
@@ -20,23 +44,27 @@ func (c *Client) GetAttestationRewards(ctx context.Context, epoch uint64, valida
 
 	var resp AttestationRewardsResponse
 	if err := c.post(ctx, path, indices, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get attestation rewards for epoch %d: %w", epoch, err)
+		return nil, requestError("get attestation rewards", err).epoch(epoch).validators(len(validatorIndices))
+	}
+	if resp.Data.TotalRewards == nil && resp.Data.IdealRewards == nil {
+		return nil, &MalformedResponseError{Path: path, Reason: "total_rewards and ideal_rewards are missing"}
 	}
 
-	return &resp.Data, nil
+	return &resp, nil
 }
 
 // GetBlockRewards fetches aggregate proposer rewards for a beacon block.
 // blockID may be a slot string, "head", "finalized", genesis, or a block root (0x-prefixed hex).
-func (c *Client) GetBlockRewards(ctx context.Context, blockID string) (*BlockRewardsData, error) {
+// The full envelope is returned so callers can inspect execution_optimistic.
+func (c *Client) GetBlockRewards(ctx context.Context, blockID string) (*BlockRewardsResponse, error) {
 	path := fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%s", url.PathEscape(blockID))
 
 	var resp BlockRewardsResponse
 	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get block rewards for %s: %w", blockID, err)
+		return nil, requestError("get block rewards", err).at(blockID)
 	}
 
-	return &resp.Data, nil
+	return &resp, nil
 }
 
 // GetBlockExecutionBlockNumber returns the execution payload block_number for a consensus block, if present.
@@ -45,7 +73,7 @@ func (c *Client) GetBlockExecutionBlockNumber(ctx context.Context, blockID strin
 
 	var raw blockV2ExecutionNumberJSON
 	if err := c.get(ctx, path, &raw); err != nil {
-		return nil, err
+		return nil, requestError("get execution block number", err).at(blockID)
 	}
 	ep := raw.Data.Message.Body.ExecutionPayload
 	if ep == nil {
@@ -64,8 +92,27 @@ type SyncCommitteeRewardsResult struct {
 
 // GetSyncCommitteeRewards fetches per-validator sync committee rewards for a beacon block.
 // blockID may be a slot string, "head", "finalized", genesis, or a block root (0x-prefixed hex).
-// When validatorIndices is nil or empty, the request body is [] and the node returns every sync committee member.
+// When validatorIndices is nil or empty, the request body is [] and the node returns every sync committee member;
+// otherwise indices are sent in chunks of beacon_api.max_validators_per_request.
 func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockID string, validatorIndices []uint64) (*SyncCommitteeRewardsResult, error) {
+	var out *SyncCommitteeRewardsResult
+	for _, ids := range c.chunks(validatorIndices) {
+		res, err := c.getSyncCommitteeRewards(ctx, blockID, ids)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = res
+			continue
+		}
+		out.Rows = append(out.Rows, res.Rows...)
+		out.ExecutionOptimistic = out.ExecutionOptimistic || res.ExecutionOptimistic
+		out.Finalized = out.Finalized && res.Finalized
+	}
+	return out, nil
+}
+
+func (c *Client) getSyncCommitteeRewards(ctx context.Context, blockID string, validatorIndices []uint64) (*SyncCommitteeRewardsResult, error) {
 	path := fmt.Sprintf("/eth/v1/beacon/rewards/sync_committee/%s", url.PathEscape(blockID))
 
 	indices := make([]string, len(validatorIndices))
@@ -75,7 +122,7 @@ func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockID string, va
 
 	var resp SyncCommitteeRewardsResponse
 	if err := c.post(ctx, path, indices, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get sync committee rewards for %s: %w", blockID, err)
+		return nil, requestError("get sync committee rewards", err).at(blockID).validators(len(validatorIndices))
 	}
 
 	return &SyncCommitteeRewardsResult{
@@ -92,9 +139,9 @@ func (c *Client) GetAttestationRewardsMap(ctx context.Context, epoch uint64, val
 		return nil, err
 	}
 
-	rewards := make(map[uint64]*AttestationReward, len(resp.TotalRewards))
-	for i := range resp.TotalRewards {
-		reward := &resp.TotalRewards[i]
+	rewards := make(map[uint64]*AttestationReward, len(resp.Data.TotalRewards))
+	for i := range resp.Data.TotalRewards {
+		reward := &resp.Data.TotalRewards[i]
 		rewards[reward.ValidatorIndex.Uint64()] = reward
 	}
 
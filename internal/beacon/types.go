@@ -1,7 +1,9 @@
 package beacon
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Beacon API response wrapper types
@@ -31,6 +33,25 @@ type Validator struct {
 	} `json:"validator"`
 }
 
+// FarFutureEpoch is the spec's FAR_FUTURE_EPOCH (2^64-1): an activation, exit or withdrawable epoch
+// that has not been scheduled yet.
+const FarFutureEpoch = ^uint64(0)
+
+// IsFarFutureEpoch reports whether epoch is the unscheduled sentinel.
+func IsFarFutureEpoch(epoch uint64) bool {
+	return epoch == FarFutureEpoch
+}
+
+// OptionalEpoch returns epoch, or nil when it is FarFutureEpoch, so "not set" is stored as NULL
+// rather than as 18446744073709551615.
+func OptionalEpoch(epoch Uint64Str) *uint64 {
+	if IsFarFutureEpoch(epoch.Uint64()) {
+		return nil
+	}
+	e := epoch.Uint64()
+	return &e
+}
+
 // ValidatorResponse is the response from /eth/v1/beacon/states/{state_id}/validators/{validator_id}.
 type ValidatorResponse = APIResponse[Validator]
 
@@ -55,6 +76,29 @@ type AttesterDutiesResponse struct {
 	Data                []AttesterDuty `json:"data"`
 }
 
+// ProposerDuty represents a block proposal duty for a validator.
+type ProposerDuty struct {
+	Pubkey         string    `json:"pubkey"`
+	ValidatorIndex Uint64Str `json:"validator_index"`
+	Slot           Uint64Str `json:"slot"`
+}
+
+// ProposerDutiesResponse is the response from /eth/v1/validator/duties/proposer/{epoch}.
+type ProposerDutiesResponse struct {
+	DependentRoot       string         `json:"dependent_root"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	Data                []ProposerDuty `json:"data"`
+}
+
+// ValidatorLiveness is one entry from POST /eth/v1/validator/liveness/{epoch}.
+type ValidatorLiveness struct {
+	Index  Uint64Str `json:"index"`
+	IsLive bool      `json:"is_live"`
+}
+
+// ValidatorLivenessResponse is the response from /eth/v1/validator/liveness/{epoch}.
+type ValidatorLivenessResponse = APIResponse[[]ValidatorLiveness]
+
 // AttestationReward represents rewards for a single validator's attestation.
 type AttestationReward struct {
 	ValidatorIndex Uint64Str `json:"validator_index"`
@@ -151,19 +195,23 @@ type Checkpoint struct {
 // FinalityCheckpointsResponse is the response from /eth/v1/beacon/states/{state_id}/finality_checkpoints.
 type FinalityCheckpointsResponse = APIResponse[FinalityCheckpoints]
 
-// Uint64Str handles JSON numbers that are encoded as strings.
+// Uint64Str handles JSON numbers that are encoded as strings. "" and whitespace-only strings (emitted by
+// some nodes for absent optional fields) decode as 0 instead of failing the whole response; null is a
+// no-op, as for encoding/json's own types.
 type Uint64Str uint64
 
 func (u *Uint64Str) UnmarshalJSON(data []byte) error {
-	// Remove quotes if present
-	s := string(data)
-	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
-		s = s[1 : len(s)-1]
+	if isJSONNull(data) {
+		return nil
+	}
+	s, ok := numericJSON(data)
+	if !ok {
+		*u = 0
+		return nil
 	}
-
 	v, err := strconv.ParseUint(s, 10, 64)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid uint64 %s: %w", data, err)
 	}
 	*u = Uint64Str(v)
 	return nil
@@ -173,24 +221,40 @@ func (u Uint64Str) Uint64() uint64 {
 	return uint64(u)
 }
 
-// Int64Str handles JSON signed integers that are encoded as strings.
+// Int64Str handles JSON signed integers that are encoded as strings. Absent values decode as 0 like Uint64Str.
 type Int64Str int64
 
 func (i *Int64Str) UnmarshalJSON(data []byte) error {
-	// Remove quotes if present
-	s := string(data)
-	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
-		s = s[1 : len(s)-1]
+	if isJSONNull(data) {
+		return nil
+	}
+	s, ok := numericJSON(data)
+	if !ok {
+		*i = 0
+		return nil
 	}
-
 	v, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid int64 %s: %w", data, err)
 	}
 	*i = Int64Str(v)
 	return nil
 }
 
+func isJSONNull(data []byte) bool {
+	return strings.TrimSpace(string(data)) == "null"
+}
+
+// numericJSON strips quotes and surrounding whitespace from a JSON number or numeric string.
+// ok is false for empty or whitespace-only strings.
+func numericJSON(data []byte) (s string, ok bool) {
+	s = strings.TrimSpace(string(data))
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s, s != ""
+}
+
 func (i Int64Str) Int64() int64 {
 	return int64(i)
 }
@@ -0,0 +1,103 @@
+package beacon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint64Str_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		in   string
+		want uint64
+	}{
+		{`"42"`, 42},
+		{`42`, 42},
+		{`" 7 "`, 7},
+		{`""`, 0},
+		{`"   "`, 0},
+	}
+	for _, tc := range cases {
+		u := Uint64Str(99)
+		require.NoError(t, json.Unmarshal([]byte(tc.in), &u), tc.in)
+		require.Equal(t, tc.want, u.Uint64(), tc.in)
+	}
+
+	u := Uint64Str(99)
+	require.NoError(t, json.Unmarshal([]byte(`null`), &u))
+	require.Equal(t, uint64(99), u.Uint64(), "null is a no-op")
+
+	err := json.Unmarshal([]byte(`"abc"`), &u)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"abc"`)
+	require.Error(t, json.Unmarshal([]byte(`"-1"`), &u))
+}
+
+func TestInt64Str_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{`"-5"`, -5},
+		{`""`, 0},
+		{`" "`, 0},
+	}
+	for _, tc := range cases {
+		i := Int64Str(99)
+		require.NoError(t, json.Unmarshal([]byte(tc.in), &i), tc.in)
+		require.Equal(t, tc.want, i.Int64(), tc.in)
+	}
+	i := Int64Str(-3)
+	require.NoError(t, json.Unmarshal([]byte(`null`), &i))
+	require.Equal(t, int64(-3), i.Int64(), "null is a no-op")
+	require.Error(t, json.Unmarshal([]byte(`"1.5"`), &i))
+}
+
+func TestUint64Str_emptyFieldDoesNotAbortDecode(t *testing.T) {
+	var v Validator
+	require.NoError(t, json.Unmarshal([]byte(`{"index":"3","balance":"","validator":{"exit_epoch":null}}`), &v))
+	require.Equal(t, uint64(3), v.Index.Uint64())
+	require.Equal(t, uint64(0), v.Balance.Uint64())
+}
+
+func TestNumericStrings_fieldMatrix(t *testing.T) {
+	type row struct {
+		U Uint64Str `json:"u"`
+		I Int64Str  `json:"i"`
+	}
+	cases := []struct {
+		name  string
+		value string
+		wantU uint64
+		wantI int64
+	}{
+		{"quoted number", `"12"`, 12, 12},
+		{"bare number", `12`, 12, 12},
+		{"null", `null`, 0, 0},
+		{"empty string", `""`, 0, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var r row
+			require.NoError(t, json.Unmarshal([]byte(`{"u":`+tc.value+`,"i":`+tc.value+`}`), &r))
+			require.Equal(t, tc.wantU, r.U.Uint64())
+			require.Equal(t, tc.wantI, r.I.Int64())
+		})
+	}
+
+	// Some clients send null for exit_epoch of active validators instead of FAR_FUTURE_EPOCH.
+	var v Validator
+	require.NoError(t, json.Unmarshal([]byte(`{"index":"1","validator":{"exit_epoch":null,"withdrawable_epoch":"18446744073709551615"}}`), &v))
+	require.Equal(t, uint64(0), v.Validator.ExitEpoch.Uint64())
+	require.Equal(t, ^uint64(0), v.Validator.WithdrawableEpoch.Uint64())
+}
+
+func TestOptionalEpoch(t *testing.T) {
+	require.True(t, IsFarFutureEpoch(18446744073709551615))
+	require.False(t, IsFarFutureEpoch(0))
+	require.Nil(t, OptionalEpoch(Uint64Str(FarFutureEpoch)))
+	e := OptionalEpoch(194048)
+	require.NotNil(t, e)
+	require.Equal(t, uint64(194048), *e)
+}
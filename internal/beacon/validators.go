@@ -4,12 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 )
 
-// MaxValidatorIDsPerGetValidators limits how many validator indices are sent in one
-// GET /eth/v1/beacon/states/.../validators?id=... request to avoid oversized URLs.
-const MaxValidatorIDsPerGetValidators = 100
-
 // GetValidator fetches a single validator's state.
 // stateID can be "head", "genesis", "finalized", "justified", a slot number, or a state root.
 // validatorID can be a validator index or a public key.
@@ -18,7 +15,10 @@ func (c *Client) GetValidator(ctx context.Context, stateID string, validatorID u
 
 	var resp ValidatorResponse
 	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get validator %d: %w", validatorID, err)
+		return nil, requestError("get validator", asStatePruned(stateID, err)).at(stateID).validator(validatorID)
+	}
+	if resp.Data.Index.Uint64() != validatorID || resp.Data.Validator.Pubkey == "" {
+		return nil, &MalformedResponseError{Path: path, Reason: fmt.Sprintf("requested validator %d, got index %d with pubkey %q", validatorID, resp.Data.Index.Uint64(), resp.Data.Validator.Pubkey)}
 	}
 
 	return &resp.Data, nil
@@ -37,7 +37,10 @@ func (c *Client) GetValidatorByPubkey(ctx context.Context, stateID, pubkey strin
 
 	var resp ValidatorResponse
 	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get validator %s: %w", pubkey, err)
+		return nil, requestError("get validator "+pubkey, err).at(stateID)
+	}
+	if resp.Data.Validator.Pubkey == "" {
+		return nil, &MalformedResponseError{Path: path, Reason: "validator has no pubkey"}
 	}
 
 	return &resp.Data, nil
@@ -46,6 +49,35 @@ func (c *Client) GetValidatorByPubkey(ctx context.Context, stateID, pubkey strin
 // GetValidators fetches multiple validators' states.
 // If validatorIDs is empty, returns all validators.
 func (c *Client) GetValidators(ctx context.Context, stateID string, validatorIDs []uint64) ([]Validator, error) {
+	resp, err := c.GetValidatorsResponse(ctx, stateID, validatorIDs)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetValidatorsResponse is GetValidators returning the full envelope, including execution_optimistic.
+// Validator IDs are sent in chunks of beacon_api.max_validators_per_request; the merged response is
+// execution optimistic if any chunk was and finalized only if every chunk was.
+func (c *Client) GetValidatorsResponse(ctx context.Context, stateID string, validatorIDs []uint64) (*ValidatorsResponse, error) {
+	var out *ValidatorsResponse
+	for _, ids := range c.chunks(validatorIDs) {
+		resp, err := c.getValidatorsResponse(ctx, stateID, ids)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = resp
+			continue
+		}
+		out.Data = append(out.Data, resp.Data...)
+		out.ExecutionOptimistic = out.ExecutionOptimistic || resp.ExecutionOptimistic
+		out.Finalized = out.Finalized && resp.Finalized
+	}
+	return out, nil
+}
+
+func (c *Client) getValidatorsResponse(ctx context.Context, stateID string, validatorIDs []uint64) (*ValidatorsResponse, error) {
 	path := fmt.Sprintf("/eth/v1/beacon/states/%s/validators", stateID)
 
 	// Add validator IDs as query parameters if specified
@@ -61,10 +93,13 @@ func (c *Client) GetValidators(ctx context.Context, stateID string, validatorIDs
 
 	var resp ValidatorsResponse
 	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get validators: %w", err)
+		return nil, requestError("get validators", asStatePruned(stateID, err)).at(stateID).validators(len(validatorIDs))
+	}
+	if resp.Data == nil {
+		return nil, &MalformedResponseError{Path: path, Reason: "data is null"}
 	}
 
-	return resp.Data, nil
+	return &resp, nil
 }
 
 // GetValidatorsAllAtSlot fetches every validator's state at slot (single beacon request).
@@ -74,25 +109,12 @@ func (c *Client) GetValidatorsAllAtSlot(ctx context.Context, slot uint64) ([]Val
 }
 
 // GetValidatorsAtSlot fetches the given validators' state at slot in one or more
-// chunked GET requests (see MaxValidatorIDsPerGetValidators).
+// chunked GET requests (see beacon_api.max_validators_per_request).
 func (c *Client) GetValidatorsAtSlot(ctx context.Context, slot uint64, validatorIDs []uint64) ([]Validator, error) {
 	if len(validatorIDs) == 0 {
 		return nil, nil
 	}
-	stateID := strconv.FormatUint(slot, 10)
-	var out []Validator
-	for i := 0; i < len(validatorIDs); i += MaxValidatorIDsPerGetValidators {
-		end := i + MaxValidatorIDsPerGetValidators
-		if end > len(validatorIDs) {
-			end = len(validatorIDs)
-		}
-		part, err := c.GetValidators(ctx, stateID, validatorIDs[i:end])
-		if err != nil {
-			return nil, fmt.Errorf("validators at slot %d (chunk %d-%d): %w", slot, i, end, err)
-		}
-		out = append(out, part...)
-	}
-	return out, nil
+	return c.GetValidators(ctx, strconv.FormatUint(slot, 10), validatorIDs)
 }
 
 // GetValidatorsByStatus fetches validators filtered by status.
@@ -125,7 +147,7 @@ func (c *Client) GetFinalityCheckpoints(ctx context.Context, stateID string) (*F
 
 	var resp FinalityCheckpointsResponse
 	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get finality checkpoints: %w", err)
+		return nil, requestError("get finality checkpoints", err).at(stateID)
 	}
 
 	return &resp.Data, nil
@@ -149,7 +171,7 @@ func (c *Client) GetBlockHeader(ctx context.Context, blockID string) (*BlockHead
 
 	var resp BlockHeaderResponse
 	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, fmt.Errorf("failed to get block header: %w", err)
+		return nil, requestError("get block header", err).at(blockID)
 	}
 
 	return &resp, nil
@@ -172,10 +194,27 @@ func (c *Client) GetGenesis(ctx context.Context) (*GenesisResponse, error) {
 	if err := c.get(ctx, path, &resp); err != nil {
 		return nil, fmt.Errorf("failed to get genesis: %w", err)
 	}
+	if resp.Data.GenesisTime == 0 {
+		c.cache.drop(path)
+		return nil, &MalformedResponseError{Path: path, Reason: "genesis_time is zero or missing"}
+	}
+	c.cache.setGenesis(time.Unix(int64(resp.Data.GenesisTime), 0))
 
 	return &resp, nil
 }
 
+// GetSpec fetches the chain configuration (/eth/v1/config/spec) as the node returns it: constant
+// names to decimal or hex strings.
+func (c *Client) GetSpec(ctx context.Context) (map[string]string, error) {
+	var resp struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := c.get(ctx, "/eth/v1/config/spec", &resp); err != nil {
+		return nil, fmt.Errorf("failed to get spec: %w", err)
+	}
+	return resp.Data, nil
+}
+
 // GetSyncStatus fetches the node's sync status.
 func (c *Client) GetSyncStatus(ctx context.Context) (*SyncingResponse, error) {
 	path := "/eth/v1/node/syncing"
@@ -188,6 +227,19 @@ func (c *Client) GetSyncStatus(ctx context.Context) (*SyncingResponse, error) {
 	return &resp, nil
 }
 
+// GetNodeVersion returns the node's client and version string (e.g. "Lighthouse/v5.1.3-3058b96/x86_64-linux").
+func (c *Client) GetNodeVersion(ctx context.Context) (string, error) {
+	var resp struct {
+		Data struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	if err := c.get(ctx, "/eth/v1/node/version", &resp); err != nil {
+		return "", fmt.Errorf("failed to get node version: %w", err)
+	}
+	return resp.Data.Version, nil
+}
+
 // IsNodeSynced checks if the beacon node is fully synced.
 func (c *Client) IsNodeSynced(ctx context.Context) (bool, error) {
 	status, err := c.GetSyncStatus(ctx)
@@ -0,0 +1,84 @@
+package beacon
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Endpoint classes for rate limit weighting (rate_limit.weights).
+const (
+	EndpointValidators = "validators" // states/{state_id}/validators, the bulk validator list
+	EndpointValidator  = "validator"  // states/{state_id}/validators/{validator_id}
+	EndpointCommittees = "committees" // states/{state_id}/committees
+	EndpointRewards    = "rewards"    // beacon/rewards/*
+	EndpointBlocks     = "blocks"     // v2 beacon/blocks/{block_id}
+	EndpointDuties     = "duties"     // validator/duties/* and validator/liveness/*
+	EndpointLight      = "light"      // everything else: genesis, headers, spec, syncing, finality checkpoints
+)
+
+// defaultWeights are the rate limiter tokens one request of each class takes. The bulk validator list
+// reads the whole state on the node, so it is by far the heaviest.
+var defaultWeights = map[string]int{
+	EndpointValidators: 10,
+	EndpointValidator:  1,
+	EndpointCommittees: 5,
+	EndpointRewards:    5,
+	EndpointBlocks:     2,
+	EndpointDuties:     2,
+	EndpointLight:      1,
+}
+
+// endpointWeights maps endpoint classes to rate limiter tokens per request.
+type endpointWeights map[string]int
+
+// newEndpointWeights applies overrides to the defaults. A weight above burst is capped at burst,
+// since the limiter can never grant more tokens than its bucket holds.
+func newEndpointWeights(overrides map[string]int, burst int) endpointWeights {
+	w := make(endpointWeights, len(defaultWeights))
+	for class, n := range defaultWeights {
+		w[class] = n
+	}
+	for class, n := range overrides {
+		if _, ok := defaultWeights[class]; !ok {
+			log.Warn().Str("class", class).Msg("rate_limit.weights: unknown endpoint class ignored")
+			continue
+		}
+		w[class] = n
+	}
+	for class, n := range w {
+		if n > burst {
+			w[class] = burst
+		}
+	}
+	return w
+}
+
+// weight returns the tokens a request to path takes.
+func (w endpointWeights) weight(path string) int {
+	return w[endpointClass(path)]
+}
+
+// endpointClass classifies a standard (not rewritten) beacon API path.
+func endpointClass(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 5 && parts[0] == "eth" && parts[2] == "beacon" && parts[3] == "states":
+		switch {
+		case len(parts) == 6 && parts[5] == "validators":
+			return EndpointValidators
+		case len(parts) == 7 && parts[5] == "validators":
+			return EndpointValidator
+		case len(parts) == 6 && parts[5] == "committees":
+			return EndpointCommittees
+		}
+	case len(parts) >= 4 && parts[0] == "eth" && parts[2] == "beacon" && parts[3] == "rewards":
+		return EndpointRewards
+	case len(parts) >= 4 && parts[0] == "eth" && parts[2] == "beacon" && parts[3] == "blocks":
+		return EndpointBlocks
+	case len(parts) >= 4 && parts[0] == "eth" && parts[2] == "validator" && (parts[3] == "duties" || parts[3] == "liveness"):
+		return EndpointDuties
+	}
+	return EndpointLight
+}
@@ -11,17 +11,28 @@ type BlockchainNetwork struct {
 	pollingIntervalSlots int
 	slotsPerEpoch        uint64
 	genesisTime          time.Time
+	// genesisValidatorsRoot is the root the node must report ("" = unchecked); strict fails startup on a mismatch.
+	genesisValidatorsRoot string
+	strict                bool
 }
 
 // NewBlockchainNetwork builds network timing from application config (genesis time is set later via SetGenesisTime).
 func NewBlockchainNetwork(c *Config) *BlockchainNetwork {
 	return &BlockchainNetwork{
-		slotDuration:         c.SlotDuration(),
-		pollingIntervalSlots: c.PollingIntervalSlots,
-		slotsPerEpoch:        SlotsPerEpoch(),
+		slotDuration:          c.SlotDuration(),
+		pollingIntervalSlots:  c.PollingIntervalSlots,
+		slotsPerEpoch:         SlotsPerEpoch(),
+		genesisValidatorsRoot: c.ExpectedGenesisValidatorsRoot(),
+		strict:                c.StrictNetwork,
 	}
 }
 
+// ExpectedGenesisValidatorsRoot returns the configured chain identity ("" = not checked) and whether a
+// mismatch must stop startup (strict_network).
+func (n *BlockchainNetwork) ExpectedGenesisValidatorsRoot() (root string, strict bool) {
+	return n.genesisValidatorsRoot, n.strict
+}
+
 // SetGenesisTime sets the chain genesis wall time (from beacon genesis API).
 func (n *BlockchainNetwork) SetGenesisTime(t time.Time) {
 	n.genesisTime = t
@@ -32,6 +43,14 @@ func (n *BlockchainNetwork) GenesisTime() time.Time {
 	return n.genesisTime
 }
 
+// SlotTime returns the wall-clock start of slot (genesis + slot × slot duration); zero before SetGenesisTime.
+func (n *BlockchainNetwork) SlotTime(slot uint64) time.Time {
+	if n == nil || n.genesisTime.IsZero() {
+		return time.Time{}
+	}
+	return n.genesisTime.Add(time.Duration(slot) * n.slotDuration).UTC()
+}
+
 // SlotDuration returns wall duration of one consensus slot.
 func (n *BlockchainNetwork) SlotDuration() time.Duration {
 	return n.slotDuration
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockchainNetwork_SlotTime(t *testing.T) {
+	n := &BlockchainNetwork{slotDuration: 12 * time.Second}
+	if got := n.SlotTime(10); !got.IsZero() {
+		t.Fatalf("SlotTime before genesis = %v, want zero", got)
+	}
+
+	genesis := time.Unix(1606824023, 0)
+	n.SetGenesisTime(genesis)
+	want := genesis.Add(120 * time.Second).UTC()
+	if got := n.SlotTime(10); !got.Equal(want) {
+		t.Fatalf("SlotTime(10) = %v, want %v", got, want)
+	}
+
+	var nilNet *BlockchainNetwork
+	if got := nilNet.SlotTime(1); !got.IsZero() {
+		t.Fatalf("nil SlotTime = %v, want zero", got)
+	}
+}
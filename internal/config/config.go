@@ -3,8 +3,13 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
+	"github.com/rs/zerolog/log"
 	"gopkg.in/yaml.v3"
 )
 
@@ -12,6 +17,11 @@ import (
 type Config struct {
 	BeaconNodeURL string `yaml:"beacon_node_url"`
 	BeaconAPIKey  string `yaml:"beacon_api_key,omitempty"` // Optional API key for providers like Tatum
+	// BeaconAPI adapts endpoint paths for gateways (path prefix, per-endpoint API versions).
+	BeaconAPI BeaconAPIConf `yaml:"beacon_api"`
+	// BeaconConsistency compares beacon_node_url against reference nodes (e.g. other client
+	// implementations) and alerts when they disagree.
+	BeaconConsistency BeaconConsistencyConf `yaml:"beacon_consistency"`
 	// ExecutionNodeURL is optional JSON-RPC URL (e.g. http://localhost:8545). When set, the monitor
 	// fetches execution-layer priority fees for proposed blocks via eth_getBlockByNumber + eth_getBlockReceipts.
 	ExecutionNodeURL string `yaml:"execution_node_url,omitempty"`
@@ -22,17 +32,101 @@ type Config struct {
 	// or "none" / "off" to send no auth headers (bare JSON-RPC), even if execution_api_key is set.
 	ExecutionAuthHeader string `yaml:"execution_auth_header,omitempty"`
 	Validators          []uint64 `yaml:"validators"`
+	// ValidatorRanges adds inclusive index ranges to validators, e.g. [{from: 1000, to: 1999}].
+	ValidatorRanges []ValidatorRange `yaml:"validator_ranges,omitempty"`
+	// PriorityValidators are validators (from validators, validator_ranges or tenants) given high priority:
+	// they are polled every epoch even with validator_sampling, and go first in per-validator beacon
+	// requests. Every other validator has normal priority.
+	PriorityValidators []uint64 `yaml:"priority_validators,omitempty"`
+	// ValidatorSelect adds validators matched by pubkey prefix or withdrawal credentials; it is resolved
+	// against the head state when pauli starts and on SIGHUP reload.
+	ValidatorSelect ValidatorSelectConf `yaml:"validator_select"`
+	// PurgeRemovedValidators deletes per-validator rows (attestation duties, liveness) when an index is removed
+	// from validators on reload. Default keeps history.
+	PurgeRemovedValidators bool `yaml:"purge_removed_validators,omitempty"`
+	// Tenants stores each tenant's validators' rows in its own PostgreSQL schema; their validators are
+	// added to validators. Network-wide rows (blocks, indexer progress) stay in postgres.schema.
+	Tenants []TenantConf `yaml:"tenants,omitempty"`
+	// Network names the chain the beacon node is expected to serve (mainnet, sepolia, holesky or hoodi).
+	// Its genesis validators root is compared with the node's at startup; a mismatch is logged.
+	Network string `yaml:"network,omitempty"`
+	// GenesisValidatorsRoot is the expected genesis_validators_root, for chains without a network name
+	// (devnets). It takes precedence over network.
+	GenesisValidatorsRoot string `yaml:"genesis_validators_root,omitempty"`
+	// StrictNetwork refuses to start when the node's genesis validators root does not match network or
+	// genesis_validators_root, instead of only logging the mismatch.
+	StrictNetwork bool `yaml:"strict_network,omitempty"`
 	PollingIntervalSlots int      `yaml:"polling_interval_slots"`
 	// SlotDurationSeconds allows overriding the default 12s slot duration.
 	// For local devnets (e.g. kurtosis) you can set this to 2.
 	SlotDurationSeconds int           `yaml:"slot_duration_seconds,omitempty"`
 	WorkerPoolSize      int           `yaml:"worker_pool_size"`
+	// WorkerQueueSize is how many jobs may wait for a worker (default 2 × worker_pool_size). When it is
+	// full, realtime jobs are dropped (and retried on the next poll) instead of stalling the scheduler.
+	WorkerQueueSize int `yaml:"worker_queue_size"`
+	// WorkerPoolWarmStart starts this many workers and adds one whenever jobs are waiting, up to
+	// worker_pool_size (0 = start all). worker_pool_size itself is applied on SIGHUP reload.
+	WorkerPoolWarmStart int `yaml:"worker_pool_warm_start"`
+	// ShutdownTimeoutSeconds bounds how long shutdown waits for queued and running jobs to finish (default 30).
+	ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds"`
 	RateLimit           RateLimitConf `yaml:"rate_limit"`
 	HTTP                HTTPConf      `yaml:"http"`
-	// DatabaseDriver is optional; only "postgres" is supported (default when empty).
+	// DatabaseDriver is optional: "postgres" (default when empty) or "none" (discard rows; pair with output_jsonl).
 	DatabaseDriver string       `yaml:"database_driver,omitempty"`
 	Postgres       PostgresConf `yaml:"postgres"`
 	Backfill       BackfillConf `yaml:"backfill"`
+	// AttestationDuties indexes attester duties for watched validators and checks inclusion shortly after each duty slot.
+	AttestationDuties AttestationDutiesConf `yaml:"attestation_duties"`
+	// ProposerDuties tracks block proposals of watched validators: proposer reward or missed proposal.
+	ProposerDuties ProposerDutiesConf `yaml:"proposer_duties"`
+	// ValidatorSampling spreads per-validator duty and liveness polling of large fleets over a window.
+	ValidatorSampling ValidatorSamplingConf `yaml:"validator_sampling"`
+	// RewardsReconcile periodically re-indexes finalized epochs missing from storage.
+	RewardsReconcile RewardsReconcileConf `yaml:"rewards_reconcile"`
+	// JobDeadlineSlots bounds each async job: a job for head slot N is cancelled at the start of slot
+	// N+JobDeadlineSlots (or that many slots after it starts, for jobs about older slots). 0 = 64.
+	JobDeadlineSlots uint64 `yaml:"job_deadline_slots"`
+	// MaxSyncDistance skips realtime head processing while the node's sync_distance exceeds it
+	// (0 disables the check; a syncing node is always skipped when it is set).
+	MaxSyncDistance uint64 `yaml:"max_sync_distance"`
+	// PollWhileSyncing keeps processing head slots while the node reports is_syncing. By default the
+	// realtime runner checks /eth/v1/node/syncing every poll and pauses until the node is synced.
+	PollWhileSyncing bool `yaml:"poll_while_syncing"`
+	// RewardsReorgLookbackEpochs re-fetches balances and attestation rewards of the last this many
+	// finalized epochs after a reorg (a chain_reorg event, or attester duties changed by a reorg) and
+	// overwrites the stored rows. 0 (default) keeps finalized epochs as first indexed.
+	RewardsReorgLookbackEpochs uint64 `yaml:"rewards_reorg_lookback_epochs"`
+	// Jobs switches the realtime rewards and block jobs off for focused deployments.
+	Jobs JobsConf `yaml:"jobs"`
+	// Events subscribes to the beacon node's event stream for low-latency block and attestation sightings.
+	Events EventsConf `yaml:"events"`
+	// ValidatorLiveness stores the node's per-epoch liveness verdict for watched validators.
+	ValidatorLiveness ValidatorLivenessConf `yaml:"validator_liveness"`
+	// InactiveValidators stops per-validator polling for validators in a terminal status.
+	InactiveValidators InactiveValidatorsConf `yaml:"inactive_validators"`
+	// Storage.Backend is an alias for database_driver ("postgres" or "none").
+	Storage StorageConf `yaml:"storage,omitempty"`
+	// Metrics serves Prometheus metrics, including per-validator series for the validators list.
+	Metrics MetricsConf `yaml:"metrics"`
+	// Notifications configures alert delivery (always logged; optional webhook).
+	Notifications NotificationsConf `yaml:"notifications"`
+	// ResultLogLevels sets the level of per-validator result logs by event type (see ResultLogEvents),
+	// e.g. {attestation_included: off, attestation_missed: error}. Unlisted types keep their built-in
+	// level. Rows and metrics are written whatever the level.
+	ResultLogLevels map[string]string `yaml:"result_log_levels,omitempty"`
+	// RawResponses keeps the raw reward and validator status responses for audit (raw_responses table).
+	RawResponses RawResponsesConf `yaml:"raw_responses"`
+	// Archive copies aged epoch records to S3-compatible object storage (the cold tier).
+	Archive ArchiveConf `yaml:"archive"`
+	// Watchdog alerts when no indexing results have been produced for too long.
+	Watchdog WatchdogConf `yaml:"watchdog"`
+	// Report configures the pauli-report command.
+	Report ReportConf `yaml:"report"`
+	// SnapshotWrites controls how validator epoch records (snapshots) are written.
+	SnapshotWrites SnapshotWritesConf `yaml:"snapshot_writes"`
+	// OutputJSONL writes every saved row to stdout as one JSON line (see doc/jsonl-output.md).
+	// Operational logs move to stderr so stdout stays machine-readable.
+	OutputJSONL bool `yaml:"output_jsonl,omitempty"`
 }
 
 // BackfillConf configures the historical backfill runner (slot + epoch tracks).
@@ -47,6 +141,9 @@ type BackfillConf struct {
 	EpochsPerPass int     `yaml:"epochs_per_pass"`
 	PollDelayMs      int `yaml:"poll_delay_ms"`
 	IdlePollDelayMs  int `yaml:"idle_poll_delay_ms"`
+	// EpochConcurrency is how many epochs of a pass are fetched from the beacon node at once (default 1).
+	// Results are still saved and marked indexed in epoch order.
+	EpochConcurrency int `yaml:"epoch_concurrency"`
 }
 
 // PollDelay returns pacing between backfill passes while catching up.
@@ -65,10 +162,795 @@ func (b *BackfillConf) IdlePollDelay() time.Duration {
 	return time.Duration(b.IdlePollDelayMs) * time.Millisecond
 }
 
+// StorageConf selects the storage backend (alias for database_driver).
+type StorageConf struct {
+	Backend string `yaml:"backend"`
+}
+
+// RawResponsesConf configures audit storage of raw beacon responses: the gzip-compressed JSON of
+// attestation, block and sync committee rewards and of validator status, keyed by endpoint, epoch and
+// fetch time. Raw responses are large, so it is off by default and has its own retention.
+type RawResponsesConf struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxBodyBytes skips responses larger than this, uncompressed (default 8 MiB).
+	MaxBodyBytes int `yaml:"max_body_bytes"`
+	// TTLDays deletes stored responses older than this many days (default 7), independent of ttl_days.
+	TTLDays int `yaml:"ttl_days"`
+}
+
+// TTL returns how long raw responses are kept.
+func (r *RawResponsesConf) TTL() time.Duration {
+	return time.Duration(r.TTLDays) * 24 * time.Hour
+}
+
+// ArchiveConf configures archival of aged validator epoch records to an S3-compatible bucket as
+// gzip-compressed JSON lines, partitioned by validator and month.
+type ArchiveConf struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the S3 API URL, e.g. http://minio:9000; empty uses AWS S3 in Region.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Region signs requests (default us-east-1; most S3-compatible stores accept any).
+	Region          string `yaml:"region"`
+	Bucket          string `yaml:"bucket"`
+	Prefix          string `yaml:"prefix,omitempty"`
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	// PathStyle uses <endpoint>/<bucket>/<key> URLs (MinIO and most self-hosted stores need it).
+	PathStyle bool `yaml:"path_style"`
+	// AfterDays is how old an epoch must be before it is archived (default 30). Keep it below the
+	// database retention so rows are archived before they expire.
+	AfterDays int `yaml:"after_days"`
+	// IntervalMinutes is how often the archive job runs (default 60).
+	IntervalMinutes int `yaml:"interval_minutes"`
+}
+
+func (a *ArchiveConf) validate() error {
+	if !a.Enabled {
+		return nil
+	}
+	if a.Bucket == "" {
+		return fmt.Errorf("archive.bucket is required when archive is enabled")
+	}
+	if a.AccessKeyID == "" || a.SecretAccessKey == "" {
+		return fmt.Errorf("archive.access_key_id and archive.secret_access_key are required when archive is enabled")
+	}
+	if a.Endpoint != "" && !strings.HasPrefix(a.Endpoint, "http://") && !strings.HasPrefix(a.Endpoint, "https://") {
+		return fmt.Errorf("archive.endpoint %q must be an http(s) URL", a.Endpoint)
+	}
+	return nil
+}
+
+// MetricsConf configures the Prometheus /metrics endpoint.
+type MetricsConf struct {
+	Enabled bool `yaml:"enabled"`
+	// Listen is the HTTP listen address (default ":9090").
+	Listen string `yaml:"listen"`
+	// AggregateOnly exports sums/counts across validators instead of one series per validator index.
+	// Per-index series are meant for dozens to hundreds of validators; enable this for larger sets.
+	AggregateOnly bool `yaml:"aggregate_only"`
+	// RemoteWrite pushes the same series to a Prometheus remote-write endpoint (Grafana Cloud,
+	// VictoriaMetrics, Mimir) for deployments that cannot be scraped.
+	RemoteWrite RemoteWriteConf `yaml:"remote_write"`
+	// DisableScrape does not serve /metrics; only valid with remote_write.url.
+	DisableScrape bool `yaml:"disable_scrape"`
+}
+
+// RemoteWriteConf configures pushing metrics with the Prometheus remote-write protocol.
+type RemoteWriteConf struct {
+	// URL is the receiver's push endpoint, e.g. https://prometheus-prod-01.grafana.net/api/prom/push.
+	URL string `yaml:"url"`
+	// BearerToken, or Username and Password (basic auth), authenticate each push.
+	BearerToken string `yaml:"bearer_token,omitempty"`
+	Username    string `yaml:"username,omitempty"`
+	Password    string `yaml:"password,omitempty"`
+	// Headers are sent with every push (e.g. X-Scope-OrgID).
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Labels are added to every pushed series (default job: pauli), standing in for scrape target labels.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// IntervalSeconds is the push interval (default 60). TimeoutSeconds bounds one push (default 10).
+	IntervalSeconds int `yaml:"interval_seconds"`
+	TimeoutSeconds  int `yaml:"timeout_seconds"`
+}
+
+func (m *MetricsConf) validate() error {
+	rw := m.RemoteWrite
+	if rw.URL == "" {
+		if m.DisableScrape {
+			return fmt.Errorf("metrics.disable_scrape requires metrics.remote_write.url")
+		}
+		return nil
+	}
+	if !m.Enabled {
+		return fmt.Errorf("metrics.remote_write requires metrics.enabled")
+	}
+	if !strings.HasPrefix(rw.URL, "http://") && !strings.HasPrefix(rw.URL, "https://") {
+		return fmt.Errorf("metrics.remote_write.url %q must be an http(s) URL", rw.URL)
+	}
+	if rw.BearerToken != "" && rw.Username != "" {
+		return fmt.Errorf("metrics.remote_write: set bearer_token or username/password, not both")
+	}
+	return nil
+}
+
+// NotificationsConf configures alert destinations.
+type NotificationsConf struct {
+	// WebhookURL receives each alert as a JSON POST when set.
+	WebhookURL     string `yaml:"webhook_url,omitempty"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+	// CooldownSeconds collapses repeated alerts of the same type for the same validator
+	// into one reminder per window. 0 uses one hour; negative disables deduplication.
+	CooldownSeconds int `yaml:"cooldown_seconds"`
+	// Template (Go text/template over the alert) formats the message for the log and webhook.
+	Template string `yaml:"template,omitempty"`
+	// PagerDuty opens and resolves incidents via the Events API v2 when RoutingKey is set.
+	PagerDuty PagerDutyConf `yaml:"pagerduty"`
+	// Discord posts alerts as embeds to a Discord webhook when WebhookURL is set.
+	Discord DiscordConf `yaml:"discord"`
+}
+
+// DiscordConf configures the Discord webhook notifier.
+type DiscordConf struct {
+	WebhookURL string `yaml:"webhook_url,omitempty"`
+	// BatchSeconds collects alerts for this long into one message (0 = 2 seconds).
+	BatchSeconds int `yaml:"batch_seconds"`
+	// Template formats the embed description.
+	Template string `yaml:"template,omitempty"`
+}
+
+// BatchWindow returns how long Discord alerts are collected before sending.
+func (d *DiscordConf) BatchWindow() time.Duration {
+	if d.BatchSeconds <= 0 {
+		return 2 * time.Second
+	}
+	return time.Duration(d.BatchSeconds) * time.Second
+}
+
+// PagerDutyConf configures the PagerDuty Events API v2 notifier.
+type PagerDutyConf struct {
+	RoutingKey string `yaml:"routing_key,omitempty"`
+	// Severity maps an alert type (e.g. stale_data) to critical, error, warning or info.
+	// Unmapped types follow the alert's own severity; validator_slashed defaults to critical.
+	Severity map[string]string `yaml:"severity,omitempty"`
+	// Template formats the incident summary; empty prefixes the validator index to the message.
+	Template string `yaml:"template,omitempty"`
+}
+
+// Timeout returns the per-request timeout for outbound notifications.
+func (n *NotificationsConf) Timeout() time.Duration {
+	if n.TimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(n.TimeoutSeconds) * time.Second
+}
+
+// Cooldown returns the alert deduplication window (0 when disabled).
+func (n *NotificationsConf) Cooldown() time.Duration {
+	switch {
+	case n.CooldownSeconds < 0:
+		return 0
+	case n.CooldownSeconds == 0:
+		return time.Hour
+	}
+	return time.Duration(n.CooldownSeconds) * time.Second
+}
+
+// validateTemplates rejects notification templates that do not parse.
+func (n *NotificationsConf) validateTemplates() error {
+	for name, text := range map[string]string{
+		"notifications.template":           n.Template,
+		"notifications.pagerduty.template": n.PagerDuty.Template,
+		"notifications.discord.template":   n.Discord.Template,
+	} {
+		if _, err := template.New(name).Parse(text); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ReportConf configures currency conversion in pauli-report. Storage always keeps Gwei.
+type ReportConf struct {
+	// PriceURL returns the ETH price as JSON; when empty reports are ETH-only.
+	PriceURL string `yaml:"price_url,omitempty"`
+	// PriceField is the dot-separated path to the price in the response (e.g. "ethereum.usd").
+	PriceField string `yaml:"price_field,omitempty"`
+	// Currency labels the fiat column (default USD).
+	Currency string `yaml:"currency,omitempty"`
+	// PriceCacheSeconds is how long a fetched price is reused (0 = 300).
+	PriceCacheSeconds int `yaml:"price_cache_seconds"`
+}
+
+// PriceCacheTTL returns how long a fetched price is reused.
+func (r *ReportConf) PriceCacheTTL() time.Duration {
+	if r.PriceCacheSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(r.PriceCacheSeconds) * time.Second
+}
+
+// WatchdogConf configures the stale-data watchdog.
+type WatchdogConf struct {
+	Disabled bool `yaml:"disabled"`
+	// MaxSilenceSeconds is how long without a successful indexing job before alerting.
+	// 0 derives it from the poll interval (3 polls, at least 5 minutes).
+	MaxSilenceSeconds int `yaml:"max_silence_seconds"`
+	// FinalityLagEpochs raises a finality_lag alert when head epoch minus finalized epoch exceeds it.
+	// A healthy chain sits at 2. Default 4.
+	FinalityLagEpochs uint64 `yaml:"finality_lag_epochs"`
+}
+
+// JobsConf turns realtime job types off; all run by default. Attester duties and liveness checks have
+// their own switches (attestation_duties.enabled, validator_liveness.enabled).
+type JobsConf struct {
+	// DisableRewards skips the per-epoch balances and attestation rewards index (validator_epoch_records)
+	// and the post-reorg recompute. The finalized epoch is still fetched for the finality lag check.
+	DisableRewards bool `yaml:"disable_rewards"`
+	// DisableBlocks skips per-slot block indexing (blocks, proposer and sync committee rewards), also for
+	// block events.
+	DisableBlocks bool `yaml:"disable_blocks"`
+}
+
+// AttestationDutiesConf configures attester duty indexing for the validators list.
+type AttestationDutiesConf struct {
+	Enabled bool `yaml:"enabled"`
+	// InclusionDelaySlots is how many slots after a duty slot the realtime runner checks whether the
+	// attestation landed on-chain, scanning blocks duty_slot+1 .. duty_slot+InclusionDelaySlots.
+	InclusionDelaySlots uint64 `yaml:"inclusion_delay_slots"`
+	// MaxInclusionDelaySlots flags an included attestation as slow when it landed more than this many
+	// slots after its duty slot. It must fit in the inclusion_delay_slots window. Default from the
+	// committee preset: 1 on mainnet (the next block should carry it), 2 on minimal-preset devnets.
+	MaxInclusionDelaySlots uint64 `yaml:"max_inclusion_delay_slots"`
+	// TargetCommitteeSize and MaxCommitteeLength are the preset values used to flag implausible
+	// committee lengths in duties (0 = mainnet 128 / 2048; minimal-preset devnets use 4).
+	TargetCommitteeSize uint64 `yaml:"target_committee_size"`
+	MaxCommitteeLength  uint64 `yaml:"max_committee_length"`
+	// TimedChecks runs each duty slot's inclusion check on a timer at the end of its inclusion window
+	// instead of on the first realtime poll after it.
+	TimedChecks bool `yaml:"timed_checks"`
+	// Filter limits which duties are stored and checked for inclusion. Empty stores every duty.
+	Filter DutyFilterConf `yaml:"filter"`
+	// VerifyCommittees cross-checks every fetched duty against the epoch's committees
+	// (/eth/v1/beacon/states/head/committees) and stores disagreements as duty mismatches,
+	// for nodes whose duties endpoint is suspect. Costs one extra request per epoch.
+	VerifyCommittees bool `yaml:"verify_committees"`
+	// Anomalies checks that each watched validator is in exactly one committee per epoch.
+	Anomalies DutyAnomaliesConf `yaml:"anomalies"`
+	// Source selects where duties come from: "duties" (default, the attester duties endpoint) or
+	// "committees" (derived from all of the epoch's committees in one request; the duties endpoint is
+	// still asked for the dependent_root and disagreements are stored as duty mismatches).
+	Source string `yaml:"source"`
+}
+
+// ProposerDutiesConf configures proposal tracking for the validators list.
+type ProposerDutiesConf struct {
+	Enabled bool `yaml:"enabled"`
+	// CheckDelaySlots is how many slots after a proposal slot the realtime runner checks for the block
+	// and its reward (default 2), so a late block or a one-slot reorg at the slot has settled. Each
+	// result is checked again once the epoch is finalized.
+	CheckDelaySlots uint64 `yaml:"check_delay_slots"`
+}
+
+// ValidatorSamplingConf splits the validators list into groups polled in turn, one group per epoch, for
+// fleets too large to poll attester duties, inclusion and liveness for every validator every epoch.
+// Network-wide epoch rewards and proposer duties still cover every validator.
+type ValidatorSamplingConf struct {
+	// WindowSlots is how long a full rotation takes: the validators are split into window_slots/32
+	// groups by their position in index order, and each validator is polled in exactly one epoch of
+	// every window. Must be a multiple of 32; 0 (default) polls every validator every epoch.
+	WindowSlots uint64 `yaml:"window_slots"`
+}
+
+// Groups returns how many groups the validators are split into (1 when sampling is off).
+func (s ValidatorSamplingConf) Groups() uint64 {
+	if s.WindowSlots < SlotsPerEpoch() {
+		return 1
+	}
+	return s.WindowSlots / SlotsPerEpoch()
+}
+
+func (s ValidatorSamplingConf) validate() error {
+	if s.WindowSlots%SlotsPerEpoch() != 0 {
+		return fmt.Errorf("validator_sampling.window_slots must be a multiple of %d, got %d", SlotsPerEpoch(), s.WindowSlots)
+	}
+	return nil
+}
+
+// RewardsReconcileConf configures a realtime safety net for finalized epochs the epoch boundary
+// bookkeeping skipped (e.g. a restart while finality advanced): storage is checked for unindexed
+// epochs behind the finalized one and the gaps are indexed like newly finalized epochs.
+type RewardsReconcileConf struct {
+	Enabled bool `yaml:"enabled"`
+	// IntervalEpochs is how often, in epochs, storage is checked (default 16).
+	IntervalEpochs uint64 `yaml:"interval_epochs"`
+	// LookbackEpochs is how far behind the finalized epoch gaps are looked for (default 256, about
+	// 27 hours); older gaps are left to backfill.
+	LookbackEpochs uint64 `yaml:"lookback_epochs"`
+}
+
+// Attester duty sources (attestation_duties.source).
+const (
+	DutySourceDuties     = "duties"
+	DutySourceCommittees = "committees"
+)
+
+// DutyAnomaliesConf configures the committee-position anomaly check on fetched attester duties.
+// Anomalies are stored in duty_mismatches and counted in pauli_duty_anomalies_total.
+type DutyAnomaliesConf struct {
+	Enabled bool `yaml:"enabled"`
+	// Missing also flags a validator with no duty in an epoch after it had one in the previous epoch
+	// (also raised once when a validator exits).
+	Missing bool `yaml:"missing"`
+}
+
+// minimalPresetTargetCommitteeSize is TARGET_COMMITTEE_SIZE of the minimal preset used by devnets.
+const minimalPresetTargetCommitteeSize = 4
+
+func (a *AttestationDutiesConf) validate() error {
+	switch a.Source {
+	case "", DutySourceDuties, DutySourceCommittees:
+	default:
+		return fmt.Errorf("attestation_duties.source %q: use %s or %s", a.Source, DutySourceDuties, DutySourceCommittees)
+	}
+	if a.MaxInclusionDelaySlots == 0 {
+		return nil
+	}
+	window := a.InclusionDelaySlots
+	if window == 0 {
+		window = defaultInclusionDelaySlots
+	}
+	if a.MaxInclusionDelaySlots > window {
+		return fmt.Errorf("attestation_duties.max_inclusion_delay_slots (%d) exceeds inclusion_delay_slots (%d): later inclusions are never seen",
+			a.MaxInclusionDelaySlots, window)
+	}
+	return nil
+}
+
+func (a *AttestationDutiesConf) setDefaults() {
+	if a.InclusionDelaySlots == 0 {
+		a.InclusionDelaySlots = defaultInclusionDelaySlots
+	}
+	if a.MaxInclusionDelaySlots == 0 {
+		a.MaxInclusionDelaySlots = 1
+		if a.TargetCommitteeSize != 0 && a.TargetCommitteeSize <= minimalPresetTargetCommitteeSize {
+			a.MaxInclusionDelaySlots = min(2, a.InclusionDelaySlots)
+		}
+	}
+}
+
+// networkGenesisValidatorsRoots are the genesis validators roots of the public networks accepted as network.
+var networkGenesisValidatorsRoots = map[string]string{
+	"mainnet": "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+	"sepolia": "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078",
+	"holesky": "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
+	"hoodi":   "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f",
+}
+
+func (c *Config) validateNetwork() error {
+	if c.Network != "" {
+		if _, ok := networkGenesisValidatorsRoots[c.Network]; !ok {
+			return fmt.Errorf("unknown network %q (use mainnet, sepolia, holesky or hoodi, or set genesis_validators_root)", c.Network)
+		}
+	}
+	if c.GenesisValidatorsRoot != "" {
+		root := strings.TrimPrefix(c.GenesisValidatorsRoot, "0x")
+		if len(root) != 64 || strings.Trim(strings.ToLower(root), "0123456789abcdef") != "" {
+			return fmt.Errorf("genesis_validators_root %q is not a 32-byte hex root", c.GenesisValidatorsRoot)
+		}
+	}
+	if c.StrictNetwork && c.ExpectedGenesisValidatorsRoot() == "" {
+		return fmt.Errorf("strict_network requires network or genesis_validators_root")
+	}
+	return nil
+}
+
+// ExpectedGenesisValidatorsRoot returns the lower-case, 0x-prefixed root the beacon node must report:
+// genesis_validators_root if set, else the root of network, else "" (no check).
+func (c *Config) ExpectedGenesisValidatorsRoot() string {
+	if c.GenesisValidatorsRoot != "" {
+		return "0x" + strings.ToLower(strings.TrimPrefix(c.GenesisValidatorsRoot, "0x"))
+	}
+	return networkGenesisValidatorsRoots[c.Network]
+}
+
+// Per-validator result log event types accepted as result_log_levels keys.
+const (
+	ResultAttestationIncluded = "attestation_included"
+	ResultAttestationLate     = "attestation_late"
+	ResultAttestationMissed   = "attestation_missed"
+	ResultAttestationSeen     = "attestation_seen"
+	ResultDutyMismatch        = "duty_mismatch"
+	ResultDutyAnomaly         = "duty_anomaly"
+	ResultValidatorNotLive    = "validator_not_live"
+	ResultValidatorInactive   = "validator_inactive"
+	ResultValidatorActive     = "validator_reactivated"
+	ResultProposalSeen        = "proposal_seen"
+	ResultProposalProposed    = "proposal_proposed"
+	ResultProposalMissed      = "proposal_missed"
+)
+
+// ResultLogEvents lists the result_log_levels keys.
+var ResultLogEvents = []string{
+	ResultAttestationIncluded, ResultAttestationLate, ResultAttestationMissed, ResultAttestationSeen,
+	ResultDutyMismatch, ResultDutyAnomaly, ResultValidatorNotLive, ResultValidatorInactive,
+	ResultValidatorActive, ResultProposalSeen, ResultProposalProposed, ResultProposalMissed,
+}
+
+func validateResultLogLevels(levels map[string]string) error {
+	for event, level := range levels {
+		if !slices.Contains(ResultLogEvents, event) {
+			return fmt.Errorf("result_log_levels: unknown event %q (use one of %s)", event, strings.Join(ResultLogEvents, ", "))
+		}
+		switch strings.ToLower(level) {
+		case "debug", "info", "warn", "error", "off":
+		default:
+			return fmt.Errorf("result_log_levels.%s: unknown level %q (use debug, info, warn, error or off)", event, level)
+		}
+	}
+	return nil
+}
+
+// defaultInclusionDelaySlots is the default attestation_duties.inclusion_delay_slots.
+const defaultInclusionDelaySlots = 2
+
+// DutyFilterConf keeps only attester duties at the listed committee positions or in the listed
+// committees (by index within the slot). An empty list does not restrict; both set must both match.
+type DutyFilterConf struct {
+	CommitteePositions []uint64 `yaml:"committee_positions"`
+	CommitteeIndices   []uint64 `yaml:"committee_indices"`
+}
+
+// SnapshotWritesConf configures change-detection for validator epoch records.
+type SnapshotWritesConf struct {
+	// ChangeOnly writes a validator's epoch record only when one of Fields differs from the last
+	// record written for it since startup. Off by default.
+	ChangeOnly bool `yaml:"change_only"`
+	// Fields compared: balance, effective_balance, status (default all three).
+	Fields []string `yaml:"fields"`
+	// FullSnapshotIntervalSlots writes a record anyway once the last written one is this many slots old,
+	// as a heartbeat for consumers (0 = only on change). Records are per epoch, so the effective
+	// interval is rounded up to whole epochs.
+	FullSnapshotIntervalSlots uint64 `yaml:"full_snapshot_interval_slots"`
+}
+
+func (s *SnapshotWritesConf) validate() error {
+	for _, f := range s.Fields {
+		switch f {
+		case "balance", "effective_balance", "status":
+		default:
+			return fmt.Errorf("snapshot_writes.fields: unknown field %q (use balance, effective_balance or status)", f)
+		}
+	}
+	return nil
+}
+
+// maxRangeValidators caps how many indices validator_ranges may expand to.
+const maxRangeValidators = 1 << 20
+
+// ValidatorRange is an inclusive range of validator indices.
+type ValidatorRange struct {
+	From uint64 `yaml:"from"`
+	To   uint64 `yaml:"to"`
+}
+
+// TenantConf is a group of validators whose per-validator rows are kept apart from other tenants'.
+type TenantConf struct {
+	// Name identifies the tenant in logs ([a-z0-9_]).
+	Name string `yaml:"name"`
+	// Schema is the PostgreSQL schema holding the tenant's tables (default tenant_<name>); it is
+	// created and migrated on startup unless postgres.skip_migrations is set.
+	Schema          string           `yaml:"schema"`
+	Validators      []uint64         `yaml:"validators"`
+	ValidatorRanges []ValidatorRange `yaml:"validator_ranges,omitempty"`
+}
+
+// expandTenants validates tenants, expands their validator_ranges into their validators and adds
+// every tenant validator to validators. A validator may belong to one tenant only.
+func (c *Config) expandTenants() error {
+	if len(c.Tenants) == 0 {
+		return nil
+	}
+	if c.DatabaseDriver == "none" {
+		return fmt.Errorf("tenants require database_driver postgres")
+	}
+	names := make(map[string]struct{}, len(c.Tenants))
+	schemas := map[string]struct{}{c.Postgres.Schema: {}, "public": {}}
+	owner := make(map[uint64]string)
+	for i := range c.Tenants {
+		t := &c.Tenants[i]
+		if !isIdentifier(t.Name) {
+			return fmt.Errorf("tenants[%d]: name %q must be non-empty lowercase letters, digits and underscores", i, t.Name)
+		}
+		if _, dup := names[t.Name]; dup {
+			return fmt.Errorf("tenants: duplicate name %q", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if t.Schema == "" {
+			t.Schema = "tenant_" + t.Name
+		}
+		if !isIdentifier(t.Schema) {
+			return fmt.Errorf("tenant %q: schema %q must be lowercase letters, digits and underscores", t.Name, t.Schema)
+		}
+		if _, dup := schemas[t.Schema]; dup {
+			return fmt.Errorf("tenant %q: schema %q is already used", t.Name, t.Schema)
+		}
+		schemas[t.Schema] = struct{}{}
+
+		ranges := &Config{Validators: t.Validators, ValidatorRanges: t.ValidatorRanges}
+		if err := ranges.expandValidatorRanges(); err != nil {
+			return fmt.Errorf("tenant %q: %w", t.Name, err)
+		}
+		t.Validators = ranges.Validators
+		for _, v := range t.Validators {
+			if other, ok := owner[v]; ok && other != t.Name {
+				return fmt.Errorf("tenant %q: validator %d already belongs to tenant %q", t.Name, v, other)
+			}
+			owner[v] = t.Name
+		}
+		c.AddValidators(t.Validators)
+	}
+	return nil
+}
+
+// isIdentifier reports whether s is a non-empty lowercase SQL identifier that needs no quoting.
+func isIdentifier(s string) bool {
+	if s == "" || len(s) > 63 || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatorSelectConf selects validators by attributes instead of index. Values are 0x-prefixed hex.
+type ValidatorSelectConf struct {
+	// PubkeyPrefixes matches validators whose pubkey starts with any prefix.
+	PubkeyPrefixes []string `yaml:"pubkey_prefixes"`
+	// WithdrawalCredentials matches full 32-byte credentials, or a 20-byte execution address against
+	// 0x01 / 0x02 credentials.
+	WithdrawalCredentials []string `yaml:"withdrawal_credentials"`
+	// CacheFile keeps the last resolved indices on disk: startup uses them without waiting for the
+	// node and refreshes in the background, and a failed resolution falls back to them.
+	CacheFile string `yaml:"cache_file"`
+}
+
+// Enabled reports whether any selector is configured.
+func (s ValidatorSelectConf) Enabled() bool {
+	return len(s.PubkeyPrefixes) > 0 || len(s.WithdrawalCredentials) > 0
+}
+
+func (s *ValidatorSelectConf) validate() error {
+	for i, p := range s.PubkeyPrefixes {
+		norm, err := normalizeHex(p)
+		if err != nil {
+			return fmt.Errorf("validator_select.pubkey_prefixes: %w", err)
+		}
+		s.PubkeyPrefixes[i] = norm
+	}
+	for i, wc := range s.WithdrawalCredentials {
+		norm, err := normalizeHex(wc)
+		if err != nil {
+			return fmt.Errorf("validator_select.withdrawal_credentials: %w", err)
+		}
+		if len(norm) != 2+64 && len(norm) != 2+40 {
+			return fmt.Errorf("validator_select.withdrawal_credentials: %q is neither 32 bytes nor a 20-byte address", wc)
+		}
+		s.WithdrawalCredentials[i] = norm
+	}
+	return nil
+}
+
+// normalizeHex lowercases v and ensures a 0x prefix.
+func normalizeHex(v string) (string, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if !strings.HasPrefix(v, "0x") {
+		v = "0x" + v
+	}
+	if len(v) == 2 {
+		return "", fmt.Errorf("empty hex value")
+	}
+	for _, r := range v[2:] {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", fmt.Errorf("%q is not hex", v)
+		}
+	}
+	return v, nil
+}
+
+// expandValidatorRanges appends every index in validator_ranges to validators, dropping indices listed
+// more than once (keeping the first occurrence) with a warning so they are not polled and stored twice.
+func (c *Config) expandValidatorRanges() error {
+	var total uint64
+	for _, r := range c.ValidatorRanges {
+		if r.To < r.From {
+			return fmt.Errorf("validator_ranges: from %d is after to %d", r.From, r.To)
+		}
+		total += r.To - r.From + 1
+		if total > maxRangeValidators {
+			return fmt.Errorf("validator_ranges expand to more than %d validators", maxRangeValidators)
+		}
+	}
+	all := make([]uint64, 0, uint64(len(c.Validators))+total)
+	all = append(all, c.Validators...)
+	for _, r := range c.ValidatorRanges {
+		for v := r.From; ; v++ {
+			all = append(all, v)
+			if v == r.To {
+				break
+			}
+		}
+	}
+	var dups []uint64
+	c.Validators, dups = dedupeValidators(all)
+	if len(dups) > 0 {
+		log.Warn().
+			Int("count", len(dups)).
+			Uints64("duplicates", dups[:min(len(dups), 20)]).
+			Msg("config: validator indices listed more than once (validators / validator_ranges); each is monitored once")
+	}
+	return nil
+}
+
+// dedupeValidators returns validators without repeats, keeping first occurrences in order, and the
+// repeated indices in the order they were first repeated.
+func dedupeValidators(validators []uint64) (unique, dups []uint64) {
+	seen := make(map[uint64]bool, len(validators))
+	reported := make(map[uint64]bool)
+	unique = make([]uint64, 0, len(validators))
+	for _, v := range validators {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+			continue
+		}
+		if !reported[v] {
+			reported[v] = true
+			dups = append(dups, v)
+		}
+	}
+	return unique, dups
+}
+
+// validatePriorityValidators drops repeated priority_validators and rejects indices that are not watched.
+// With validator_select the watched set is only known at runtime, so the check is skipped.
+func (c *Config) validatePriorityValidators() error {
+	c.PriorityValidators, _ = dedupeValidators(c.PriorityValidators)
+	if len(c.PriorityValidators) == 0 || c.ValidatorSelect.Enabled() {
+		return nil
+	}
+	watched := make(map[uint64]struct{}, len(c.Validators))
+	for _, v := range c.Validators {
+		watched[v] = struct{}{}
+	}
+	for _, v := range c.PriorityValidators {
+		if _, ok := watched[v]; !ok {
+			return fmt.Errorf("priority_validators: validator %d is not in validators, validator_ranges or tenants", v)
+		}
+	}
+	return nil
+}
+
+// AddValidators appends indices not already in validators, keeping the existing order.
+func (c *Config) AddValidators(extra []uint64) {
+	seen := make(map[uint64]struct{}, len(c.Validators)+len(extra))
+	for _, v := range c.Validators {
+		seen[v] = struct{}{}
+	}
+	for _, v := range extra {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		c.Validators = append(c.Validators, v)
+	}
+}
+
+// BeaconAPIConf rewrites beacon API paths for gateways that do not serve the standard layout.
+type BeaconAPIConf struct {
+	// PathPrefix is inserted before every /eth/vN/... path (e.g. "/beacon"). Empty by default.
+	PathPrefix string `yaml:"path_prefix"`
+	// EndpointVersions overrides the API version per endpoint, keyed by the path after /eth/vN/
+	// (longest matching prefix wins), e.g. {"beacon/blinded_blocks": "v2"}.
+	EndpointVersions map[string]string `yaml:"endpoint_versions"`
+	// MaxValidatorsPerRequest caps the validator indices sent in one validators, duties, liveness or
+	// rewards request; larger sets are split and the responses merged (default 100).
+	MaxValidatorsPerRequest int `yaml:"max_validators_per_request"`
+}
+
+func (b *BeaconAPIConf) validate() error {
+	b.PathPrefix = strings.TrimRight(b.PathPrefix, "/")
+	if b.PathPrefix != "" && !strings.HasPrefix(b.PathPrefix, "/") {
+		b.PathPrefix = "/" + b.PathPrefix
+	}
+	for endpoint, version := range b.EndpointVersions {
+		n, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+		if !strings.HasPrefix(version, "v") || err != nil || n < 1 {
+			return fmt.Errorf("beacon_api.endpoint_versions[%q]: version %q must look like v1, v2, ...", endpoint, version)
+		}
+	}
+	if b.MaxValidatorsPerRequest < 0 {
+		return fmt.Errorf("beacon_api.max_validators_per_request must be >= 0, got %d", b.MaxValidatorsPerRequest)
+	}
+	return nil
+}
+
+// BeaconConsistencyConf configures a periodic cross-node check: every interval_epochs the finalized
+// checkpoint and the attestation rewards of a sample of the validators served by beacon_node_url are
+// fetched again from each reference node, and any difference raises a beacon_node_divergence alert.
+// Stored data always comes from beacon_node_url; reference nodes are only read by the check.
+type BeaconConsistencyConf struct {
+	ReferenceNodes []BeaconReferenceNode `yaml:"reference_nodes"`
+	// IntervalEpochs is how often the check runs (default 4).
+	IntervalEpochs uint64 `yaml:"interval_epochs"`
+	// SampleValidators is how many validators' rewards are compared per check, rotating through the
+	// validators list (default 16).
+	SampleValidators int `yaml:"sample_validators"`
+}
+
+// BeaconReferenceNode is a beacon node only queried by the consistency check.
+type BeaconReferenceNode struct {
+	URL    string `yaml:"url"`
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+// Enabled reports whether any reference node is configured.
+func (b BeaconConsistencyConf) Enabled() bool {
+	return len(b.ReferenceNodes) > 0
+}
+
+func (b BeaconConsistencyConf) validate() error {
+	for i, n := range b.ReferenceNodes {
+		if n.URL == "" {
+			return fmt.Errorf("beacon_consistency.reference_nodes[%d].url is required", i)
+		}
+	}
+	if b.SampleValidators < 0 {
+		return fmt.Errorf("beacon_consistency.sample_validators must be >= 0, got %d", b.SampleValidators)
+	}
+	return nil
+}
+
+// EventsConf configures the GET /eth/v1/events subscription of the realtime runner.
+type EventsConf struct {
+	// Enabled consumes "block" events: each imported block is indexed right away (and a watched
+	// proposer is logged) instead of waiting for the next head poll.
+	Enabled bool `yaml:"enabled"`
+	// Attestations also consumes gossip attestations (attestation and single_attestation) and matches
+	// them against scheduled attester duties; needs attestation_duties.enabled. High volume on mainnet.
+	Attestations bool `yaml:"attestations"`
+}
+
+// ValidatorLivenessConf configures liveness checks via POST /eth/v1/validator/liveness/{epoch}.
+type ValidatorLivenessConf struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// InactiveValidatorsConf drops exited_slashed / withdrawal_done validators from realtime per-validator
+// polling (attester duties and inclusion checks).
+type InactiveValidatorsConf struct {
+	Skip bool `yaml:"skip"`
+	// RecheckEpochs is how often an inactive validator's status is re-queried in case it re-enters (default 225, ~1 day).
+	RecheckEpochs uint64 `yaml:"recheck_epochs"`
+}
+
 // RateLimitConf configures the rate limiter.
 type RateLimitConf struct {
 	RequestsPerSecond float64 `yaml:"requests_per_second"`
 	Burst             int     `yaml:"burst"`
+	// Weights overrides how many tokens one request of an endpoint class takes (validators, validator,
+	// committees, rewards, blocks, duties, light); weights above burst are capped at burst.
+	Weights map[string]int `yaml:"weights"`
+}
+
+func (r RateLimitConf) validate() error {
+	for class, w := range r.Weights {
+		if w < 1 {
+			return fmt.Errorf("rate_limit.weights.%s must be at least 1", class)
+		}
+	}
+	return nil
 }
 
 // HTTPConf configures the HTTP client (beacon REST API).
@@ -78,6 +960,25 @@ type HTTPConf struct {
 	// MaxRetries is the maximum number of retries after a failed attempt (timeouts, 429, 503, etc.).
 	// Applied by the beacon client only; not related to database drivers.
 	MaxRetries int `yaml:"max_retries"`
+	// ErrorBodyMaxBytes caps how much of a response body is kept in beacon HTTP and decode errors (default 512).
+	ErrorBodyMaxBytes int `yaml:"error_body_max_bytes"`
+	// MaxResponseBytes caps how much of a beacon response body is read (default 512 MiB, enough for
+	// the mainnet all-validators state); larger bodies fail with beacon.ResponseTooLargeError.
+	MaxResponseBytes int64 `yaml:"max_response_bytes"`
+	// Cache keeps responses of slowly-changing beacon endpoints.
+	Cache BeaconCacheConf `yaml:"cache"`
+}
+
+// BeaconCacheConf sets how long the beacon client reuses responses of endpoints that rarely change.
+// A negative value disables caching for that endpoint.
+type BeaconCacheConf struct {
+	Disabled bool `yaml:"disabled"`
+	// GenesisSeconds caches /eth/v1/beacon/genesis; 0 (default) keeps it for the whole run.
+	GenesisSeconds int `yaml:"genesis_seconds"`
+	// SpecSeconds caches /eth/v1/config/spec (default 3600).
+	SpecSeconds int `yaml:"spec_seconds"`
+	// FinalitySeconds caches finality checkpoints; 0 (default) keeps them until the next slot starts.
+	FinalitySeconds int `yaml:"finality_seconds"`
 }
 
 // PostgresConf configures PostgreSQL connection.
@@ -90,6 +991,33 @@ type PostgresConf struct {
 	SSLMode  string `yaml:"ssl_mode"`
 	MaxConns int32  `yaml:"max_conns"`
 	TTLDays  int    `yaml:"ttl_days"`
+	// RetentionEpochs / RetentionSlots express retention in chain time ("keep the last N epochs");
+	// when set they take precedence over TTLDays (epochs first). See Config.RetentionTTL.
+	RetentionEpochs uint64 `yaml:"retention_epochs"`
+	RetentionSlots  uint64 `yaml:"retention_slots"`
+	// BatchRowFallback retries a failed write batch row by row so one bad row does not drop the
+	// rest; off by default because a failing batch then costs one round trip per row.
+	BatchRowFallback bool `yaml:"batch_row_fallback"`
+	// SkipMigrations does not run DDL on startup (for roles without CREATE privileges); the schema
+	// must already be migrated by a privileged user, which is checked against schema_migrations.
+	SkipMigrations bool `yaml:"skip_migrations"`
+	// DegradeSynchronousCommit, when set (local, remote_write or off), writes a batch that still fails
+	// with a transient error after its retries once more with synchronous_commit lowered to this level
+	// for that transaction only. Opt-in: a degraded write may be lost if the primary fails before a
+	// standby has it.
+	DegradeSynchronousCommit string `yaml:"degrade_synchronous_commit"`
+	// WriteConcurrency caps how many write batches are in flight at once across all jobs, and lets one
+	// large save (a network-wide epoch) write its batches on that many connections in parallel.
+	// 0 = no cap; each save writes its batches one after another.
+	WriteConcurrency int `yaml:"write_concurrency"`
+	// Schema sets the connection's search_path, so pauli's tables live in (and are migrated into) this
+	// schema instead of the role's default. Empty = server default (usually public).
+	Schema string `yaml:"schema,omitempty"`
+	// ReadHost / ReadPort point the API, report and export queries at a read replica (hot standby) with
+	// the same credentials and database, so analytical reads do not contend with ingestion. Empty =
+	// reads use the primary; read_port defaults to port.
+	ReadHost string `yaml:"read_host,omitempty"`
+	ReadPort int    `yaml:"read_port,omitempty"`
 }
 
 // ApplyDefaults sets default values for optional Postgres fields.
@@ -100,6 +1028,9 @@ func (p *PostgresConf) ApplyDefaults() {
 	if p.SSLMode == "" {
 		p.SSLMode = "disable"
 	}
+	if p.ReadPort == 0 {
+		p.ReadPort = p.Port
+	}
 	if p.MaxConns <= 0 {
 		p.MaxConns = 10
 	}
@@ -123,13 +1054,33 @@ func (c *Config) SlotDuration() time.Duration {
 	return time.Duration(seconds) * time.Second
 }
 
+// ShutdownTimeout returns shutdown_timeout_seconds as a time.Duration.
+func (c *Config) ShutdownTimeout() time.Duration {
+	return time.Duration(c.ShutdownTimeoutSeconds) * time.Second
+}
+
+// RetentionTTL returns the effective data retention: postgres.retention_epochs or retention_slots
+// translated with the slot duration when set, otherwise postgres.ttl_days.
+func (c *Config) RetentionTTL() time.Duration {
+	p := c.Postgres
+	switch {
+	case p.RetentionEpochs > 0:
+		return time.Duration(p.RetentionEpochs*SlotsPerEpoch()) * c.SlotDuration()
+	case p.RetentionSlots > 0:
+		return time.Duration(p.RetentionSlots) * c.SlotDuration()
+	default:
+		return time.Duration(p.TTLDays) * 24 * time.Hour
+	}
+}
+
 // SlotsPerEpoch returns the number of slots per epoch (32).
 func SlotsPerEpoch() uint64 {
 	return 32
 }
 
-// Load reads and parses the configuration from a YAML file.
-func Load(path string) (*Config, error) {
+// Load reads and parses the configuration from a YAML file. Overrides are applied to the parsed
+// file before validation, so command-line values are checked like configured ones.
+func Load(path string, overrides ...func(*Config)) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
@@ -139,6 +1090,9 @@ func Load(path string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	for _, override := range overrides {
+		override(&cfg)
+	}
 
 	if err := cfg.validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -162,6 +1116,20 @@ func validatePostgres(p *PostgresConf) error {
 	if p.Database == "" {
 		return fmt.Errorf("postgres database is required")
 	}
+	if p.WriteConcurrency < 0 {
+		return fmt.Errorf("postgres.write_concurrency must not be negative")
+	}
+	if p.MaxConns > 0 && int(p.MaxConns) < p.WriteConcurrency {
+		return fmt.Errorf("postgres.write_concurrency (%d) exceeds postgres.max_conns (%d)", p.WriteConcurrency, p.MaxConns)
+	}
+	if p.Schema != "" && !isIdentifier(p.Schema) {
+		return fmt.Errorf("postgres.schema %q must be lowercase letters, digits and underscores", p.Schema)
+	}
+	switch p.DegradeSynchronousCommit {
+	case "", "local", "remote_write", "off":
+	default:
+		return fmt.Errorf("postgres.degrade_synchronous_commit %q: use local, remote_write or off", p.DegradeSynchronousCommit)
+	}
 	return nil
 }
 
@@ -171,27 +1139,113 @@ func (c *Config) validate() error {
 		return fmt.Errorf("beacon_node_url is required")
 	}
 	// validators is optional: network-wide epoch indexing does not use it for RPC.
+	if c.Storage.Backend != "" {
+		if c.DatabaseDriver != "" && c.DatabaseDriver != c.Storage.Backend {
+			return fmt.Errorf("storage.backend %q conflicts with database_driver %q", c.Storage.Backend, c.DatabaseDriver)
+		}
+		c.DatabaseDriver = c.Storage.Backend
+	}
+	if err := c.Notifications.validateTemplates(); err != nil {
+		return err
+	}
+	if err := c.BeaconAPI.validate(); err != nil {
+		return err
+	}
+	if err := c.RateLimit.validate(); err != nil {
+		return err
+	}
+	if err := c.BeaconConsistency.validate(); err != nil {
+		return err
+	}
+	if err := c.expandValidatorRanges(); err != nil {
+		return err
+	}
+	if err := c.ValidatorSampling.validate(); err != nil {
+		return err
+	}
+	if err := c.expandTenants(); err != nil {
+		return err
+	}
+	if err := c.validatePriorityValidators(); err != nil {
+		return err
+	}
+	if err := c.ValidatorSelect.validate(); err != nil {
+		return err
+	}
+	if err := c.SnapshotWrites.validate(); err != nil {
+		return err
+	}
+	if err := c.AttestationDuties.validate(); err != nil {
+		return err
+	}
+	if c.Jobs.DisableRewards && c.Jobs.DisableBlocks && !c.AttestationDuties.Enabled && !c.ValidatorLiveness.Enabled && !c.ProposerDuties.Enabled {
+		return fmt.Errorf("every job type is disabled: enable rewards, blocks, attestation_duties, proposer_duties or validator_liveness")
+	}
+	if err := c.Metrics.validate(); err != nil {
+		return err
+	}
+	if err := c.Archive.validate(); err != nil {
+		return err
+	}
+	if err := validateResultLogLevels(c.ResultLogLevels); err != nil {
+		return err
+	}
+	if err := c.validateNetwork(); err != nil {
+		return err
+	}
 	switch c.DatabaseDriver {
 	case "", "postgres":
 		if err := validatePostgres(&c.Postgres); err != nil {
 			return err
 		}
+	case "none":
+		// No database: indexer progress is kept in memory and rows are discarded (or emitted via output_jsonl).
+		if c.RawResponses.Enabled {
+			return fmt.Errorf("raw_responses.enabled requires a postgres database")
+		}
+		if c.Archive.Enabled {
+			return fmt.Errorf("archive.enabled requires a postgres database")
+		}
 	case "scylladb":
 		return fmt.Errorf("database_driver \"scylladb\" is no longer supported; use postgres only")
 	default:
-		return fmt.Errorf("unsupported database_driver: %s (only postgres is supported)", c.DatabaseDriver)
+		return fmt.Errorf("unsupported database_driver: %s (use postgres or none)", c.DatabaseDriver)
 	}
 	return nil
 }
 
 // setDefaults sets default values for optional fields.
 func (c *Config) setDefaults() {
+	if c.Archive.Region == "" {
+		c.Archive.Region = "us-east-1"
+	}
+	if c.Archive.AfterDays <= 0 {
+		c.Archive.AfterDays = 30
+	}
+	if c.Archive.IntervalMinutes <= 0 {
+		c.Archive.IntervalMinutes = 60
+	}
+	if c.RawResponses.MaxBodyBytes <= 0 {
+		c.RawResponses.MaxBodyBytes = 8 << 20
+	}
+	if c.RawResponses.TTLDays <= 0 {
+		c.RawResponses.TTLDays = 7
+	}
+	if c.Watchdog.FinalityLagEpochs == 0 {
+		c.Watchdog.FinalityLagEpochs = 4
+	}
 	if c.PollingIntervalSlots <= 0 {
 		c.PollingIntervalSlots = 32
 	}
 	if c.WorkerPoolSize <= 0 {
 		c.WorkerPoolSize = 10
 	}
+	if c.WorkerQueueSize <= 0 {
+		c.WorkerQueueSize = 2 * c.WorkerPoolSize
+	}
+	if c.ShutdownTimeoutSeconds <= 0 {
+		c.ShutdownTimeoutSeconds = 30
+	}
 	if c.RateLimit.RequestsPerSecond <= 0 {
 		c.RateLimit.RequestsPerSecond = 50
 	}
@@ -207,11 +1261,63 @@ func (c *Config) setDefaults() {
 	if c.HTTP.MaxRetries <= 0 {
 		c.HTTP.MaxRetries = 3
 	}
+	if c.HTTP.ErrorBodyMaxBytes <= 0 {
+		c.HTTP.ErrorBodyMaxBytes = 512
+	}
+	if c.HTTP.MaxResponseBytes <= 0 {
+		c.HTTP.MaxResponseBytes = 512 << 20
+	}
+	if c.HTTP.Cache.SpecSeconds == 0 {
+		c.HTTP.Cache.SpecSeconds = 3600
+	}
 	if c.DatabaseDriver == "" {
 		c.DatabaseDriver = "postgres"
 	}
 	c.Postgres.ApplyDefaults()
 	c.Backfill.setDefaults()
+	if c.Metrics.Listen == "" {
+		c.Metrics.Listen = ":9090"
+	}
+	if c.Metrics.RemoteWrite.IntervalSeconds <= 0 {
+		c.Metrics.RemoteWrite.IntervalSeconds = 60
+	}
+	if c.Metrics.RemoteWrite.TimeoutSeconds <= 0 {
+		c.Metrics.RemoteWrite.TimeoutSeconds = 10
+	}
+	if c.Metrics.RemoteWrite.URL != "" && c.Metrics.RemoteWrite.Labels["job"] == "" {
+		if c.Metrics.RemoteWrite.Labels == nil {
+			c.Metrics.RemoteWrite.Labels = make(map[string]string)
+		}
+		c.Metrics.RemoteWrite.Labels["job"] = "pauli"
+	}
+	c.AttestationDuties.setDefaults()
+	if c.BeaconAPI.MaxValidatorsPerRequest == 0 {
+		c.BeaconAPI.MaxValidatorsPerRequest = 100
+	}
+	if c.BeaconConsistency.IntervalEpochs == 0 {
+		c.BeaconConsistency.IntervalEpochs = 4
+	}
+	if c.BeaconConsistency.SampleValidators == 0 {
+		c.BeaconConsistency.SampleValidators = 16
+	}
+	if c.ProposerDuties.CheckDelaySlots == 0 {
+		c.ProposerDuties.CheckDelaySlots = 2
+	}
+	if c.RewardsReconcile.IntervalEpochs == 0 {
+		c.RewardsReconcile.IntervalEpochs = 16
+	}
+	if c.RewardsReconcile.LookbackEpochs == 0 {
+		c.RewardsReconcile.LookbackEpochs = 256
+	}
+	if c.JobDeadlineSlots == 0 {
+		c.JobDeadlineSlots = 64
+	}
+	if c.Report.Currency == "" {
+		c.Report.Currency = "USD"
+	}
+	if c.InactiveValidators.RecheckEpochs == 0 {
+		c.InactiveValidators.RecheckEpochs = 225
+	}
 }
 
 func (b *BackfillConf) setDefaults() {
@@ -230,4 +1336,7 @@ func (b *BackfillConf) setDefaults() {
 	if b.IdlePollDelayMs <= 0 {
 		b.IdlePollDelayMs = 12000
 	}
+	if b.EpochConcurrency <= 0 {
+		b.EpochConcurrency = 1
+	}
 }
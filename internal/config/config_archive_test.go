@@ -0,0 +1,19 @@
+package config
+
+import "testing"
+
+func TestArchiveConf_validate(t *testing.T) {
+	ok := ArchiveConf{Enabled: true, Endpoint: "http://minio:9000", Bucket: "pauli", AccessKeyID: "k", SecretAccessKey: "s"}
+	if err := ok.validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, a := range map[string]ArchiveConf{
+		"no bucket":      {Enabled: true, AccessKeyID: "k", SecretAccessKey: "s"},
+		"no credentials": {Enabled: true, Bucket: "pauli"},
+		"no scheme":      {Enabled: true, Endpoint: "minio:9000", Bucket: "pauli", AccessKeyID: "k", SecretAccessKey: "s"},
+	} {
+		if err := a.validate(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
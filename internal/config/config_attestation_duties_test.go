@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestAttestationDutiesConf_maxInclusionDelay(t *testing.T) {
+	a := AttestationDutiesConf{}
+	a.setDefaults()
+	if a.MaxInclusionDelaySlots != 1 {
+		t.Fatalf("mainnet default = %d, want 1", a.MaxInclusionDelaySlots)
+	}
+	a = AttestationDutiesConf{TargetCommitteeSize: 4}
+	a.setDefaults()
+	if a.MaxInclusionDelaySlots != 2 {
+		t.Fatalf("minimal preset default = %d, want 2", a.MaxInclusionDelaySlots)
+	}
+	a = AttestationDutiesConf{TargetCommitteeSize: 4, InclusionDelaySlots: 1}
+	a.setDefaults()
+	if a.MaxInclusionDelaySlots != 1 {
+		t.Fatalf("default capped by window = %d, want 1", a.MaxInclusionDelaySlots)
+	}
+
+	if err := (&AttestationDutiesConf{MaxInclusionDelaySlots: 3}).validate(); err == nil {
+		t.Fatal("expected error for threshold beyond the default window")
+	}
+	if err := (&AttestationDutiesConf{MaxInclusionDelaySlots: 3, InclusionDelaySlots: 4}).validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import "testing"
+
+func TestBeaconAPIConf_maxValidatorsPerRequest(t *testing.T) {
+	b := BeaconAPIConf{MaxValidatorsPerRequest: -1}
+	if err := b.validate(); err == nil {
+		t.Fatal("expected error for negative max_validators_per_request")
+	}
+	c := &Config{}
+	c.setDefaults()
+	if c.BeaconAPI.MaxValidatorsPerRequest != 100 {
+		t.Fatalf("max_validators_per_request default: got %d", c.BeaconAPI.MaxValidatorsPerRequest)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBeaconConsistencyConf(t *testing.T) {
+	c := &Config{BeaconConsistency: BeaconConsistencyConf{ReferenceNodes: []BeaconReferenceNode{{APIKey: "k"}}}}
+	if err := c.BeaconConsistency.validate(); err == nil {
+		t.Fatal("expected error: reference node without url")
+	}
+	c.BeaconConsistency.ReferenceNodes[0].URL = "http://user:pw@teku:5051"
+	if err := c.BeaconConsistency.validate(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := c.Effective()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := out["beacon_consistency"].(map[string]any)["reference_nodes"].([]any)
+	node := nodes[0].(map[string]any)
+	if node["api_key"] != redacted || strings.Contains(node["url"].(string), "pw") {
+		t.Fatalf("reference node not redacted: %v", node)
+	}
+	if c.BeaconConsistency.ReferenceNodes[0].APIKey != "k" {
+		t.Fatal("Effective modified the config")
+	}
+}
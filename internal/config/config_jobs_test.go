@@ -0,0 +1,14 @@
+package config
+
+import "testing"
+
+func TestConfig_validateJobs(t *testing.T) {
+	c := &Config{BeaconNodeURL: "http://localhost:5052", DatabaseDriver: "none", Jobs: JobsConf{DisableRewards: true, DisableBlocks: true}}
+	if err := c.validate(); err == nil {
+		t.Fatal("expected error with every job type disabled")
+	}
+	c.AttestationDuties.Enabled = true
+	if err := c.validate(); err != nil {
+		t.Fatal(err)
+	}
+}
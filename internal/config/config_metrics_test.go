@@ -0,0 +1,20 @@
+package config
+
+import "testing"
+
+func TestMetricsConf_validateRemoteWrite(t *testing.T) {
+	ok := MetricsConf{Enabled: true, DisableScrape: true, RemoteWrite: RemoteWriteConf{URL: "https://example.com/api/prom/push"}}
+	if err := ok.validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, m := range map[string]MetricsConf{
+		"metrics disabled":   {RemoteWrite: RemoteWriteConf{URL: "https://example.com/push"}},
+		"no scheme":          {Enabled: true, RemoteWrite: RemoteWriteConf{URL: "example.com/push"}},
+		"two auth methods":   {Enabled: true, RemoteWrite: RemoteWriteConf{URL: "https://example.com/push", BearerToken: "t", Username: "u"}},
+		"no scrape, no push": {Enabled: true, DisableScrape: true},
+	} {
+		if err := m.validate(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfig_validateNetwork(t *testing.T) {
+	c := &Config{Network: "mainnet", StrictNetwork: true}
+	if err := c.validateNetwork(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ExpectedGenesisValidatorsRoot(); got != networkGenesisValidatorsRoots["mainnet"] {
+		t.Fatalf("expected root = %q", got)
+	}
+	c = &Config{Network: "mainnet", GenesisValidatorsRoot: "AB" + strings.Repeat("0", 62)}
+	if err := c.validateNetwork(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ExpectedGenesisValidatorsRoot(); got != "0xab"+strings.Repeat("0", 62) {
+		t.Fatalf("genesis_validators_root should take precedence, got %q", got)
+	}
+	for name, c := range map[string]*Config{
+		"unknown network":    {Network: "goerli"},
+		"short root":         {GenesisValidatorsRoot: "0x1234"},
+		"strict without one": {StrictNetwork: true},
+	} {
+		if err := c.validateNetwork(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package config
+
+import "testing"
+
+func TestPostgresConf_readReplicaDefaults(t *testing.T) {
+	p := PostgresConf{Host: "db", Port: 6432, ReadHost: "replica"}
+	p.ApplyDefaults()
+	if p.ReadPort != 6432 {
+		t.Fatalf("read_port default: got %d, want port", p.ReadPort)
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig_validatePriorityValidators(t *testing.T) {
+	c := &Config{Validators: []uint64{1, 2, 3}, PriorityValidators: []uint64{3, 1, 3}}
+	if err := c.validatePriorityValidators(); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.PriorityValidators) != 2 || c.PriorityValidators[0] != 3 || c.PriorityValidators[1] != 1 {
+		t.Fatalf("PriorityValidators = %v, want [3 1]", c.PriorityValidators)
+	}
+	c.PriorityValidators = []uint64{4}
+	if err := c.validatePriorityValidators(); err == nil {
+		t.Fatal("expected error: priority validator 4 is not watched")
+	}
+	c.ValidatorSelect.PubkeyPrefixes = []string{"0xab"}
+	if err := c.validatePriorityValidators(); err != nil {
+		t.Fatalf("validator_select resolves validators at runtime: %v", err)
+	}
+}
+
+func TestLoad_validatesOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "beacon_node_url: http://localhost:5052\ndatabase_driver: none\nvalidators: [1, 2]\npriority_validators: [2]\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, func(c *Config) { c.Validators = []uint64{1} }); err == nil {
+		t.Fatal("expected error: priority validator 2 is not in the overridden validators")
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+import "testing"
+
+func TestProposerDutiesConf(t *testing.T) {
+	c := &Config{
+		BeaconNodeURL:  "http://localhost:5052",
+		DatabaseDriver: "none",
+		Jobs:           JobsConf{DisableRewards: true, DisableBlocks: true},
+		ProposerDuties: ProposerDutiesConf{Enabled: true},
+	}
+	if err := c.validate(); err != nil {
+		t.Fatalf("proposer duties alone should count as a job: %v", err)
+	}
+	c.setDefaults()
+	if c.ProposerDuties.CheckDelaySlots != 2 {
+		t.Fatalf("proposer_duties.check_delay_slots default: got %d", c.ProposerDuties.CheckDelaySlots)
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_rawResponses(t *testing.T) {
+	c := &Config{BeaconNodeURL: "http://localhost:5052", DatabaseDriver: "none", RawResponses: RawResponsesConf{Enabled: true}}
+	if err := c.validate(); err == nil {
+		t.Fatal("expected error: raw responses need a database")
+	}
+	c.setDefaults()
+	if c.RawResponses.MaxBodyBytes != 8<<20 || c.RawResponses.TTL() != 7*24*time.Hour {
+		t.Fatalf("defaults: max_body_bytes %d, ttl %s", c.RawResponses.MaxBodyBytes, c.RawResponses.TTL())
+	}
+}
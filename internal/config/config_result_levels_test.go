@@ -0,0 +1,15 @@
+package config
+
+import "testing"
+
+func TestValidateResultLogLevels(t *testing.T) {
+	if err := validateResultLogLevels(map[string]string{ResultAttestationIncluded: "off", ResultAttestationMissed: "Error"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateResultLogLevels(map[string]string{"attestation": "info"}); err == nil {
+		t.Fatal("expected error for unknown event")
+	}
+	if err := validateResultLogLevels(map[string]string{ResultProposalSeen: "loud"}); err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+}
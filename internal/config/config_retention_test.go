@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_RetentionTTL(t *testing.T) {
+	c := &Config{Postgres: PostgresConf{TTLDays: 90}}
+	if got := c.RetentionTTL(); got != 90*24*time.Hour {
+		t.Fatalf("RetentionTTL (days) = %v, want 2160h", got)
+	}
+
+	c.Postgres.RetentionSlots = 100
+	if got := c.RetentionTTL(); got != 1200*time.Second {
+		t.Fatalf("RetentionTTL (slots) = %v, want 20m", got)
+	}
+
+	c.Postgres.RetentionEpochs = 3150
+	if got := c.RetentionTTL(); got != 3150*32*12*time.Second {
+		t.Fatalf("RetentionTTL (epochs) = %v, want 336h", got)
+	}
+
+	c.SlotDurationSeconds = 2
+	if got := c.RetentionTTL(); got != 3150*32*2*time.Second {
+		t.Fatalf("RetentionTTL (epochs, 2s slots) = %v", got)
+	}
+}
@@ -0,0 +1,11 @@
+package config
+
+import "testing"
+
+func TestConfig_rewardsReconcileDefaults(t *testing.T) {
+	c := &Config{}
+	c.setDefaults()
+	if c.RewardsReconcile.IntervalEpochs != 16 || c.RewardsReconcile.LookbackEpochs != 256 {
+		t.Fatalf("rewards_reconcile defaults: %+v", c.RewardsReconcile)
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import "testing"
+
+func TestConfig_expandTenants(t *testing.T) {
+	c := &Config{
+		Validators: []uint64{1},
+		Tenants: []TenantConf{
+			{Name: "acme", Validators: []uint64{10}, ValidatorRanges: []ValidatorRange{{From: 11, To: 12}}},
+			{Name: "globex", Schema: "globex_data", Validators: []uint64{20}},
+		},
+	}
+	if err := c.expandTenants(); err != nil {
+		t.Fatal(err)
+	}
+	if c.Tenants[0].Schema != "tenant_acme" {
+		t.Fatalf("default schema = %q, want tenant_acme", c.Tenants[0].Schema)
+	}
+	if got := c.Tenants[0].Validators; len(got) != 3 || got[2] != 12 {
+		t.Fatalf("tenant validators = %v, want [10 11 12]", got)
+	}
+	if len(c.Validators) != 5 {
+		t.Fatalf("Validators = %v, want the tenants' validators added", c.Validators)
+	}
+
+	for name, tenants := range map[string][]TenantConf{
+		"shared validator": {{Name: "a", Validators: []uint64{1}}, {Name: "b", Validators: []uint64{1}}},
+		"duplicate name":   {{Name: "a"}, {Name: "a"}},
+		"shared schema":    {{Name: "a", Schema: "s"}, {Name: "b", Schema: "s"}},
+		"public schema":    {{Name: "a", Schema: "public"}},
+		"bad name":         {{Name: "Acme-1"}},
+	} {
+		c := &Config{Tenants: tenants}
+		if err := c.expandTenants(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
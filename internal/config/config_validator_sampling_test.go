@@ -0,0 +1,15 @@
+package config
+
+import "testing"
+
+func TestValidatorSamplingConf(t *testing.T) {
+	if err := (ValidatorSamplingConf{WindowSlots: 100}).validate(); err == nil {
+		t.Fatal("expected error: window_slots not a multiple of 32")
+	}
+	if g := (ValidatorSamplingConf{WindowSlots: 320}).Groups(); g != 10 {
+		t.Fatalf("Groups() = %d, want 10", g)
+	}
+	if g := (ValidatorSamplingConf{}).Groups(); g != 1 {
+		t.Fatalf("Groups() with sampling off = %d, want 1", g)
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConfig_expandValidatorRanges(t *testing.T) {
+	c := &Config{
+		Validators:      []uint64{5, 90000},
+		ValidatorRanges: []ValidatorRange{{From: 3, To: 6}, {From: 90000, To: 90000}},
+	}
+	if err := c.expandValidatorRanges(); err != nil {
+		t.Fatal(err)
+	}
+	want := []uint64{5, 90000, 3, 4, 6}
+	if len(c.Validators) != len(want) {
+		t.Fatalf("Validators = %v, want %v", c.Validators, want)
+	}
+	for i := range want {
+		if c.Validators[i] != want[i] {
+			t.Fatalf("Validators = %v, want %v", c.Validators, want)
+		}
+	}
+
+	c.ValidatorRanges = []ValidatorRange{{From: 10, To: 9}}
+	if err := c.expandValidatorRanges(); err == nil {
+		t.Fatal("expected error for reversed range")
+	}
+	c.ValidatorRanges = []ValidatorRange{{From: 0, To: maxRangeValidators}}
+	if err := c.expandValidatorRanges(); err == nil {
+		t.Fatal("expected error for oversized range")
+	}
+}
+
+func TestConfig_expandValidatorRangesDuplicates(t *testing.T) {
+	c := &Config{
+		Validators:      []uint64{7, 2, 7, 40},
+		ValidatorRanges: []ValidatorRange{{From: 1, To: 3}, {From: 3, To: 4}, {From: 40, To: 40}},
+	}
+	if err := c.expandValidatorRanges(); err != nil {
+		t.Fatal(err)
+	}
+	want := []uint64{7, 2, 40, 1, 3, 4}
+	if !slices.Equal(c.Validators, want) {
+		t.Fatalf("Validators = %v, want %v", c.Validators, want)
+	}
+	if _, dups := dedupeValidators([]uint64{7, 2, 7, 40, 1, 2, 3, 3, 4, 40, 7}); !slices.Equal(dups, []uint64{7, 2, 3, 40}) {
+		t.Fatalf("duplicates = %v", dups)
+	}
+}
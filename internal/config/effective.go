@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces a configured secret in Effective.
+const redacted = "[redacted]"
+
+// Effective returns the resolved configuration (after defaults and command-line overrides) keyed like
+// the YAML file, for the startup log. API keys, passwords, tokens, webhook URLs and routing keys are
+// replaced by "[redacted]", other URLs lose their credentials and query, and validator lists are
+// replaced by their length.
+func (c *Config) Effective() (map[string]any, error) {
+	r := *c
+	r.BeaconNodeURL = RedactURL(r.BeaconNodeURL)
+	r.BeaconAPIKey = redactSecret(r.BeaconAPIKey)
+	r.ExecutionNodeURL = RedactURL(r.ExecutionNodeURL)
+	r.ExecutionAPIKey = redactSecret(r.ExecutionAPIKey)
+	r.BeaconConsistency.ReferenceNodes = make([]BeaconReferenceNode, len(c.BeaconConsistency.ReferenceNodes))
+	for i, n := range c.BeaconConsistency.ReferenceNodes {
+		r.BeaconConsistency.ReferenceNodes[i] = BeaconReferenceNode{URL: RedactURL(n.URL), APIKey: redactSecret(n.APIKey)}
+	}
+	r.Postgres.Password = redactSecret(r.Postgres.Password)
+	r.Archive.AccessKeyID = redactSecret(r.Archive.AccessKeyID)
+	r.Archive.SecretAccessKey = redactSecret(r.Archive.SecretAccessKey)
+	rw := &r.Metrics.RemoteWrite
+	rw.URL = RedactURL(rw.URL)
+	rw.BearerToken = redactSecret(rw.BearerToken)
+	rw.Password = redactSecret(rw.Password)
+	if len(rw.Headers) > 0 {
+		headers := make(map[string]string, len(rw.Headers))
+		for k := range rw.Headers {
+			headers[k] = redacted
+		}
+		rw.Headers = headers
+	}
+	r.Notifications.WebhookURL = redactSecret(r.Notifications.WebhookURL)
+	r.Notifications.Discord.WebhookURL = redactSecret(r.Notifications.Discord.WebhookURL)
+	r.Notifications.PagerDuty.RoutingKey = redactSecret(r.Notifications.PagerDuty.RoutingKey)
+
+	r.Validators = nil
+	r.PriorityValidators = nil
+	tenantValidators := make([]int, len(c.Tenants))
+	r.Tenants = make([]TenantConf, len(c.Tenants))
+	for i, t := range c.Tenants {
+		tenantValidators[i] = len(t.Validators)
+		t.Validators = nil
+		r.Tenants[i] = t
+	}
+
+	data, err := yaml.Marshal(&r)
+	if err != nil {
+		return nil, fmt.Errorf("marshal effective config: %w", err)
+	}
+	var out map[string]any
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal effective config: %w", err)
+	}
+	out["validators"] = len(c.Validators)
+	if len(c.PriorityValidators) > 0 {
+		out["priority_validators"] = len(c.PriorityValidators)
+	}
+	if tenants, ok := out["tenants"].([]any); ok {
+		for i, t := range tenants {
+			if m, ok := t.(map[string]any); ok && i < len(tenantValidators) {
+				m["validators"] = tenantValidators[i]
+			}
+		}
+	}
+	return out, nil
+}
+
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// RedactURL drops the password and query string of raw, which may carry credentials; unparsable
+// values are redacted entirely.
+func RedactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = redacted
+	}
+	return u.String()
+}
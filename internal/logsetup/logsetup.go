@@ -10,6 +10,11 @@ import (
 
 // Setup configures global zerolog level and output (console when stdout is a TTY).
 func Setup(debug bool) {
+	SetupOutput(debug, os.Stdout)
+}
+
+// SetupOutput is Setup writing to out; output_jsonl uses os.Stderr so stdout carries data only.
+func SetupOutput(debug bool, out *os.File) {
 	if debug {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	} else {
@@ -19,17 +24,17 @@ func Setup(debug bool) {
 
 	zerolog.TimeFieldFormat = time.RFC3339
 
-	if isTerminal() {
+	if isTerminal(out) {
 		log.Logger = log.Output(zerolog.ConsoleWriter{
-			Out:        os.Stdout,
+			Out:        out,
 			TimeFormat: time.RFC3339,
 		})
 	} else {
-		log.Logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+		log.Logger = zerolog.New(out).With().Timestamp().Logger()
 	}
 }
 
-func isTerminal() bool {
-	fileInfo, _ := os.Stdout.Stat()
+func isTerminal(f *os.File) bool {
+	fileInfo, _ := f.Stat()
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
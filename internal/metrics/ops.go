@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Operational series about pauli itself.
+var (
+	// LastResultTimestamp is the unix time of the last successfully completed indexing job.
+	LastResultTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_last_result_timestamp_seconds",
+		Help: "Unix time of the last successfully completed indexing job.",
+	})
+
+	// JobsDeadlineExceeded counts async jobs cancelled because they ran past their chain-time deadline.
+	JobsDeadlineExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_jobs_deadline_exceeded_total",
+		Help: "Async indexing jobs cancelled for running past job_deadline_slots.",
+	}, []string{"step"})
+
+	// JobsDropped counts realtime jobs not queued because the worker queue was full.
+	JobsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_jobs_dropped_total",
+		Help: "Realtime jobs dropped because the worker queue (worker_queue_size) was full.",
+	}, []string{"step"})
+
+	// EventsReceived counts beacon node events consumed from the SSE stream, by topic.
+	EventsReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_beacon_events_total",
+		Help: "Beacon node events received on the event stream, by topic.",
+	}, []string{"topic"})
+
+	// GossipAttestationsSeen counts watched validators' attestations first seen on the event stream.
+	GossipAttestationsSeen = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_gossip_attestations_seen_total",
+		Help: "Attestations of watched validators seen on the beacon event stream (once per duty).",
+	})
+
+	// DutyAnomalies counts watched validators found in zero or several committees in an epoch's duties.
+	DutyAnomalies = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_duty_anomalies_total",
+		Help: "Attester duty anomalies (a watched validator in multiple_committees or no_committee in an epoch), by reason.",
+	}, []string{"reason"})
+
+	// SlowAttestationInclusions counts watched validators' attestations included later than
+	// attestation_duties.max_inclusion_delay_slots after their duty slot.
+	SlowAttestationInclusions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_slow_attestation_inclusions_total",
+		Help: "Attestations of watched validators included more than max_inclusion_delay_slots after their duty slot.",
+	})
+
+	// ProposalOutcomes counts checked block proposals of watched validators by status (proposed, missed,
+	// reassigned), counted once per slot at the first check.
+	ProposalOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_proposal_outcomes_total",
+		Help: "Checked block proposals of watched validators by outcome.",
+	}, []string{"status"})
+
+	// SnapshotWritesSkipped counts validator epoch records not written because nothing changed.
+	SnapshotWritesSkipped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_snapshot_writes_skipped_total",
+		Help: "Validator epoch records skipped by snapshot_writes.change_only because balance, effective balance and status were unchanged.",
+	})
+
+	// Stale is 1 while the watchdog considers indexing stalled.
+	Stale = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_stale",
+		Help: "1 while no indexing results have been produced for longer than watchdog.max_silence_seconds.",
+	})
+
+	// FinalizationLagEpochs is head epoch minus finalized epoch at the last epoch boundary.
+	FinalizationLagEpochs = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_finalization_lag_epochs",
+		Help: "Head epoch minus the finalized epoch, sampled once per epoch boundary.",
+	})
+
+	// WatchedEpochReward is the distribution (min, max, mean, median) of total attestation rewards across
+	// the watched validators in the epoch WatchedEpochRewardEpoch.
+	WatchedEpochReward = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_watched_epoch_reward_gwei",
+		Help: "Total attestation reward of the watched validators in the last finalized epoch indexed, by statistic.",
+	}, []string{"stat"})
+
+	// WatchedEpochRewardEpoch is the epoch pauli_watched_epoch_reward_gwei describes.
+	WatchedEpochRewardEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_watched_epoch_reward_epoch",
+		Help: "Finalized epoch summarized by pauli_watched_epoch_reward_gwei.",
+	})
+
+	// BeaconNodeChecks counts beacon_consistency checks per reference node by result (ok, diverged, error).
+	BeaconNodeChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_beacon_consistency_checks_total",
+		Help: "Cross-node consistency checks against a beacon_consistency reference node, by result.",
+	}, []string{"node", "result"})
+
+	// BeaconNodeDivergences counts answers a reference node gave differently from beacon_node_url, by check.
+	BeaconNodeDivergences = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_beacon_node_divergences_total",
+		Help: "Values a beacon_consistency reference node disagreed on with beacon_node_url, by check (finalized_root, attestation_rewards).",
+	}, []string{"node", "check"})
+
+	// StorageWritersBusy is how many postgres.write_concurrency writer slots are in use.
+	StorageWritersBusy = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_storage_writers_busy",
+		Help: "Write batches currently holding a postgres.write_concurrency slot.",
+	})
+
+	// StorageWritersLimit is postgres.write_concurrency (0 when writes are not capped).
+	StorageWritersLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_storage_writers_limit",
+		Help: "Configured postgres.write_concurrency; busy / limit is writer utilization.",
+	})
+
+	// RemoteWriteFailures counts metrics.remote_write pushes that failed.
+	RemoteWriteFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_remote_write_failures_total",
+		Help: "Metrics remote-write pushes that failed (network error or non-2xx response).",
+	})
+
+	// RawResponsesDropped counts raw_responses that were not stored, by reason (too_large, queue_full, save_failed).
+	RawResponsesDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_raw_responses_dropped_total",
+		Help: "Raw beacon responses not kept for audit, by reason.",
+	}, []string{"reason"})
+)
@@ -0,0 +1,202 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// RemoteWriteOptions configures PushRemoteWrite.
+type RemoteWriteOptions struct {
+	URL string
+	// BearerToken, or Username and Password, authenticate the push; Headers are added as is
+	// (e.g. X-Scope-OrgID for multi-tenant receivers).
+	BearerToken string
+	Username    string
+	Password    string
+	Headers     map[string]string
+	// Labels are added to every series, since no scraper adds job/instance.
+	Labels   map[string]string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// RemoteWriter pushes every series registered with the default registry (the same ones /metrics
+// serves) to a Prometheus remote-write endpoint.
+type RemoteWriter struct {
+	opts     RemoteWriteOptions
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	log      zerolog.Logger
+}
+
+// NewRemoteWriter builds a writer for the default registry.
+func NewRemoteWriter(opts RemoteWriteOptions, log zerolog.Logger) *RemoteWriter {
+	return &RemoteWriter{
+		opts:     opts,
+		gatherer: prometheus.DefaultGatherer,
+		client:   &http.Client{Timeout: opts.Timeout},
+		log:      log,
+	}
+}
+
+// Run pushes once per interval until ctx is cancelled. A failed push is logged and counted; the next
+// push sends current values again, so nothing is queued.
+func (w *RemoteWriter) Run(ctx context.Context) {
+	w.log.Info().Str("url", w.opts.URL).Dur("interval", w.opts.Interval).Msg("metrics remote write started")
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Push(ctx); err != nil && ctx.Err() == nil {
+				RemoteWriteFailures.Inc()
+				w.log.Warn().Err(err).Str("url", w.opts.URL).Msg("metrics remote write failed")
+			}
+		}
+	}
+}
+
+// Push gathers the current metric values and sends them as one remote-write request.
+func (w *RemoteWriter) Push(ctx context.Context) error {
+	families, err := w.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("gather metrics: %w", err)
+	}
+	body := snappy.Encode(nil, encodeWriteRequest(families, w.opts.Labels, time.Now()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	req.Header.Set("User-Agent", "pauli")
+	for k, v := range w.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case w.opts.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+w.opts.BearerToken)
+	case w.opts.Username != "":
+		req.SetBasicAuth(w.opts.Username, w.opts.Password)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote write: HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+type label struct{ name, value string }
+
+// encodeWriteRequest encodes families as a prometheus.WriteRequest protobuf: one TimeSeries per sample,
+// histograms and summaries split into their _bucket/_sum/_count (or quantile) series like the text format.
+func encodeWriteRequest(families []*dto.MetricFamily, extra map[string]string, now time.Time) []byte {
+	ts := now.UnixMilli()
+	var out []byte
+	add := func(name string, base []label, value float64, more ...label) {
+		labels := make([]label, 0, len(base)+len(more)+1)
+		labels = append(labels, label{"__name__", name})
+		labels = append(labels, base...)
+		labels = append(labels, more...)
+		sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendBytes(out, encodeTimeSeries(labels, value, ts))
+	}
+	for _, mf := range families {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			base := make([]label, 0, len(m.GetLabel())+len(extra))
+			for _, lp := range m.GetLabel() {
+				base = append(base, label{lp.GetName(), lp.GetValue()})
+			}
+			for k, v := range extra {
+				if !hasLabel(base, k) {
+					base = append(base, label{k, v})
+				}
+			}
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, base, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, base, m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, base, m.GetUntyped().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					add(name+"_bucket", base, float64(b.GetCumulativeCount()), label{"le", formatFloat(b.GetUpperBound())})
+				}
+				add(name+"_bucket", base, float64(h.GetSampleCount()), label{"le", "+Inf"})
+				add(name+"_sum", base, h.GetSampleSum())
+				add(name+"_count", base, float64(h.GetSampleCount()))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, base, q.GetValue(), label{"quantile", formatFloat(q.GetQuantile())})
+				}
+				add(name+"_sum", base, s.GetSampleSum())
+				add(name+"_count", base, float64(s.GetSampleCount()))
+			}
+		}
+	}
+	return out
+}
+
+// encodeTimeSeries encodes one TimeSeries{labels, samples: [{value, timestamp}]}.
+func encodeTimeSeries(labels []label, value float64, ts int64) []byte {
+	var b []byte
+	for _, l := range labels {
+		var lb []byte
+		lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.name)
+		lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.value)
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, lb)
+	}
+	var sb []byte
+	sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+	sb = protowire.AppendFixed64(sb, math.Float64bits(value))
+	sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+	sb = protowire.AppendVarint(sb, uint64(ts))
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendBytes(b, sb)
+}
+
+func hasLabel(labels []label, name string) bool {
+	for _, l := range labels {
+		if l.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, +1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteRequest returns each pushed series as its label map plus "value".
+func decodeWriteRequest(t *testing.T, b []byte) []map[string]any {
+	fields := func(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) int) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+			n = fn(num, typ, b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+		}
+	}
+	var out []map[string]any
+	fields(b, func(_ protowire.Number, _ protowire.Type, b []byte) int {
+		ts, n := protowire.ConsumeBytes(b)
+		series := map[string]any{}
+		fields(ts, func(num protowire.Number, _ protowire.Type, b []byte) int {
+			msg, n := protowire.ConsumeBytes(b)
+			var name string
+			fields(msg, func(f protowire.Number, typ protowire.Type, b []byte) int {
+				switch {
+				case num == 1 && f == 1:
+					v, n := protowire.ConsumeString(b)
+					name = v
+					return n
+				case num == 1 && f == 2:
+					v, n := protowire.ConsumeString(b)
+					series[name] = v
+					return n
+				case num == 2 && f == 1:
+					v, n := protowire.ConsumeFixed64(b)
+					series["value"] = math.Float64frombits(v)
+					return n
+				default:
+					return protowire.ConsumeFieldValue(f, typ, b)
+				}
+			})
+			return n
+		})
+		out = append(out, series)
+		return n
+	})
+	return out
+}
+
+func TestRemoteWriter_Push(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	jobs := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_jobs_total", Help: "h"}, []string{"step"})
+	jobs.WithLabelValues("epoch").Add(3)
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Help: "h", Buckets: []float64{1}})
+	hist.Observe(0.5)
+	reg.MustRegister(jobs, hist)
+
+	var got []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "tenant-1", r.Header.Get("X-Scope-OrgID"))
+		compressed, _ := io.ReadAll(r.Body)
+		body, err := snappy.Decode(nil, compressed)
+		if assert.NoError(t, err) {
+			got = decodeWriteRequest(t, body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	w := NewRemoteWriter(RemoteWriteOptions{
+		URL:         srv.URL,
+		BearerToken: "secret",
+		Headers:     map[string]string{"X-Scope-OrgID": "tenant-1"},
+		Labels:      map[string]string{"job": "pauli"},
+		Timeout:     5 * time.Second,
+	}, zerolog.Nop())
+	w.gatherer = reg
+	require.NoError(t, w.Push(context.Background()))
+
+	require.Contains(t, got, map[string]any{"__name__": "test_jobs_total", "step": "epoch", "job": "pauli", "value": 3.0})
+	require.Contains(t, got, map[string]any{"__name__": "test_seconds_bucket", "le": "1", "job": "pauli", "value": 1.0})
+	require.Contains(t, got, map[string]any{"__name__": "test_seconds_bucket", "le": "+Inf", "job": "pauli", "value": 1.0})
+	require.Contains(t, got, map[string]any{"__name__": "test_seconds_sum", "job": "pauli", "value": 0.5})
+	require.Len(t, got, 5)
+}
+
+func TestRemoteWriter_PushErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+	w := NewRemoteWriter(RemoteWriteOptions{URL: srv.URL, Timeout: 5 * time.Second}, zerolog.Nop())
+	w.gatherer = prometheus.NewRegistry()
+	err := w.Push(context.Background())
+	require.ErrorContains(t, err, "HTTP 400: out of order sample")
+}
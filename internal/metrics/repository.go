@@ -0,0 +1,235 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Options selects how validator data is exported.
+type Options struct {
+	// AggregateOnly exports sums and counts across watched validators instead of per-index series.
+	// Use it for large validator sets where one series per index is too much cardinality.
+	AggregateOnly bool
+}
+
+var rewardComponents = []string{"head", "source", "target", "total"}
+
+// validatorState is the latest epoch record seen for a watched validator.
+type validatorState struct {
+	epoch            uint64
+	balance          uint64
+	effectiveBalance uint64
+	status           string
+	rewards          [4]*int64 // rewardComponents order
+}
+
+// Repository wraps a storage.Repository and, after each successful save, updates the validator series
+// for watched validators only (network-wide rows would explode cardinality).
+type Repository struct {
+	storage.Repository
+
+	watched func(uint64) bool
+	opts    Options
+
+	mu     sync.Mutex
+	latest map[uint64]*validatorState
+}
+
+// NewRepository tees saved rows for validators where watched returns true into Prometheus.
+func NewRepository(inner storage.Repository, watched func(uint64) bool, opts Options) *Repository {
+	return &Repository{
+		Repository: inner,
+		watched:    watched,
+		opts:       opts,
+		latest:     make(map[uint64]*validatorState),
+	}
+}
+
+// SaveValidatorEpochRecords persists records, then updates balance, status and reward series. Older
+// epochs (e.g. from backfill) never overwrite a newer epoch's values.
+func (r *Repository) SaveValidatorEpochRecords(ctx context.Context, records []*storage.ValidatorEpochRecord) error {
+	if err := r.Repository.SaveValidatorEpochRecords(ctx, records); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range records {
+		if !r.watched(rec.ValidatorIndex) {
+			continue
+		}
+		prev := r.latest[rec.ValidatorIndex]
+		if prev != nil && rec.Epoch < prev.epoch {
+			continue
+		}
+		next := &validatorState{
+			epoch:            rec.Epoch,
+			balance:          rec.Balance,
+			effectiveBalance: rec.EffectiveBalance,
+			status:           rec.Status,
+			rewards:          [4]*int64{rec.HeadReward, rec.SourceReward, rec.TargetReward, rec.TotalReward},
+		}
+		r.latest[rec.ValidatorIndex] = next
+		if r.opts.AggregateOnly {
+			applyAggregate(prev, -1)
+			applyAggregate(next, 1)
+		} else {
+			observeValidator(rec.ValidatorIndex, prev, next)
+		}
+	}
+	return nil
+}
+
+// SaveBlock persists row, then counts the proposal if the proposer is watched and the block was not
+// counted before (see SaveBlocks).
+func (r *Repository) SaveBlock(ctx context.Context, row *storage.Block) error {
+	if err := r.Repository.SaveBlock(ctx, row); err != nil {
+		return err
+	}
+	return r.observeBlocks(ctx, []*storage.Block{row})
+}
+
+// SaveBlocks persists rows, then counts proposals by watched validators. Each block is marked in
+// epoch_processed first, so a block saved again by backfill or a replay is not counted twice.
+func (r *Repository) SaveBlocks(ctx context.Context, rows []*storage.Block) error {
+	if err := r.Repository.SaveBlocks(ctx, rows); err != nil {
+		return err
+	}
+	return r.observeBlocks(ctx, rows)
+}
+
+// SaveAttestationLiveness persists rows, then counts provisional hits and misses of rows not marked in
+// epoch_processed before, so each duty is counted once however often its epoch is reprocessed.
+func (r *Repository) SaveAttestationLiveness(ctx context.Context, rows []*storage.AttestationLiveness) error {
+	if err := r.Repository.SaveAttestationLiveness(ctx, rows); err != nil {
+		return err
+	}
+	byMark := make(map[storage.EpochProcessed]*storage.AttestationLiveness)
+	var marks []*storage.EpochProcessed
+	for _, row := range rows {
+		if !r.watched(row.ValidatorIndex) {
+			continue
+		}
+		m := &storage.EpochProcessed{
+			Aggregate:      storage.AggregateAttestationLiveness,
+			Epoch:          row.Epoch,
+			ValidatorIndex: row.ValidatorIndex,
+			Slot:           row.Slot,
+		}
+		byMark[*m] = row
+		marks = append(marks, m)
+	}
+	fresh, err := r.Repository.MarkEpochProcessed(ctx, marks)
+	if err != nil {
+		return err
+	}
+	for _, m := range fresh {
+		row := byMark[*m]
+		switch {
+		case r.opts.AggregateOnly && row.Included:
+			attestationsIncludedTotal.Inc()
+		case r.opts.AggregateOnly:
+			attestationsMissedTotal.Inc()
+		case row.Included:
+			validatorAttestationsIncluded.WithLabelValues(indexLabel(row.ValidatorIndex)).Inc()
+		default:
+			validatorAttestationsMissed.WithLabelValues(indexLabel(row.ValidatorIndex)).Inc()
+		}
+	}
+	return nil
+}
+
+// Forget drops removed validators: their per-index series are deleted, or in aggregate mode their
+// latest values are subtracted from the sums.
+func (r *Repository) Forget(validators []uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range validators {
+		if r.opts.AggregateOnly {
+			applyAggregate(r.latest[v], -1)
+		} else {
+			for _, vec := range perValidatorVecs {
+				vec.DeletePartialMatch(prometheus.Labels{labelValidatorIndex: indexLabel(v)})
+			}
+		}
+		delete(r.latest, v)
+	}
+}
+
+// observeBlocks counts the watched proposers' blocks that epoch_processed has not seen yet.
+func (r *Repository) observeBlocks(ctx context.Context, rows []*storage.Block) error {
+	byMark := make(map[storage.EpochProcessed]*storage.Block)
+	var marks []*storage.EpochProcessed
+	for _, row := range rows {
+		if !r.watched(row.ValidatorIndex) {
+			continue
+		}
+		m := &storage.EpochProcessed{
+			Aggregate:      storage.AggregateBlocks,
+			Epoch:          beacon.SlotToEpoch(row.SlotNumber),
+			ValidatorIndex: row.ValidatorIndex,
+			Slot:           row.SlotNumber,
+		}
+		byMark[*m] = row
+		marks = append(marks, m)
+	}
+	fresh, err := r.Repository.MarkEpochProcessed(ctx, marks)
+	if err != nil {
+		return err
+	}
+	for _, m := range fresh {
+		row := byMark[*m]
+		if r.opts.AggregateOnly {
+			blocksProposedTotal.Inc()
+			blockRewardsGweiTotal.Add(float64(row.Rewards))
+			continue
+		}
+		label := indexLabel(row.ValidatorIndex)
+		validatorBlocksProposed.WithLabelValues(label).Inc()
+		validatorBlockRewardsGwei.WithLabelValues(label).Add(float64(row.Rewards))
+	}
+	return nil
+}
+
+func observeValidator(index uint64, prev, next *validatorState) {
+	label := indexLabel(index)
+	validatorBalanceGwei.WithLabelValues(label).Set(float64(next.balance))
+	validatorEffectiveBalanceGwei.WithLabelValues(label).Set(float64(next.effectiveBalance))
+	validatorLatestEpoch.WithLabelValues(label).Set(float64(next.epoch))
+	validatorSlashed.WithLabelValues(label).Set(boolGauge(storage.IsSlashedStatus(next.status)))
+	if prev != nil && prev.status != next.status {
+		validatorStatus.DeleteLabelValues(label, prev.status)
+	}
+	validatorStatus.WithLabelValues(label, next.status).Set(1)
+	for i, component := range rewardComponents {
+		if v := next.rewards[i]; v != nil {
+			validatorAttestationRewardGwei.WithLabelValues(label, component).Set(float64(*v))
+		}
+	}
+}
+
+// applyAggregate adds (sign 1) or removes (sign -1) one validator's latest state from the aggregate gauges.
+func applyAggregate(s *validatorState, sign float64) {
+	if s == nil {
+		return
+	}
+	validatorsBalanceGweiSum.Add(sign * float64(s.balance))
+	validatorsEffectiveBalanceGweiSum.Add(sign * float64(s.effectiveBalance))
+	validatorsByStatus.WithLabelValues(s.status).Add(sign)
+	validatorsSlashed.Add(sign * boolGauge(storage.IsSlashedStatus(s.status)))
+	for i, component := range rewardComponents {
+		if v := s.rewards[i]; v != nil {
+			validatorsAttestationRewardGweiSum.WithLabelValues(component).Add(sign * float64(*v))
+		}
+	}
+}
+
+func boolGauge(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+func TestRepository_watchedValidatorsOnly(t *testing.T) {
+	repo := NewRepository(noop.NewRepository(), func(i uint64) bool { return i == 101 }, Options{})
+	ctx := context.Background()
+
+	total := int64(-12)
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 101, Epoch: 20, Status: storage.StatusActiveOngoing, Balance: 32000000000, TotalReward: &total},
+		{ValidatorIndex: 102, Epoch: 20, Balance: 31000000000},
+	}))
+	// Backfilled older epoch must not overwrite the latest values.
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 101, Epoch: 5, Balance: 1},
+	}))
+
+	require.Equal(t, float64(32000000000), testutil.ToFloat64(validatorBalanceGwei.WithLabelValues("101")))
+	require.Equal(t, float64(-12), testutil.ToFloat64(validatorAttestationRewardGwei.WithLabelValues("101", "total")))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorStatus.WithLabelValues("101", storage.StatusActiveOngoing)))
+	require.Equal(t, float64(0), testutil.ToFloat64(validatorSlashed.WithLabelValues("101")))
+	require.Equal(t, 1, testutil.CollectAndCount(validatorBalanceGwei, "pauli_validator_balance_gwei"), "unwatched validator has no series")
+
+	require.NoError(t, repo.SaveAttestationLiveness(ctx, []*storage.AttestationLiveness{
+		{ValidatorIndex: 101, Included: false},
+		{ValidatorIndex: 102, Included: false},
+	}))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorAttestationsMissed.WithLabelValues("101")))
+
+	repo.Forget([]uint64{101})
+	require.Equal(t, 0, testutil.CollectAndCount(validatorBalanceGwei, "pauli_validator_balance_gwei"))
+	require.Equal(t, 0, testutil.CollectAndCount(validatorAttestationsMissed, "pauli_validator_attestations_missed_total"))
+}
+
+func TestRepository_aggregateOnly(t *testing.T) {
+	repo := NewRepository(noop.NewRepository(), func(i uint64) bool { return i == 1 || i == 2 }, Options{AggregateOnly: true})
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 1, Epoch: 7, Status: storage.StatusActiveOngoing, Balance: 10, EffectiveBalance: 8},
+		{ValidatorIndex: 2, Epoch: 7, Status: storage.StatusActiveSlashed, Balance: 5, EffectiveBalance: 4},
+		{ValidatorIndex: 3, Epoch: 7, Status: storage.StatusActiveOngoing, Balance: 1000},
+	}))
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 1, Epoch: 8, Status: storage.StatusActiveOngoing, Balance: 11, EffectiveBalance: 8},
+	}))
+
+	require.Equal(t, float64(16), testutil.ToFloat64(validatorsBalanceGweiSum))
+	require.Equal(t, float64(12), testutil.ToFloat64(validatorsEffectiveBalanceGweiSum))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorsSlashed))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorsByStatus.WithLabelValues(storage.StatusActiveOngoing)))
+
+	repo.Forget([]uint64{2})
+	require.Equal(t, float64(11), testutil.ToFloat64(validatorsBalanceGweiSum))
+	require.Equal(t, float64(0), testutil.ToFloat64(validatorsSlashed))
+}
+
+func TestRepository_reprocessedRowsCountedOnce(t *testing.T) {
+	repo := NewRepository(noop.NewRepository(), func(i uint64) bool { return i == 201 }, Options{})
+	ctx := context.Background()
+
+	block := &storage.Block{ValidatorIndex: 201, SlotNumber: 500, Rewards: 40}
+	require.NoError(t, repo.SaveBlock(ctx, block))
+	require.NoError(t, repo.SaveBlocks(ctx, []*storage.Block{block}))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorBlocksProposed.WithLabelValues("201")))
+	require.Equal(t, float64(40), testutil.ToFloat64(validatorBlockRewardsGwei.WithLabelValues("201")))
+
+	miss := []*storage.AttestationLiveness{{ValidatorIndex: 201, Slot: 480}}
+	require.NoError(t, repo.SaveAttestationLiveness(ctx, miss))
+	require.NoError(t, repo.SaveAttestationLiveness(ctx, miss))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorAttestationsMissed.WithLabelValues("201")))
+}
+
+func TestRepository_processedMarksOutliveWrapper(t *testing.T) {
+	inner := noop.NewRepository()
+	watched := func(i uint64) bool { return i == 202 }
+	ctx := context.Background()
+
+	block := &storage.Block{ValidatorIndex: 202, SlotNumber: 640, Rewards: 7}
+	require.NoError(t, NewRepository(inner, watched, Options{}).SaveBlock(ctx, block))
+	// A new wrapper over the same storage (e.g. after a restart) still sees the block as counted.
+	require.NoError(t, NewRepository(inner, watched, Options{}).SaveBlock(ctx, block))
+	require.Equal(t, float64(1), testutil.ToFloat64(validatorBlocksProposed.WithLabelValues("202")))
+}
@@ -0,0 +1,130 @@
+// Package metrics exposes Prometheus metrics: per-validator data-plane series fed from indexed rows
+// (see Repository) and the /metrics HTTP endpoint.
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const labelValidatorIndex = "validator_index"
+
+var (
+	validatorBalanceGwei = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validator_balance_gwei",
+		Help: "Validator balance at the start of the latest indexed epoch.",
+	}, []string{labelValidatorIndex})
+
+	validatorEffectiveBalanceGwei = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validator_effective_balance_gwei",
+		Help: "Validator effective balance at the start of the latest indexed epoch.",
+	}, []string{labelValidatorIndex})
+
+	validatorSlashed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validator_slashed",
+		Help: "1 if the validator's latest status is slashed, else 0.",
+	}, []string{labelValidatorIndex})
+
+	validatorStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validator_status",
+		Help: "Latest beacon status as a state set: 1 on the series whose status label is current.",
+	}, []string{labelValidatorIndex, "status"})
+
+	validatorAttestationRewardGwei = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validator_attestation_reward_gwei",
+		Help: "Attestation reward for the latest indexed epoch by component (head, source, target, total).",
+	}, []string{labelValidatorIndex, "component"})
+
+	validatorLatestEpoch = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validator_latest_epoch",
+		Help: "Latest epoch indexed for the validator.",
+	}, []string{labelValidatorIndex})
+
+	validatorAttestationsIncluded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_validator_attestations_included_total",
+		Help: "Attestations seen in a block within the inclusion window (provisional).",
+	}, []string{labelValidatorIndex})
+
+	validatorAttestationsMissed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_validator_attestations_missed_total",
+		Help: "Attestations not seen in a block within the inclusion window (provisional).",
+	}, []string{labelValidatorIndex})
+
+	validatorBlocksProposed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_validator_blocks_proposed_total",
+		Help: "Blocks proposed by the validator and indexed by this process.",
+	}, []string{labelValidatorIndex})
+
+	validatorBlockRewardsGwei = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pauli_validator_block_rewards_gwei_total",
+		Help: "Consensus proposer rewards of indexed blocks.",
+	}, []string{labelValidatorIndex})
+)
+
+// Aggregate-only series (metrics.aggregate_only): sums and counts across all watched validators.
+var (
+	validatorsBalanceGweiSum = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_validators_balance_gwei_sum",
+		Help: "Sum of latest balances of watched validators.",
+	})
+
+	validatorsEffectiveBalanceGweiSum = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_validators_effective_balance_gwei_sum",
+		Help: "Sum of latest effective balances of watched validators.",
+	})
+
+	validatorsByStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validators_status_count",
+		Help: "Watched validators by latest beacon status.",
+	}, []string{"status"})
+
+	validatorsSlashed = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pauli_validators_slashed_count",
+		Help: "Watched validators whose latest status is slashed.",
+	})
+
+	validatorsAttestationRewardGweiSum = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pauli_validators_attestation_reward_gwei_sum",
+		Help: "Sum of each watched validator's latest-epoch attestation reward by component.",
+	}, []string{"component"})
+
+	attestationsIncludedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_attestations_included_total",
+		Help: "Attestations of watched validators seen within the inclusion window (provisional).",
+	})
+
+	attestationsMissedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_attestations_missed_total",
+		Help: "Attestations of watched validators not seen within the inclusion window (provisional).",
+	})
+
+	blocksProposedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_blocks_proposed_total",
+		Help: "Blocks proposed by watched validators.",
+	})
+
+	blockRewardsGweiTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pauli_block_rewards_gwei_total",
+		Help: "Consensus proposer rewards of blocks proposed by watched validators.",
+	})
+)
+
+// perValidatorVecs lists every vector keyed by validator_index (for Forget).
+var perValidatorVecs = []*prometheus.MetricVec{
+	validatorBalanceGwei.MetricVec,
+	validatorEffectiveBalanceGwei.MetricVec,
+	validatorSlashed.MetricVec,
+	validatorStatus.MetricVec,
+	validatorAttestationRewardGwei.MetricVec,
+	validatorLatestEpoch.MetricVec,
+	validatorAttestationsIncluded.MetricVec,
+	validatorAttestationsMissed.MetricVec,
+	validatorBlocksProposed.MetricVec,
+	validatorBlockRewardsGwei.MetricVec,
+}
+
+func indexLabel(index uint64) string {
+	return strconv.FormatUint(index, 10)
+}
@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/notifier"
+)
+
+// FinalityLag tracks head epoch minus finalized epoch, raising a finality_lag warning while it
+// exceeds threshold and a resolved alert once finality catches up.
+type FinalityLag struct {
+	threshold uint64
+	notify    notifier.Notifier
+	log       zerolog.Logger
+
+	lagging bool // only read/written by Observe (realtime chain goroutine)
+}
+
+// NewFinalityLag creates a tracker alerting above threshold epochs of lag.
+func NewFinalityLag(threshold uint64, notify notifier.Notifier, log zerolog.Logger) *FinalityLag {
+	return &FinalityLag{threshold: threshold, notify: notify, log: log}
+}
+
+// Observe records one head/finalized sample.
+func (f *FinalityLag) Observe(ctx context.Context, headEpoch, finalizedEpoch uint64) {
+	var lag uint64
+	if headEpoch > finalizedEpoch {
+		lag = headEpoch - finalizedEpoch
+	}
+	metrics.FinalizationLagEpochs.Set(float64(lag))
+
+	ev := f.log.Info()
+	if lag > f.threshold {
+		ev = f.log.Warn()
+	}
+	ev.Str("check", "finality_lag").
+		Uint64("head_epoch", headEpoch).
+		Uint64("finalized_epoch", finalizedEpoch).
+		Uint64("lag_epochs", lag).
+		Uint64("threshold_epochs", f.threshold).
+		Msg("finality_lag: finalization lag")
+
+	switch {
+	case lag > f.threshold && !f.lagging:
+		f.lagging = true
+		f.send(ctx, notifier.Event{
+			Type:     notifier.EventFinalityLag,
+			Severity: notifier.SeverityWarning,
+			Epoch:    &finalizedEpoch,
+			Message:  fmt.Sprintf("finalized epoch %d is %d epochs behind head (limit %d)", finalizedEpoch, lag, f.threshold),
+		})
+	case lag <= f.threshold && f.lagging:
+		f.lagging = false
+		f.send(ctx, notifier.Event{
+			Type:     notifier.EventFinalityLag,
+			Severity: notifier.SeverityWarning,
+			Epoch:    &finalizedEpoch,
+			Message:  fmt.Sprintf("finality caught up: finalized epoch %d is %d epochs behind head", finalizedEpoch, lag),
+			Resolved: true,
+		})
+	}
+}
+
+func (f *FinalityLag) send(ctx context.Context, ev notifier.Event) {
+	if err := f.notify.Notify(ctx, ev); err != nil {
+		f.log.Error().Err(err).Str("alert", ev.Type).Msg("finality lag notification failed")
+	}
+}
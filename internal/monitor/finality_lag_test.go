@@ -0,0 +1,38 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/notifier"
+)
+
+func TestFinalityLag_firesOnceAndResolves(t *testing.T) {
+	rec := &recordingNotifier{}
+	f := NewFinalityLag(4, rec, zerolog.Nop())
+	ctx := context.Background()
+
+	f.Observe(ctx, 100, 98)
+	f.Observe(ctx, 101, 97)
+	require.Empty(t, rec.events, "lag at the threshold does not alert")
+
+	f.Observe(ctx, 102, 97)
+	f.Observe(ctx, 103, 97)
+	require.Len(t, rec.events, 1, "alert fires once while lagging")
+	require.Equal(t, notifier.EventFinalityLag, rec.events[0].Type)
+	require.False(t, rec.events[0].Resolved)
+	require.Equal(t, uint64(97), *rec.events[0].Epoch)
+
+	f.Observe(ctx, 104, 102)
+	require.Len(t, rec.events, 2)
+	require.True(t, rec.events[1].Resolved)
+}
+
+func TestFinalityLag_finalizedAheadOfHead(t *testing.T) {
+	rec := &recordingNotifier{}
+	f := NewFinalityLag(4, rec, zerolog.Nop())
+	f.Observe(context.Background(), 0, 1)
+	require.Empty(t, rec.events)
+}
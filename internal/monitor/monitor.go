@@ -2,15 +2,24 @@ package monitor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/archive"
 	"github.com/tharun/pauli/internal/beacon"
 	"github.com/tharun/pauli/internal/config"
 	"github.com/tharun/pauli/internal/execution"
+	"github.com/tharun/pauli/internal/metrics"
 	"github.com/tharun/pauli/internal/monitor/queue"
 	runbackfill "github.com/tharun/pauli/internal/monitor/runner/backfill"
 	runrealtime "github.com/tharun/pauli/internal/monitor/runner/realtime"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/notifier"
 	"github.com/tharun/pauli/internal/storage"
 )
 
@@ -24,6 +33,19 @@ type Monitor struct {
 	pool    *queue.Pool
 	logger  zerolog.Logger
 	wg      sync.WaitGroup
+
+	// validatorsMu serializes ReloadValidators; realtimeR is set by Start.
+	validatorsMu sync.Mutex
+	realtimeR    *runrealtime.Runner
+	// watched is the validators list as a set, read by metrics on every saved row.
+	watched atomic.Pointer[map[uint64]struct{}]
+	// metricsRepo is non-nil when metrics are enabled (wraps repo).
+	metricsRepo *metrics.Repository
+	notify      notifier.Notifier
+	// watchdog is nil when watchdog.disabled is set.
+	watchdog *Watchdog
+	// finalityLag always exports the gauge; it only alerts when the watchdog is enabled.
+	finalityLag *FinalityLag
 }
 
 // NewMonitor creates a new Monitor instance.
@@ -36,8 +58,29 @@ func NewMonitor(cfg *config.Config, client *beacon.Client, repo storage.Reposito
 		network: network,
 		logger:  logger,
 	}
+	m.setWatched(cfg.Validators)
+
+	if cfg.Metrics.Enabled {
+		m.metricsRepo = metrics.NewRepository(repo, m.isWatched, metrics.Options{AggregateOnly: cfg.Metrics.AggregateOnly})
+		m.repo = m.metricsRepo
+	}
 
-	m.pool = queue.NewPool(cfg.WorkerPoolSize, queue.StepJobRunner(), logger)
+	m.notify = notifier.New(cfg.Notifications, logger)
+
+	jobRunner := queue.WithDeadline(queue.StepJobRunner(), m.jobDeadline, func(job steps.Job) {
+		metrics.JobsDeadlineExceeded.WithLabelValues(fmt.Sprintf("%T", job.Step)).Inc()
+	})
+	if !cfg.Watchdog.Disabled {
+		m.watchdog = NewWatchdog(m.watchdogMaxSilence(), m.notify, logger)
+		jobRunner = queue.WithSuccessHook(jobRunner, m.watchdog.Touch)
+		m.finalityLag = NewFinalityLag(cfg.Watchdog.FinalityLagEpochs, m.notify, logger)
+	} else {
+		m.finalityLag = NewFinalityLag(cfg.Watchdog.FinalityLagEpochs, notifier.Multi{}, logger)
+	}
+	m.pool = queue.NewPool(cfg.WorkerPoolSize, cfg.WorkerQueueSize, jobRunner, logger)
+	if cfg.WorkerPoolWarmStart > 0 {
+		m.pool.WarmStart(cfg.WorkerPoolWarmStart)
+	}
 
 	return m
 }
@@ -50,22 +93,43 @@ func (m *Monitor) Start(ctx context.Context) error {
 
 	m.logNodeSyncStatus(ctx)
 
+	if err := m.syncValidatorSetOnStartup(ctx); err != nil {
+		m.logger.Warn().Err(err).Msg("validator set audit on startup failed")
+	}
+
+	if m.cfg.RawResponses.Enabled {
+		archive := NewRawResponseArchive(m.cfg.RawResponses, m.repo, m.network, m.logger)
+		m.client.RecordRawResponses(archive.Record)
+		m.startBackgroundWorker(ctx, archive.Run)
+	}
+
+	if m.cfg.Archive.Enabled {
+		if err := m.startArchive(ctx); err != nil {
+			return err
+		}
+	}
+
 	enqueue := m.pool.Enqueue
 	execClient := execution.NewClient(m.cfg)
-	realtimeR := runrealtime.New(m.network, m.client, execClient, m.repo, m.client.GetHeadSlot, m.cfg.Validators, m.logger, enqueue)
-	if maxSlot, ok, err := m.repo.MaxIndexedSlot(ctx); err != nil {
-		m.logger.Warn().Err(err).Msg("seed realtime cursor: max indexed slot lookup failed")
-	} else if ok {
-		realtimeR.SetLastProcessedSlot(maxSlot)
-		m.logger.Debug().Uint64("last_processed_slot", maxSlot).Msg("seeded realtime cursor from indexer_progress")
-	}
+	realtimeR := m.newRealtimeRunner(ctx, runrealtime.Options{}, execClient)
+	m.validatorsMu.Lock()
+	m.realtimeR = realtimeR
+	m.validatorsMu.Unlock()
 
 	m.pool.Start(ctx)
 
 	m.startBackgroundWorker(ctx, func(runCtx context.Context) { realtimeR.Start(runCtx) })
+	if m.watchdog != nil {
+		m.startBackgroundWorker(ctx, m.watchdog.Run)
+	}
+	if m.cfg.BeaconConsistency.Enabled() {
+		interval := time.Duration(m.cfg.BeaconConsistency.IntervalEpochs*config.SlotsPerEpoch()) * m.network.SlotDuration()
+		consistency := NewNodeConsistency(m.cfg, m.client, interval, m.watchedValidators, m.notify, m.logger)
+		m.startBackgroundWorker(ctx, consistency.Run)
+	}
 
 	if m.cfg.Backfill.Enabled {
-		backfillR := runbackfill.New(m.cfg.Backfill, runbackfill.Options{}, m.client, execClient, m.repo, m.client.GetHeadSlot, m.logger.With().Str("runner", "backfill").Logger(), enqueue)
+		backfillR := runbackfill.New(m.network, m.cfg.Backfill, runbackfill.Options{}, m.client, execClient, m.repo, m.client.GetHeadSlot, m.logger.With().Str("runner", "backfill").Logger(), enqueue)
 		m.startBackgroundWorker(ctx, func(runCtx context.Context) { backfillR.Start(runCtx) })
 		m.logger.Info().Msg("backfill runner started")
 	}
@@ -79,6 +143,166 @@ func (m *Monitor) Start(ctx context.Context) error {
 	return nil
 }
 
+// RunOnce runs exactly one realtime chain pass (head block plus the latest finalized epoch),
+// drains the worker pool, and returns. No pacing loop or backfill runner is started.
+func (m *Monitor) RunOnce(ctx context.Context) error {
+	if err := InitBeaconNetworkClock(ctx, m.client, m.network, m.logger); err != nil {
+		return err
+	}
+
+	m.logNodeSyncStatus(ctx)
+
+	realtimeR := m.newRealtimeRunner(ctx, runrealtime.Options{OneShot: true}, execution.NewClient(m.cfg))
+
+	m.pool.Start(ctx)
+	m.logger.Info().Int("validators", len(m.cfg.Validators)).Msg("one-shot cycle started")
+	realtimeR.Start(ctx)
+	m.pool.Stop(ctx)
+	m.logger.Info().Msg("one-shot cycle finished")
+
+	return ctx.Err()
+}
+
+// newRealtimeRunner builds the realtime runner and seeds its cursors from scheduler_state and
+// indexer_progress so an already processed head is not re-enqueued after a restart.
+func (m *Monitor) newRealtimeRunner(ctx context.Context, opts runrealtime.Options, execClient *execution.Client) *runrealtime.Runner {
+	opts.AttestationDuties = m.cfg.AttestationDuties
+	opts.ProposerDuties = m.cfg.ProposerDuties
+	opts.RewardsReconcile = m.cfg.RewardsReconcile
+	opts.ValidatorSampling = m.cfg.ValidatorSampling
+	opts.PriorityValidators = m.cfg.PriorityValidators
+	opts.InactiveValidators = m.cfg.InactiveValidators
+	opts.ValidatorLiveness = m.cfg.ValidatorLiveness
+	opts.MaxSyncDistance = m.cfg.MaxSyncDistance
+	opts.PauseWhileSyncing = !m.cfg.PollWhileSyncing
+	opts.Events = m.cfg.Events
+	opts.Jobs = m.cfg.Jobs
+	if !m.cfg.Jobs.DisableRewards {
+		opts.RewardsReorgLookbackEpochs = m.cfg.RewardsReorgLookbackEpochs
+	}
+	opts.ResultLevels = steps.NewResultLevels(m.cfg.ResultLogLevels)
+	opts.OnFinality = m.finalityLag.Observe
+	enqueue := m.pool.Enqueue
+	if !opts.OneShot {
+		enqueue = m.tryEnqueue
+	}
+	realtimeR := runrealtime.New(m.network, opts, m.client, execClient, m.repo, m.client.GetHeadSlot, m.cfg.Validators, m.logger, enqueue)
+
+	var (
+		lastSlot uint64
+		haveSlot bool
+	)
+	if maxSlot, ok, err := m.repo.MaxIndexedSlot(ctx); err != nil {
+		m.logger.Warn().Err(err).Msg("seed realtime cursor: max indexed slot lookup failed")
+	} else if ok {
+		lastSlot, haveSlot = maxSlot, true
+	}
+	if state, ok, err := m.repo.GetSchedulerState(ctx, storage.SchedulerStateRealtimeSlot); err != nil {
+		m.logger.Warn().Err(err).Msg("seed realtime cursor: scheduler state lookup failed")
+	} else if ok {
+		if !haveSlot || state.Position > lastSlot {
+			lastSlot, haveSlot = state.Position, true
+		}
+		m.logger.Info().
+			Uint64("last_processed_slot", state.Position).
+			Time("last_processed_at", state.UpdatedAt).
+			Dur("gap", time.Since(state.UpdatedAt).Truncate(time.Second)).
+			Msg("resuming realtime scheduler")
+	}
+	if haveSlot {
+		realtimeR.SetLastProcessedSlot(lastSlot)
+		m.logger.Debug().Uint64("last_processed_slot", lastSlot).Msg("seeded realtime cursor")
+	}
+	if state, ok, err := m.repo.GetSchedulerState(ctx, storage.SchedulerStateLivenessEpoch); err != nil {
+		m.logger.Warn().Err(err).Msg("seed liveness cursor: scheduler state lookup failed")
+	} else if ok {
+		realtimeR.SetLivenessEpoch(state.Position)
+	}
+	return realtimeR
+}
+
+// ResizeWorkers changes the worker count at runtime (SIGHUP reload of worker_pool_size).
+func (m *Monitor) ResizeWorkers(n int) error {
+	if n == m.pool.Size() {
+		return nil
+	}
+	return m.pool.Resize(n)
+}
+
+// tryEnqueue queues a realtime job without blocking the scheduler; a full queue drops the job (counted in
+// pauli_jobs_dropped_total) and the engine stops the pass, so the same head is retried on the next poll.
+func (m *Monitor) tryEnqueue(_ context.Context, job steps.Job) error {
+	err := m.pool.TryEnqueue(job)
+	if errors.Is(err, queue.ErrQueueFull) {
+		metrics.JobsDropped.WithLabelValues(fmt.Sprintf("%T", job.Step)).Inc()
+	}
+	return err
+}
+
+// jobDeadline is the start of slot HeadSlot+job_deadline_slots, or job_deadline_slots from now when
+// that is already past (backfill jobs about old slots) or genesis is not known yet.
+func (m *Monitor) jobDeadline(job steps.Job) time.Time {
+	k := m.cfg.JobDeadlineSlots
+	fallback := time.Now().Add(time.Duration(k) * m.network.SlotDuration())
+	if d := m.network.SlotTime(job.Env.HeadSlot + k); d.After(time.Now()) {
+		return d
+	}
+	return fallback
+}
+
+// watchdogMaxSilence is watchdog.max_silence_seconds, or three poll intervals (at least 5 minutes).
+func (m *Monitor) watchdogMaxSilence() time.Duration {
+	if m.cfg.Watchdog.MaxSilenceSeconds > 0 {
+		return time.Duration(m.cfg.Watchdog.MaxSilenceSeconds) * time.Second
+	}
+	d := 3 * m.network.PollInterval()
+	if d < 5*time.Minute {
+		d = 5 * time.Minute
+	}
+	return d
+}
+
+func (m *Monitor) setWatched(validators []uint64) {
+	set := make(map[uint64]struct{}, len(validators))
+	for _, v := range validators {
+		set[v] = struct{}{}
+	}
+	m.watched.Store(&set)
+}
+
+func (m *Monitor) isWatched(index uint64) bool {
+	_, ok := (*m.watched.Load())[index]
+	return ok
+}
+
+// startArchive runs the archive job for the watched validators in the background.
+func (m *Monitor) startArchive(ctx context.Context) error {
+	ac := m.cfg.Archive
+	store, err := archive.NewS3ClientFromConfig(ac)
+	if err != nil {
+		return err
+	}
+	archiver := archive.New(store, m.repo, m.network, m.watchedValidators, archive.Options{
+		Prefix:   ac.Prefix,
+		After:    time.Duration(ac.AfterDays) * 24 * time.Hour,
+		Interval: time.Duration(ac.IntervalMinutes) * time.Minute,
+	}, m.logger.With().Str("component", "archive").Logger())
+	m.startBackgroundWorker(ctx, archiver.Run)
+	m.logger.Info().Str("bucket", ac.Bucket).Int("after_days", ac.AfterDays).Msg("archive job started")
+	return nil
+}
+
+// watchedValidators returns the current validators list, ascending.
+func (m *Monitor) watchedValidators() []uint64 {
+	set := *m.watched.Load()
+	out := make([]uint64, 0, len(set))
+	for v := range set {
+		out = append(out, v)
+	}
+	slices.Sort(out)
+	return out
+}
+
 func (m *Monitor) startBackgroundWorker(ctx context.Context, run func(context.Context)) {
 	m.wg.Add(1)
 	go func() {
@@ -99,6 +323,11 @@ func (m *Monitor) Stop(drainCtx context.Context) {
 	m.logger.Info().Msg("monitor stopped")
 }
 
+// PendingJobs returns how many jobs are queued and running in the worker pool.
+func (m *Monitor) PendingJobs() (queued, running int) {
+	return m.pool.Pending()
+}
+
 // Wait blocks until the monitor is stopped.
 func (m *Monitor) Wait() {
 	m.wg.Wait()
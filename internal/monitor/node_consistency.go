@@ -0,0 +1,232 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/notifier"
+)
+
+// maxDivergentValidators caps the validator indices listed in one divergence log line and alert.
+const maxDivergentValidators = 10
+
+// referenceNode is a beacon_consistency reference node; name (its host) labels logs and metrics.
+type referenceNode struct {
+	name   string
+	client *beacon.Client
+}
+
+// NodeConsistency periodically asks each reference node for data already served by the primary
+// beacon node (the finalized checkpoint and a sample of attestation rewards) and raises a
+// beacon_node_divergence alert per node while they disagree, resolved once they agree again.
+type NodeConsistency struct {
+	primary    *beacon.Client
+	nodes      []referenceNode
+	interval   time.Duration
+	sample     int
+	validators func() []uint64
+	notify     notifier.Notifier
+	log        zerolog.Logger
+
+	// diverged and round are only used by check (Run goroutine).
+	diverged map[string]bool
+	round    uint64
+}
+
+// NewNodeConsistency builds a client per cfg.BeaconConsistency reference node (same HTTP, rate limit
+// and path settings as beacon_node_url) checking every interval. validators returns the watched
+// validators the rewards sample rotates through.
+func NewNodeConsistency(cfg *config.Config, primary *beacon.Client, interval time.Duration, validators func() []uint64, notify notifier.Notifier, log zerolog.Logger) *NodeConsistency {
+	c := &NodeConsistency{
+		primary:    primary,
+		interval:   interval,
+		sample:     cfg.BeaconConsistency.SampleValidators,
+		validators: validators,
+		notify:     notify,
+		log:        log,
+		diverged:   make(map[string]bool),
+	}
+	for i, n := range cfg.BeaconConsistency.ReferenceNodes {
+		rc := *cfg
+		rc.BeaconNodeURL = n.URL
+		rc.BeaconAPIKey = n.APIKey
+		name := fmt.Sprintf("reference_%d", i)
+		if u, err := url.Parse(n.URL); err == nil && u.Host != "" {
+			name = u.Host
+		}
+		c.nodes = append(c.nodes, referenceNode{name: name, client: beacon.NewClient(&rc)})
+	}
+	return c
+}
+
+// Run logs each node's client version, then checks every interval until ctx is done.
+func (c *NodeConsistency) Run(ctx context.Context) {
+	ev := c.log.Info().Str("primary_version", c.version(ctx, c.primary))
+	for _, n := range c.nodes {
+		ev = ev.Str(n.name, c.version(ctx, n.client))
+	}
+	ev.Dur("interval", c.interval).Msg("beacon consistency check started")
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+func (c *NodeConsistency) check(ctx context.Context) {
+	cp, err := c.primary.GetFinalityCheckpoints(ctx, "head")
+	if err != nil {
+		beacon.LogErrorContext(c.log.Warn().Err(err), err).Msg("beacon consistency: primary finality checkpoints failed; check skipped")
+		return
+	}
+	finalized := cp.Finalized
+	if finalized.Epoch == 0 {
+		return
+	}
+	// Rewards for the finalized epoch itself may not be served yet (see beacon.Client.FinalizedEpoch).
+	rewardsEpoch := uint64(finalized.Epoch) - 1
+	sample := c.nextSample()
+	var rewards map[uint64]*beacon.AttestationReward
+	if len(sample) > 0 {
+		rewards, err = c.primary.GetAttestationRewardsMap(ctx, rewardsEpoch, sample)
+		if err != nil {
+			beacon.LogErrorContext(c.log.Warn().Err(err), err).Msg("beacon consistency: primary attestation rewards failed; check skipped")
+			return
+		}
+	}
+	for _, n := range c.nodes {
+		c.checkNode(ctx, n, finalized, rewardsEpoch, sample, rewards)
+	}
+}
+
+// checkNode compares one reference node against the primary's answers.
+func (c *NodeConsistency) checkNode(ctx context.Context, n referenceNode, finalized beacon.Checkpoint, rewardsEpoch uint64, sample []uint64, rewards map[uint64]*beacon.AttestationReward) {
+	var problems []string
+	header, err := n.client.GetBlockHeader(ctx, finalized.Root)
+	switch {
+	case beacon.IsNotFound(err):
+		metrics.BeaconNodeDivergences.WithLabelValues(n.name, "finalized_root").Inc()
+		problems = append(problems, fmt.Sprintf("finalized root %s (epoch %d) unknown", finalized.Root, finalized.Epoch))
+	case err != nil:
+		c.nodeError(n, err)
+		return
+	case !header.Data.Canonical:
+		metrics.BeaconNodeDivergences.WithLabelValues(n.name, "finalized_root").Inc()
+		problems = append(problems, fmt.Sprintf("finalized root %s (epoch %d) not canonical", finalized.Root, finalized.Epoch))
+	}
+
+	var differing []uint64
+	if len(sample) > 0 {
+		ref, err := n.client.GetAttestationRewardsMap(ctx, rewardsEpoch, sample)
+		if err != nil {
+			c.nodeError(n, err)
+			return
+		}
+		differing = diffAttestationRewards(sample, rewards, ref)
+		if len(differing) > 0 {
+			metrics.BeaconNodeDivergences.WithLabelValues(n.name, "attestation_rewards").Inc()
+			problems = append(problems, fmt.Sprintf("attestation rewards for epoch %d differ for %d of %d validators", rewardsEpoch, len(differing), len(sample)))
+		}
+	}
+
+	if len(problems) == 0 {
+		metrics.BeaconNodeChecks.WithLabelValues(n.name, "ok").Inc()
+		if c.diverged[n.name] {
+			c.diverged[n.name] = false
+			c.log.Info().Str("node", n.name).Msg("beacon consistency: reference node agrees with the primary again")
+			c.send(ctx, notifier.Event{
+				Type:     notifier.EventNodeDivergence,
+				Severity: notifier.SeverityWarning,
+				Message:  fmt.Sprintf("beacon node %s agrees with the primary node again", n.name),
+				Resolved: true,
+			})
+		}
+		return
+	}
+
+	metrics.BeaconNodeChecks.WithLabelValues(n.name, "diverged").Inc()
+	if len(differing) > maxDivergentValidators {
+		differing = differing[:maxDivergentValidators]
+	}
+	summary := strings.Join(problems, "; ")
+	c.log.Warn().Str("warning", "beacon_node_divergence").
+		Str("node", n.name).
+		Str("node_version", c.version(ctx, n.client)).
+		Str("primary_version", c.version(ctx, c.primary)).
+		Uint64("finalized_epoch", uint64(finalized.Epoch)).
+		Uints64("validators", differing).
+		Msg("beacon_node_divergence: " + summary)
+	if !c.diverged[n.name] {
+		c.diverged[n.name] = true
+		epoch := uint64(finalized.Epoch)
+		c.send(ctx, notifier.Event{
+			Type:     notifier.EventNodeDivergence,
+			Severity: notifier.SeverityWarning,
+			Epoch:    &epoch,
+			Message:  fmt.Sprintf("beacon node %s disagrees with the primary node: %s", n.name, summary),
+		})
+	}
+}
+
+// diffAttestationRewards returns the validators of sample whose reward is missing from one side or differs.
+func diffAttestationRewards(sample []uint64, primary, ref map[uint64]*beacon.AttestationReward) []uint64 {
+	var out []uint64
+	for _, v := range sample {
+		p, r := primary[v], ref[v]
+		if (p == nil) != (r == nil) || (p != nil && *p != *r) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// nextSample returns the next sample of up to c.sample watched validators, rotating through the list.
+func (c *NodeConsistency) nextSample() []uint64 {
+	validators := c.validators()
+	n := c.sample
+	if n > len(validators) {
+		n = len(validators)
+	}
+	if n == 0 {
+		return nil
+	}
+	start := (c.round * uint64(n)) % uint64(len(validators))
+	c.round++
+	out := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, validators[(start+uint64(i))%uint64(len(validators))])
+	}
+	return out
+}
+
+func (c *NodeConsistency) nodeError(n referenceNode, err error) {
+	metrics.BeaconNodeChecks.WithLabelValues(n.name, "error").Inc()
+	beacon.LogErrorContext(c.log.Warn().Err(err).Str("node", n.name), err).Msg("beacon consistency: reference node request failed")
+}
+
+func (c *NodeConsistency) version(ctx context.Context, client *beacon.Client) string {
+	v, err := client.GetNodeVersion(ctx)
+	if err != nil {
+		return "unknown"
+	}
+	return v
+}
+
+func (c *NodeConsistency) send(ctx context.Context, ev notifier.Event) {
+	if err := c.notify.Notify(ctx, ev); err != nil {
+		c.log.Error().Err(err).Str("alert", ev.Type).Msg("beacon divergence notification failed")
+	}
+}
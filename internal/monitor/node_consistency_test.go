@@ -0,0 +1,81 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/notifier"
+)
+
+// fakeBeaconNode serves finality checkpoints at epoch 10, the finalized block header, and attestation
+// rewards of 100 gwei per validator (plus the returned offset).
+func fakeBeaconNode(t *testing.T) (string, *atomic.Int64) {
+	var offset atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/finality_checkpoints"):
+			_, _ = fmt.Fprint(w, `{"data":{"finalized":{"epoch":"10","root":"0xaa"}}}`)
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/headers/"):
+			_, _ = fmt.Fprint(w, `{"data":{"root":"0xaa","canonical":true}}`)
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/rewards/attestations/9"):
+			reward := 100 + offset.Load()
+			_, _ = fmt.Fprintf(w, `{"data":{"total_rewards":[{"validator_index":"1","head":"%d","target":"0","source":"0"},{"validator_index":"2","head":"100","target":"0","source":"0"}]}}`, reward)
+		case r.URL.Path == "/eth/v1/node/version":
+			_, _ = fmt.Fprint(w, `{"data":{"version":"Test/v1"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, &offset
+}
+
+func TestNodeConsistency_divergenceAlertsAndResolves(t *testing.T) {
+	primaryURL, _ := fakeBeaconNode(t)
+	refURL, refOffset := fakeBeaconNode(t)
+	cfg := &config.Config{
+		BeaconNodeURL: primaryURL,
+		BeaconAPI:     config.BeaconAPIConf{MaxValidatorsPerRequest: 100},
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1, Cache: config.BeaconCacheConf{Disabled: true}},
+		BeaconConsistency: config.BeaconConsistencyConf{
+			ReferenceNodes:   []config.BeaconReferenceNode{{URL: refURL}},
+			SampleValidators: 2,
+		},
+	}
+	rec := &recordingNotifier{}
+	c := NewNodeConsistency(cfg, beacon.NewClient(cfg), time.Minute, func() []uint64 { return []uint64{1, 2} }, rec, zerolog.Nop())
+	ctx := context.Background()
+
+	c.check(ctx)
+	require.Empty(t, rec.events, "identical answers do not alert")
+
+	refOffset.Store(1)
+	c.check(ctx)
+	c.check(ctx)
+	require.Len(t, rec.events, 1, "alert fires once while the nodes disagree")
+	require.Equal(t, notifier.EventNodeDivergence, rec.events[0].Type)
+	require.Contains(t, rec.events[0].Message, "differ for 1 of 2 validators")
+
+	refOffset.Store(0)
+	c.check(ctx)
+	require.Len(t, rec.events, 2)
+	require.True(t, rec.events[1].Resolved)
+}
+
+func TestNodeConsistency_sampleRotates(t *testing.T) {
+	c := &NodeConsistency{sample: 2, validators: func() []uint64 { return []uint64{1, 2, 3} }}
+	require.Equal(t, []uint64{1, 2}, c.nextSample())
+	require.Equal(t, []uint64{3, 1}, c.nextSample())
+	require.Equal(t, []uint64{2, 3}, c.nextSample())
+}
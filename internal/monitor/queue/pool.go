@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
 	"github.com/tharun/pauli/internal/monitor/steps"
 )
 
@@ -15,9 +17,9 @@ type Runner interface {
 	Run(ctx context.Context, job steps.Job) error
 }
 
-// Pool runs queued steps concurrently with a fixed number of workers.
+// Pool runs queued steps concurrently. The worker count can change at runtime (Resize) and, with
+// WarmStart, grows from a smaller initial count while jobs are waiting.
 type Pool struct {
-	size     int
 	workChan chan steps.Job
 	wg       sync.WaitGroup
 	runner   Runner
@@ -26,26 +28,106 @@ type Pool struct {
 	mu      sync.RWMutex
 	runCtx  context.Context // context passed to Runner.Run; replaced before drain on Stop
 	stopped bool
+	started bool
+	size    int // target worker count
+	initial int // workers started by Start (WarmStart); 0 = size
+	running int // live workers
+	nextID  int
+	// resized is closed (and replaced) when size shrinks, waking idle workers to retire.
+	resized chan struct{}
+	// active counts jobs inside Runner.Run.
+	active atomic.Int64
 }
 
-func NewPool(size int, runner Runner, logger zerolog.Logger) *Pool {
+// NewPool returns a pool of size workers with room for queueSize waiting jobs.
+func NewPool(size, queueSize int, runner Runner, logger zerolog.Logger) *Pool {
 	return &Pool{
 		size:     size,
-		workChan: make(chan steps.Job, size*2),
+		workChan: make(chan steps.Job, queueSize),
 		runner:   runner,
 		logger:   logger,
+		resized:  make(chan struct{}),
 	}
 }
 
+// WarmStart makes Start launch only initial workers; one more is added whenever a job is queued
+// behind others, up to the pool size. Call before Start.
+func (p *Pool) WarmStart(initial int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.initial = initial
+}
+
 // Start launches workers. runCtx is used for Runner.Run until Stop replaces it with the drain context.
 func (p *Pool) Start(runCtx context.Context) {
 	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.runCtx = runCtx
-	p.mu.Unlock()
+	p.started = true
+	n := p.size
+	if p.initial > 0 && p.initial < n {
+		n = p.initial
+	}
+	for p.running < n {
+		p.spawnLocked()
+	}
+}
 
-	for i := 0; i < p.size; i++ {
-		p.wg.Add(1)
-		go p.worker(i)
+// Resize sets the worker count to n (at least 1). Extra workers are started at once; surplus workers
+// exit after their current job. The queue size is fixed at construction.
+func (p *Pool) Resize(n int) error {
+	if n < 1 {
+		n = 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return ErrPoolStopped
+	}
+	p.size = n
+	if !p.started {
+		return nil
+	}
+	for p.running < n {
+		p.spawnLocked()
+	}
+	if p.running > n {
+		close(p.resized)
+		p.resized = make(chan struct{})
+	}
+	p.logger.Info().Int("workers", n).Msg("worker pool resized")
+	return nil
+}
+
+// Size returns the target worker count.
+func (p *Pool) Size() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.size
+}
+
+// Pending returns how many jobs are queued and how many are running.
+func (p *Pool) Pending() (queued, running int) {
+	return len(p.workChan), int(p.active.Load())
+}
+
+// spawnLocked starts one worker; p.mu must be held for writing.
+func (p *Pool) spawnLocked() {
+	p.running++
+	p.wg.Add(1)
+	go p.worker(p.nextID)
+	p.nextID++
+}
+
+// grow adds a worker during warm start when jobs are waiting and the pool is below its size.
+func (p *Pool) grow() {
+	if len(p.workChan) == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started && !p.stopped && p.running < p.size {
+		p.spawnLocked()
 	}
 }
 
@@ -54,30 +136,64 @@ func (p *Pool) worker(id int) {
 	p.logger.Debug().Int("worker_id", id).Msg("indexing worker started")
 
 	for {
-		job, ok := <-p.workChan
-		if !ok {
-			p.logger.Debug().Int("worker_id", id).Msg("indexing worker work channel closed")
+		p.mu.Lock()
+		if !p.stopped && p.running > p.size {
+			p.running--
+			p.mu.Unlock()
+			p.logger.Debug().Int("worker_id", id).Msg("indexing worker retired after resize")
 			return
 		}
-		stepName := "<nil>"
-		if job.Step != nil {
-			stepName = fmt.Sprintf("%T", job.Step)
+		resized := p.resized
+		p.mu.Unlock()
+
+		select {
+		case job, ok := <-p.workChan:
+			if !ok {
+				p.mu.Lock()
+				p.running--
+				p.mu.Unlock()
+				p.logger.Debug().Int("worker_id", id).Msg("indexing worker work channel closed")
+				return
+			}
+			p.run(id, job)
+		case <-resized:
 		}
-		p.mu.RLock()
-		rc := p.runCtx
-		p.mu.RUnlock()
-		if rc == nil {
-			rc = context.Background()
+	}
+}
+
+func (p *Pool) run(id int, job steps.Job) {
+	stepName := "<nil>"
+	if job.Step != nil {
+		stepName = fmt.Sprintf("%T", job.Step)
+	}
+	p.mu.RLock()
+	rc := p.runCtx
+	p.mu.RUnlock()
+	if rc == nil {
+		rc = context.Background()
+	}
+	p.active.Add(1)
+	defer p.active.Add(-1)
+	if err := p.runner.Run(rc, job); err != nil {
+		ev := p.logger.Error().Err(err).Int("worker_id", id).Str("step", stepName).
+			Uint64("head_slot", job.Env.HeadSlot).Int("job_validators", len(job.Env.ValidatorIndices))
+		if len(job.Env.RewardsEpochs) > 0 {
+			ev = ev.Uints64("rewards_epochs", job.Env.RewardsEpochs)
 		}
-		if err := p.runner.Run(rc, job); err != nil {
-			p.logger.Error().Err(err).Int("worker_id", id).Str("step", stepName).Msg("async step failed")
+		if len(job.Env.RewardsGapEpochs) > 0 {
+			ev = ev.Uints64("rewards_gap_epochs", job.Env.RewardsGapEpochs)
 		}
+		beacon.LogErrorContext(ev, err).Msg("async step failed")
 	}
 }
 
 // ErrPoolStopped is returned from Enqueue after Stop has closed the work channel.
 var ErrPoolStopped = errors.New("pool stopped")
 
+// ErrQueueFull is returned from TryEnqueue when every queue slot is taken.
+var ErrQueueFull = errors.New("worker queue full")
+
+// Enqueue queues job, blocking while the queue is full until ctx is done.
 func (p *Pool) Enqueue(ctx context.Context, job steps.Job) error {
 	p.mu.RLock()
 	stopped := p.stopped
@@ -90,7 +206,26 @@ func (p *Pool) Enqueue(ctx context.Context, job steps.Job) error {
 	case <-ctx.Done():
 		return ctx.Err()
 	case p.workChan <- job:
+		p.grow()
+		return nil
+	}
+}
+
+// TryEnqueue queues job without blocking and returns ErrQueueFull when the queue has no room.
+func (p *Pool) TryEnqueue(job steps.Job) error {
+	p.mu.RLock()
+	stopped := p.stopped
+	p.mu.RUnlock()
+	if stopped {
+		return ErrPoolStopped
+	}
+
+	select {
+	case p.workChan <- job:
+		p.grow()
 		return nil
+	default:
+		return ErrQueueFull
 	}
 }
 
@@ -0,0 +1,103 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+func TestPool_TryEnqueueDropsWhenFull(t *testing.T) {
+	ran := make(chan struct{}, 3)
+	p := NewPool(1, 2, runnerFunc(func(context.Context, steps.Job) error {
+		ran <- struct{}{}
+		return nil
+	}), zerolog.Nop())
+
+	// Workers not started: the queue holds exactly queueSize jobs.
+	require.NoError(t, p.TryEnqueue(steps.Job{}))
+	require.NoError(t, p.TryEnqueue(steps.Job{}))
+	require.ErrorIs(t, p.TryEnqueue(steps.Job{}), ErrQueueFull)
+
+	p.Start(context.Background())
+	p.Stop(context.Background())
+	require.Len(t, ran, 2)
+	require.ErrorIs(t, p.TryEnqueue(steps.Job{}), ErrPoolStopped)
+}
+
+func TestPool_ResizeConcurrentWithEnqueue(t *testing.T) {
+	var ran atomic.Int64
+	p := NewPool(2, 64, runnerFunc(func(context.Context, steps.Job) error {
+		ran.Add(1)
+		return nil
+	}), zerolog.Nop())
+	p.WarmStart(1)
+	p.Start(context.Background())
+
+	const producers, perProducer = 4, 200
+	var wg sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				assert.NoError(t, p.Enqueue(context.Background(), steps.Job{}))
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			assert.NoError(t, p.Resize(1+i%5))
+		}
+	}()
+	wg.Wait()
+	require.Equal(t, 5, p.Size())
+
+	p.Stop(context.Background())
+	require.Equal(t, int64(producers*perProducer), ran.Load())
+	require.ErrorIs(t, p.Resize(3), ErrPoolStopped)
+}
+
+func TestPool_ResizeRetiresIdleWorkers(t *testing.T) {
+	p := NewPool(4, 1, runnerFunc(func(context.Context, steps.Job) error { return nil }), zerolog.Nop())
+	p.Start(context.Background())
+	require.NoError(t, p.Resize(1))
+	require.Eventually(t, func() bool {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		return p.running == 1
+	}, time.Second, time.Millisecond)
+	p.Stop(context.Background())
+}
+
+func TestPool_PendingCountsQueuedAndRunning(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	p := NewPool(1, 4, runnerFunc(func(context.Context, steps.Job) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}), zerolog.Nop())
+	require.NoError(t, p.TryEnqueue(steps.Job{}))
+	require.NoError(t, p.TryEnqueue(steps.Job{}))
+	p.Start(context.Background())
+	<-started
+
+	queued, running := p.Pending()
+	require.Equal(t, 1, queued)
+	require.Equal(t, 1, running)
+
+	close(release)
+	<-started
+	p.Stop(context.Background())
+	queued, running = p.Pending()
+	require.Zero(t, queued)
+	require.Zero(t, running)
+}
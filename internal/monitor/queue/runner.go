@@ -2,7 +2,9 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tharun/pauli/internal/monitor/steps"
 )
@@ -20,3 +22,45 @@ func (stepJobRunner) Run(ctx context.Context, job steps.Job) error {
 	}
 	return job.Step.RunAsync(ctx, &job.Env)
 }
+
+// WithSuccessHook returns a Runner that calls onSuccess after each job that inner completes without error.
+func WithSuccessHook(inner Runner, onSuccess func()) Runner {
+	return successHookRunner{inner: inner, onSuccess: onSuccess}
+}
+
+type successHookRunner struct {
+	inner     Runner
+	onSuccess func()
+}
+
+func (r successHookRunner) Run(ctx context.Context, job steps.Job) error {
+	if err := r.inner.Run(ctx, job); err != nil {
+		return err
+	}
+	r.onSuccess()
+	return nil
+}
+
+// WithDeadline returns a Runner that runs each job under a context cancelled at deadline(job). When a
+// job fails after its own deadline passed, onExceeded is called (e.g. to count it) before the error is
+// returned, so hung beacon calls are cut off instead of piling up behind later slots.
+func WithDeadline(inner Runner, deadline func(steps.Job) time.Time, onExceeded func(steps.Job)) Runner {
+	return deadlineRunner{inner: inner, deadline: deadline, onExceeded: onExceeded}
+}
+
+type deadlineRunner struct {
+	inner      Runner
+	deadline   func(steps.Job) time.Time
+	onExceeded func(steps.Job)
+}
+
+func (r deadlineRunner) Run(ctx context.Context, job steps.Job) error {
+	jobCtx, cancel := context.WithDeadline(ctx, r.deadline(job))
+	defer cancel()
+	err := r.inner.Run(jobCtx, job)
+	if err != nil && ctx.Err() == nil && errors.Is(jobCtx.Err(), context.DeadlineExceeded) {
+		r.onExceeded(job)
+		return fmt.Errorf("job deadline exceeded: %w", err)
+	}
+	return err
+}
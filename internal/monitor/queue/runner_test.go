@@ -0,0 +1,50 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+type runnerFunc func(ctx context.Context, job steps.Job) error
+
+func (f runnerFunc) Run(ctx context.Context, job steps.Job) error { return f(ctx, job) }
+
+func TestWithDeadline_CancelsAndReportsExceededJobs(t *testing.T) {
+	hung := runnerFunc(func(ctx context.Context, _ steps.Job) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	exceeded := 0
+	r := WithDeadline(hung, func(steps.Job) time.Time { return time.Now().Add(10 * time.Millisecond) },
+		func(steps.Job) { exceeded++ })
+
+	err := r.Run(context.Background(), steps.Job{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, exceeded)
+}
+
+func TestWithDeadline_IgnoresOtherOutcomes(t *testing.T) {
+	exceeded := 0
+	onExceeded := func(steps.Job) { exceeded++ }
+	later := func(steps.Job) time.Time { return time.Now().Add(time.Minute) }
+
+	ok := runnerFunc(func(context.Context, steps.Job) error { return nil })
+	require.NoError(t, WithDeadline(ok, later, onExceeded).Run(context.Background(), steps.Job{}))
+
+	boom := errors.New("boom")
+	failing := runnerFunc(func(context.Context, steps.Job) error { return boom })
+	require.ErrorIs(t, WithDeadline(failing, later, onExceeded).Run(context.Background(), steps.Job{}), boom)
+
+	// Shutdown cancels the parent; that is not a deadline miss.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hung := runnerFunc(func(ctx context.Context, _ steps.Job) error { <-ctx.Done(); return ctx.Err() })
+	require.Error(t, WithDeadline(hung, later, onExceeded).Run(ctx, steps.Job{}))
+
+	require.Zero(t, exceeded)
+}
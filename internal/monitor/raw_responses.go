@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+const (
+	// rawResponseQueueSize bounds responses waiting to be compressed and saved; more are dropped.
+	rawResponseQueueSize = 64
+	// rawResponsePruneInterval is how often responses older than raw_responses.ttl_days are deleted.
+	rawResponsePruneInterval = time.Hour
+)
+
+// RawResponseArchive stores raw reward and validator status responses (raw_responses) for audit and
+// deletes them after their TTL. Record never blocks a beacon request: responses are compressed and
+// saved by Run, and dropped when the queue is full.
+type RawResponseArchive struct {
+	repo          storage.Repository
+	slotsPerEpoch uint64
+	maxBodyBytes  int
+	ttl           time.Duration
+	log           zerolog.Logger
+	now           func() time.Time
+
+	queue chan beacon.RawResponse
+}
+
+// NewRawResponseArchive creates an archive for cfg; Run must be started for anything to be saved.
+func NewRawResponseArchive(cfg config.RawResponsesConf, repo storage.Repository, network *config.BlockchainNetwork, log zerolog.Logger) *RawResponseArchive {
+	return &RawResponseArchive{
+		repo:          repo,
+		slotsPerEpoch: network.SlotsPerEpoch(),
+		maxBodyBytes:  cfg.MaxBodyBytes,
+		ttl:           cfg.TTL(),
+		log:           log,
+		now:           time.Now,
+		queue:         make(chan beacon.RawResponse, rawResponseQueueSize),
+	}
+}
+
+// Record queues a response for saving (see beacon.Client.RecordRawResponses).
+func (a *RawResponseArchive) Record(raw beacon.RawResponse) {
+	if len(raw.Body) > a.maxBodyBytes {
+		metrics.RawResponsesDropped.WithLabelValues("too_large").Inc()
+		a.log.Debug().Str("path", raw.Path).Int("body_size", len(raw.Body)).Msg("raw response over raw_responses.max_body_bytes; not stored")
+		return
+	}
+	select {
+	case a.queue <- raw:
+	default:
+		metrics.RawResponsesDropped.WithLabelValues("queue_full").Inc()
+	}
+}
+
+// Run saves queued responses and prunes expired ones until ctx is cancelled.
+func (a *RawResponseArchive) Run(ctx context.Context) {
+	a.prune(ctx)
+	ticker := time.NewTicker(rawResponsePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.prune(ctx)
+		case raw := <-a.queue:
+			a.save(ctx, a.drain(raw))
+		}
+	}
+}
+
+// drain returns first plus whatever else is queued, so a burst is saved in one batch.
+func (a *RawResponseArchive) drain(first beacon.RawResponse) []beacon.RawResponse {
+	batch := []beacon.RawResponse{first}
+	for len(batch) < rawResponseQueueSize {
+		select {
+		case raw := <-a.queue:
+			batch = append(batch, raw)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (a *RawResponseArchive) save(ctx context.Context, batch []beacon.RawResponse) {
+	rows := make([]*storage.RawResponse, 0, len(batch))
+	for _, raw := range batch {
+		row, err := a.row(raw)
+		if err != nil {
+			metrics.RawResponsesDropped.WithLabelValues("save_failed").Inc()
+			a.log.Warn().Err(err).Str("path", raw.Path).Msg("raw response compression failed")
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if err := a.repo.SaveRawResponses(ctx, rows); err != nil {
+		metrics.RawResponsesDropped.WithLabelValues("save_failed").Add(float64(len(rows)))
+		if ctx.Err() == nil {
+			a.log.Warn().Err(err).Int("responses", len(rows)).Msg("failed to save raw responses")
+		}
+	}
+}
+
+// row compresses raw and keys it by epoch: the attestation rewards epoch, or the epoch of a slot id.
+func (a *RawResponseArchive) row(raw beacon.RawResponse) (*storage.RawResponse, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw.Body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	epoch := raw.Epoch
+	if epoch == nil && raw.Slot != nil && a.slotsPerEpoch > 0 {
+		e := *raw.Slot / a.slotsPerEpoch
+		epoch = &e
+	}
+	return &storage.RawResponse{
+		Endpoint:  raw.Endpoint,
+		Path:      raw.Path,
+		Epoch:     epoch,
+		FetchedAt: raw.FetchedAt,
+		Request:   raw.Request,
+		Body:      buf.Bytes(),
+		BodySize:  len(raw.Body),
+	}, nil
+}
+
+func (a *RawResponseArchive) prune(ctx context.Context) {
+	deleted, err := a.repo.DeleteRawResponsesBefore(ctx, a.now().Add(-a.ttl))
+	if err != nil {
+		if ctx.Err() == nil {
+			a.log.Warn().Err(err).Msg("failed to prune raw responses")
+		}
+		return
+	}
+	if deleted > 0 {
+		a.log.Info().Int64("deleted", deleted).Dur("ttl", a.ttl).Msg("pruned expired raw responses")
+	}
+}
@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type rawResponseRepo struct {
+	*noop.Repository
+	saved  []*storage.RawResponse
+	before time.Time
+}
+
+func (r *rawResponseRepo) SaveRawResponses(_ context.Context, rows []*storage.RawResponse) error {
+	r.saved = append(r.saved, rows...)
+	return nil
+}
+
+func (r *rawResponseRepo) DeleteRawResponsesBefore(_ context.Context, before time.Time) (int64, error) {
+	r.before = before
+	return 0, nil
+}
+
+func TestRawResponseArchive(t *testing.T) {
+	repo := &rawResponseRepo{Repository: noop.NewRepository()}
+	network := config.NewBlockchainNetwork(&config.Config{})
+	a := NewRawResponseArchive(config.RawResponsesConf{MaxBodyBytes: 16, TTLDays: 2}, repo, network, zerolog.Nop())
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	slot := uint64(70)
+	a.Record(beacon.RawResponse{Endpoint: "/eth/v1/beacon/states/{state_id}/validators", Slot: &slot, Body: []byte(`{"data":[]}`)})
+	a.Record(beacon.RawResponse{Endpoint: "/eth/v1/beacon/rewards/blocks/{block_id}", Body: bytes.Repeat([]byte("x"), 17)})
+	require.Len(t, a.queue, 1, "bodies over max_body_bytes are not queued")
+
+	a.save(context.Background(), a.drain(<-a.queue))
+	require.Len(t, repo.saved, 1)
+	row := repo.saved[0]
+	require.Equal(t, uint64(2), *row.Epoch, "slot 70 is in epoch 2")
+	require.Equal(t, 11, row.BodySize)
+	zr, err := gzip.NewReader(bytes.NewReader(row.Body))
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, `{"data":[]}`, string(body))
+
+	a.prune(context.Background())
+	require.Equal(t, now.Add(-48*time.Hour), repo.before)
+}
@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/tharun/pauli/internal/config"
+)
+
+// ReloadConfig is the SIGHUP reload. It loads path into a new Config, applying override (the command-line
+// values) before validation, and runs prepare on it (validator_select resolution); only when every step succeeded are the reloadable
+// settings (validators, worker_pool_size) applied. A broken file or a failed prepare returns an error and
+// leaves the running configuration untouched. Tenants are only read at startup (their storage routing is
+// fixed then), so a reload that changes them is refused as a whole: a validator moved into a tenant would
+// otherwise be watched with its rows stored in the default schema.
+func (m *Monitor) ReloadConfig(ctx context.Context, path string, override func(*config.Config), prepare func(*config.Config) error) error {
+	var overrides []func(*config.Config)
+	if override != nil {
+		overrides = append(overrides, override)
+	}
+	next, err := config.Load(path, overrides...)
+	if err != nil {
+		return err
+	}
+	if prepare != nil {
+		if err := prepare(next); err != nil {
+			return err
+		}
+	}
+	if tenantsChanged(m.cfg.Tenants, next.Tenants) {
+		return fmt.Errorf("tenants changed: restart pauli to apply tenant changes")
+	}
+	m.warnRestartRequired(next)
+
+	if err := m.ReloadValidators(ctx, next.Validators); err != nil {
+		return fmt.Errorf("validator set reload: %w", err)
+	}
+	if err := m.ResizeWorkers(next.WorkerPoolSize); err != nil {
+		return fmt.Errorf("worker pool resize: %w", err)
+	}
+	return nil
+}
+
+// warnRestartRequired logs settings that differ in next but are only read at startup.
+func (m *Monitor) warnRestartRequired(next *config.Config) {
+	var changed []string
+	if next.BeaconNodeURL != m.cfg.BeaconNodeURL {
+		changed = append(changed, "beacon_node_url")
+	}
+	if next.DatabaseDriver != m.cfg.DatabaseDriver || next.Postgres != m.cfg.Postgres {
+		changed = append(changed, "database")
+	}
+	if next.PollingIntervalSlots != m.cfg.PollingIntervalSlots {
+		changed = append(changed, "polling_interval_slots")
+	}
+	if next.WorkerQueueSize != m.cfg.WorkerQueueSize {
+		changed = append(changed, "worker_queue_size")
+	}
+	if len(changed) > 0 {
+		m.logger.Warn().Strs("settings", changed).Msg("config reload: changed settings take effect after a restart")
+	}
+}
+
+// tenantsChanged reports whether the tenants or the validators they own differ.
+func tenantsChanged(prev, next []config.TenantConf) bool {
+	return !slices.EqualFunc(prev, next, func(a, b config.TenantConf) bool {
+		return a.Name == b.Name && a.Schema == b.Schema && slices.Equal(a.Validators, b.Validators)
+	})
+}
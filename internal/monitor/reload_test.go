@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+func TestMonitor_ReloadConfigKeepsRunningConfigOnError(t *testing.T) {
+	cfg := &config.Config{
+		BeaconNodeURL:   "http://localhost:5052",
+		DatabaseDriver:  "none",
+		Validators:      []uint64{1, 2},
+		WorkerPoolSize:  2,
+		WorkerQueueSize: 4,
+		Watchdog:        config.WatchdogConf{Disabled: true},
+	}
+	m := NewMonitor(cfg, nil, noop.NewRepository(), zerolog.Nop())
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(yaml string) {
+		require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	}
+
+	write("beacon_node_url: [not a string\nvalidators: [3]\n")
+	require.Error(t, m.ReloadConfig(ctx, path, nil, nil))
+	write("validators: [3]\nworker_pool_size: 5\n") // parses, but beacon_node_url is required
+	require.Error(t, m.ReloadConfig(ctx, path, nil, nil))
+	write("beacon_node_url: http://localhost:5052\ndatabase_driver: none\nvalidators: [3]\nworker_pool_size: 5\n")
+	require.Error(t, m.ReloadConfig(ctx, path, nil, func(*config.Config) error { return errors.New("validator_select failed") }))
+	require.Equal(t, []uint64{1, 2}, m.cfg.Validators)
+	require.Equal(t, 2, m.pool.Size())
+
+	require.NoError(t, m.ReloadConfig(ctx, path, nil, nil))
+	require.Equal(t, []uint64{3}, m.cfg.Validators)
+	require.Equal(t, 5, m.pool.Size())
+}
+
+func TestMonitor_ReloadConfigRefusesTenantChanges(t *testing.T) {
+	cfg := &config.Config{
+		BeaconNodeURL:   "http://localhost:5052",
+		DatabaseDriver:  "postgres",
+		Validators:      []uint64{1, 2},
+		Tenants:         []config.TenantConf{{Name: "acme", Schema: "tenant_acme", Validators: []uint64{2}}},
+		WorkerPoolSize:  2,
+		WorkerQueueSize: 4,
+		Watchdog:        config.WatchdogConf{Disabled: true},
+	}
+	m := NewMonitor(cfg, nil, noop.NewRepository(), zerolog.Nop())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "beacon_node_url: http://localhost:5052\nvalidators: [1]\n" +
+		"postgres: {host: localhost, port: 5432, user: pauli, database: pauli}\n" +
+		"tenants:\n  - name: acme\n    validators: [2, 3]\n"
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+
+	err := m.ReloadConfig(context.Background(), path, nil, nil)
+	require.ErrorContains(t, err, "tenants changed")
+	require.Equal(t, []uint64{1, 2}, m.cfg.Validators)
+
+	yaml = strings.Replace(yaml, "[2, 3]", "[2]", 1) + "worker_pool_size: 3\n"
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	require.NoError(t, m.ReloadConfig(context.Background(), path, nil, nil))
+	require.Equal(t, 3, m.pool.Size())
+}
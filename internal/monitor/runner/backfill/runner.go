@@ -16,6 +16,7 @@ import (
 
 // Runner implements runner.Runner for dual-track slot and epoch backfill.
 type Runner struct {
+	network *config.BlockchainNetwork
 	cfg     config.BackfillConf
 	opts    Options
 	client  *beacon.Client
@@ -26,6 +27,8 @@ type Runner struct {
 	enqueue func(context.Context, steps.Job) error
 	idle    bool
 	env     *steps.Env
+	// prunedEpochFloor is the lowest epoch EpochPass still tries after the node reported a pruned state.
+	prunedEpochFloor uint64
 	// oneShotBounds freezes head-lag/finalized targets at Start so one-shot does not chase a growing chain.
 	oneShotBounds *oneShotBounds
 }
@@ -39,6 +42,7 @@ var _ runner.Runner = (*Runner)(nil)
 
 // New constructs a backfill runner.
 func New(
+	network *config.BlockchainNetwork,
 	cfg config.BackfillConf,
 	opts Options,
 	client *beacon.Client,
@@ -49,6 +53,7 @@ func New(
 	enqueue func(context.Context, steps.Job) error,
 ) *Runner {
 	return &Runner{
+		network: network,
 		cfg:     cfg,
 		opts:    opts,
 		client:  client,
@@ -295,8 +300,10 @@ func (r *Runner) stepChain() []steps.Step {
 			Cfg:                r.cfg,
 			StartEpochOverride: r.opts.StartEpoch,
 			EndEpochOverride:   r.opts.EndEpoch,
+			PrunedFloor:        &r.prunedEpochFloor,
 			Client:             r.client,
 			Repo:               r.repo,
+			Network:            r.network,
 			Log:                r.log,
 		},
 	}
@@ -2,9 +2,13 @@ package runner
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/monitor/queue"
 	"github.com/tharun/pauli/internal/monitor/steps"
 )
 
@@ -77,8 +81,12 @@ func (engine *engine) run(ctx context.Context) {
 func (engine *engine) runStepChain(ctx context.Context, log zerolog.Logger, env *steps.Env, chain []steps.Step, errDelay time.Duration) (exitRun bool) {
 	for _, step := range chain {
 		enqueue, err := step.Run(env)
+		if errors.Is(err, steps.ErrSkipChain) {
+			return false
+		}
 		if err != nil {
-			log.Error().Err(err).Msg("step failed")
+			beacon.LogErrorContext(log.Error().Err(err).Str("step", fmt.Sprintf("%T", step)).Uint64("head_slot", env.HeadSlot), err).
+				Msg("step failed")
 			if errDelay > 0 && pauseOrExit(ctx, errDelay) {
 				return true
 			}
@@ -92,6 +100,14 @@ func (engine *engine) runStepChain(ctx context.Context, log zerolog.Logger, env
 			if ctx.Err() != nil {
 				return true
 			}
+			if errors.Is(err, queue.ErrQueueFull) {
+				// The scheduler does not wait for workers; the pass ends and the head is retried next poll.
+				log.Warn().
+					Str("step", fmt.Sprintf("%T", step)).
+					Uint64("head_slot", env.HeadSlot).
+					Msg("worker queue full; job dropped, pass retried next poll")
+				return false
+			}
 			log.Error().Err(err).Msg("enqueue failed")
 			if errDelay > 0 && pauseOrExit(ctx, errDelay) {
 				return true
@@ -0,0 +1,148 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	steprt "github.com/tharun/pauli/internal/monitor/steps/realtime"
+)
+
+// maxEventGapSlots caps how many slots missed while the event stream was down are re-indexed on
+// reconnect; older gaps are left to backfill and the next head polls.
+const maxEventGapSlots = 64
+
+// runEventStream consumes the beacon event stream until ctx is done: block events are fanned into the
+// worker pool as GossipBlock jobs, attestation events are matched against scheduled duties in place.
+func (r *Runner) runEventStream(ctx context.Context) {
+	var topics []string
+	if !r.opts.Jobs.DisableBlocks {
+		topics = append(topics, beacon.TopicBlock)
+	}
+	if r.opts.Events.Attestations && r.opts.AttestationDuties.Enabled {
+		topics = append(topics, beacon.TopicAttestation, beacon.TopicSingleAttestation)
+	}
+	if r.opts.RewardsReorgLookbackEpochs > 0 {
+		topics = append(topics, beacon.TopicChainReorg)
+	}
+	if len(topics) == 0 {
+		r.log.Info().Msg("realtime: no event topics needed with block jobs disabled; event stream not started")
+		return
+	}
+	stream := &beacon.EventStream{
+		Client:      r.client,
+		Topics:      topics,
+		Log:         r.log,
+		OnReconnect: r.fillEventGap,
+	}
+	r.log.Info().Strs("topics", topics).Msg("realtime: subscribing to beacon events")
+	stream.Run(ctx, func(ev beacon.Event) { r.handleEvent(ctx, ev) })
+}
+
+func (r *Runner) handleEvent(ctx context.Context, ev beacon.Event) {
+	metrics.EventsReceived.WithLabelValues(ev.Topic).Inc()
+	switch ev.Topic {
+	case beacon.TopicBlock:
+		var b beacon.BlockEvent
+		if err := json.Unmarshal(ev.Data, &b); err != nil {
+			r.log.Warn().Err(err).Msg("realtime: bad block event")
+			return
+		}
+		slot := b.Slot.Uint64()
+		if slot > r.lastEventSlot.Load() {
+			r.lastEventSlot.Store(slot)
+		}
+		r.enqueueGossipBlock(ctx, slot)
+	case beacon.TopicChainReorg:
+		var reorg beacon.ChainReorgEvent
+		if err := json.Unmarshal(ev.Data, &reorg); err != nil {
+			r.log.Warn().Err(err).Msg("realtime: bad chain_reorg event")
+			return
+		}
+		r.log.Warn().
+			Uint64("slot", reorg.Slot.Uint64()).
+			Uint64("depth", reorg.Depth.Uint64()).
+			Str("old_head_block", reorg.OldHeadBlock).
+			Str("new_head_block", reorg.NewHeadBlock).
+			Msg("realtime: chain reorg")
+		r.recomputeRewardsAfterReorg(ctx)
+	case beacon.TopicAttestation, beacon.TopicSingleAttestation:
+		if _, err := r.gossip.Observe(ev); err != nil {
+			r.log.Debug().Err(err).Str("topic", ev.Topic).Msg("realtime: bad attestation event")
+		}
+	}
+}
+
+// fillEventGap enqueues the slots between the last block event and the current head after a reconnect.
+func (r *Runner) fillEventGap(ctx context.Context) {
+	last := r.lastEventSlot.Load()
+	if last == 0 {
+		return
+	}
+	head, err := r.getHead(ctx)
+	if err != nil {
+		r.log.Warn().Err(err).Msg("realtime: event gap fill skipped; head lookup failed")
+		return
+	}
+	if head <= last {
+		return
+	}
+	from := last + 1
+	if head-last > maxEventGapSlots {
+		from = head - maxEventGapSlots + 1
+	}
+	r.log.Warn().
+		Uint64("last_event_slot", last).
+		Uint64("head_slot", head).
+		Uint64("refill_from_slot", from).
+		Msg("realtime: block events missed while disconnected; re-indexing recent slots")
+	for slot := from; slot <= head; slot++ {
+		r.enqueueGossipBlock(ctx, slot)
+	}
+}
+
+func (r *Runner) enqueueGossipBlock(ctx context.Context, slot uint64) {
+	r.validatorsMu.Lock()
+	validators := append([]uint64(nil), r.validators...)
+	r.validatorsMu.Unlock()
+	job := steps.Job{
+		Step: &steprt.GossipBlock{
+			Client:    r.client,
+			Execution: r.exec,
+			Repo:      r.repo,
+			Log:       r.log,
+			Results:   r.opts.ResultLevels,
+		},
+		Env: steps.Env{Ctx: ctx, HeadSlot: slot, ValidatorIndices: validators},
+	}
+	if err := r.enqueue(ctx, job); err != nil && ctx.Err() == nil {
+		r.logEnqueueFailure(err, "block event job", slot)
+	}
+}
+
+// recomputeRewardsAfterReorg queues a RewardsRecompute of the last rewards_reorg_lookback_epochs finalized
+// epochs, unless one is already queued or running (a reorg often shows up as several events).
+func (r *Runner) recomputeRewardsAfterReorg(ctx context.Context) {
+	if r.opts.RewardsReorgLookbackEpochs == 0 || r.opts.OneShot || !r.rewardsRecomputing.CompareAndSwap(false, true) {
+		return
+	}
+	job := steps.Job{
+		Step: &steprt.RewardsRecompute{
+			Client:         r.client,
+			Repo:           r.repo,
+			Network:        r.network,
+			Log:            r.log,
+			LookbackEpochs: r.opts.RewardsReorgLookbackEpochs,
+			Running:        &r.rewardsRecomputing,
+		},
+		Env: steps.Env{Ctx: ctx},
+	}
+	if err := r.enqueue(ctx, job); err != nil {
+		r.rewardsRecomputing.Store(false)
+		if ctx.Err() == nil {
+			r.logEnqueueFailure(err, "rewards recompute", 0)
+		}
+	}
+}
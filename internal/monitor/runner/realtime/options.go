@@ -0,0 +1,43 @@
+package realtime
+
+import (
+	"context"
+
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+// Options adjusts realtime runner behavior for CLI modes.
+type Options struct {
+	// OneShot runs a single chain pass without pacing, indexes the latest finalized epoch
+	// even off an epoch boundary, then stops the runner.
+	OneShot bool
+	// AttestationDuties enables attester duty indexing and inclusion checks for the watched validators.
+	AttestationDuties config.AttestationDutiesConf
+	// ProposerDuties tracks the watched validators' block proposals: reward when proposed, or missed.
+	ProposerDuties config.ProposerDutiesConf
+	// ValidatorSampling polls one group of the validators per epoch for duties and liveness.
+	ValidatorSampling config.ValidatorSamplingConf
+	// PriorityValidators are polled every epoch and first in per-validator requests.
+	PriorityValidators []uint64
+	// RewardsReconcile re-indexes finalized epochs missing from storage at epoch boundaries.
+	RewardsReconcile config.RewardsReconcileConf
+	// ValidatorLiveness enables the per-epoch liveness check for the watched validators.
+	ValidatorLiveness config.ValidatorLivenessConf
+	// Events consumes the beacon event stream alongside polling.
+	Events config.EventsConf
+	// MaxSyncDistance gates each chain pass on the node's sync distance (0 = no gate).
+	MaxSyncDistance uint64
+	// PauseWhileSyncing gates each chain pass on the node's is_syncing flag, even with MaxSyncDistance 0.
+	PauseWhileSyncing bool
+	// InactiveValidators drops terminal-status validators from the per-validator steps.
+	InactiveValidators config.InactiveValidatorsConf
+	// RewardsReorgLookbackEpochs re-indexes the last this many finalized epochs after a reorg (0 = never).
+	RewardsReorgLookbackEpochs uint64
+	// Jobs turns the rewards and block jobs off.
+	Jobs config.JobsConf
+	// ResultLevels overrides the log level of per-validator results (result_log_levels).
+	ResultLevels steps.ResultLevels
+	// OnFinality is called with the head and finalized epochs each time the finalized epoch is fetched.
+	OnFinality func(ctx context.Context, headEpoch, finalizedEpoch uint64)
+}
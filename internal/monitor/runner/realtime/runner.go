@@ -2,14 +2,20 @@ package realtime
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/tharun/pauli/internal/beacon"
 	"github.com/tharun/pauli/internal/config"
 	"github.com/tharun/pauli/internal/execution"
+	"github.com/tharun/pauli/internal/monitor/queue"
 	"github.com/tharun/pauli/internal/monitor/runner"
 	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
 	steprt "github.com/tharun/pauli/internal/monitor/steps/realtime"
 	"github.com/tharun/pauli/internal/storage"
 )
@@ -17,6 +23,7 @@ import (
 // Runner implements runner.Runner: network pacing and a fixed linear chain of indexing steps.
 type Runner struct {
 	network    *config.BlockchainNetwork
+	opts       Options
 	client     *beacon.Client
 	exec       *execution.Client
 	repo       storage.Repository
@@ -24,10 +31,35 @@ type Runner struct {
 	validators []uint64
 	log        zerolog.Logger
 	enqueue    func(context.Context, steps.Job) error
+	// validatorsMu guards validators, which SetValidators may replace while the runner is running.
+	validatorsMu sync.Mutex
 	// Updated only by RecordLastProcessedSlot after a full successful chain pass; other
 	// steps skip when Env.HeadSlot equals this (dedup across polls for the same head).
 	lastProcessedSlot uint64
 	env               *steps.Env
+	dutySchedule      *steprt.DutySchedule
+	proposerSchedule  *steprt.ProposerSchedule
+	inclusionTimers   *steprt.InclusionTimers
+	gossip            *steprt.GossipAttestations
+	activeFilter      *steprt.ActiveValidatorFilter
+	dutyAnomalies     *indexing.DutyAnomalyDetector
+	livenessEpoch     uint64
+	// finalizedEpoch is the finalized epoch AttestationRewards saw at the previous boundary.
+	finalizedEpoch uint64
+	// reconcileEpoch is the head epoch of the last rewards_reconcile check.
+	reconcileEpoch uint64
+	nodeLagging    bool
+	elOffline      bool
+	// emitted maps a chain step type to the head slot its job was last queued for, so a pass retried for
+	// the same head (after a later step failed) does not queue the earlier steps' jobs twice.
+	emitted map[string]uint64
+	// lastEventSlot is the slot of the newest "block" event, used to fill gaps after a reconnect.
+	lastEventSlot atomic.Uint64
+	// rewardsRecomputing is set while a post-reorg RewardsRecompute job is queued or running.
+	rewardsRecomputing atomic.Bool
+	// producers tracks goroutines that enqueue jobs outside the step chain; Start waits for them so the
+	// worker pool is never stopped while one of them can still enqueue.
+	producers sync.WaitGroup
 }
 
 var _ runner.Runner = (*Runner)(nil)
@@ -35,6 +67,7 @@ var _ runner.Runner = (*Runner)(nil)
 // New constructs a realtime runner.
 func New(
 	network *config.BlockchainNetwork,
+	opts Options,
 	client *beacon.Client,
 	exec *execution.Client,
 	repo storage.Repository,
@@ -43,8 +76,14 @@ func New(
 	log zerolog.Logger,
 	enqueue func(context.Context, steps.Job) error,
 ) *Runner {
+	schedule := steprt.NewDutySchedule()
+	var anomalies *indexing.DutyAnomalyDetector
+	if opts.AttestationDuties.Anomalies.Enabled {
+		anomalies = indexing.NewDutyAnomalyDetector(opts.AttestationDuties.Anomalies)
+	}
 	return &Runner{
 		network:    network,
+		opts:       opts,
 		client:     client,
 		exec:       exec,
 		repo:       repo,
@@ -55,6 +94,21 @@ func New(
 		// Sentinel: no successful chain yet, so first HeadSlot always runs all steps.
 		lastProcessedSlot: ^uint64(0),
 		env:               steps.NewEnv(),
+		dutySchedule:      schedule,
+		proposerSchedule:  steprt.NewProposerSchedule(),
+		inclusionTimers:   steprt.NewInclusionTimers(),
+		gossip:            &steprt.GossipAttestations{Schedule: schedule, Log: log, Results: opts.ResultLevels},
+		livenessEpoch:     ^uint64(0),
+		finalizedEpoch:    ^uint64(0),
+		reconcileEpoch:    ^uint64(0),
+		emitted:           make(map[string]uint64),
+		dutyAnomalies:     anomalies,
+		activeFilter: &steprt.ActiveValidatorFilter{
+			Client:        client,
+			Log:           log,
+			RecheckEpochs: opts.InactiveValidators.RecheckEpochs,
+			Results:       opts.ResultLevels,
+		},
 	}
 }
 
@@ -64,11 +118,24 @@ func (r *Runner) Logger() zerolog.Logger { return r.log }
 
 func (r *Runner) Env() *steps.Env { return r.env }
 
+// Enqueue queues a chain step's job once per head slot; repeats for an already queued slot are skipped.
 func (r *Runner) Enqueue(ctx context.Context, job steps.Job) error {
-	return r.enqueue(ctx, job)
+	key := fmt.Sprintf("%T", job.Step)
+	if slot, ok := r.emitted[key]; ok && slot == job.Env.HeadSlot {
+		r.log.Debug().Str("step", key).Uint64("head_slot", slot).Msg("realtime: job already queued for head; skipping")
+		return nil
+	}
+	if err := r.enqueue(ctx, job); err != nil {
+		return err
+	}
+	r.emitted[key] = job.Env.HeadSlot
+	return nil
 }
 
 func (r *Runner) BeforeStep(ctx context.Context) error {
+	if r.opts.OneShot {
+		return nil
+	}
 	r.log.Debug().
 		Dur("poll_interval", r.network.PollInterval()).
 		Msg("realtime runner pacing wait")
@@ -78,7 +145,7 @@ func (r *Runner) BeforeStep(ctx context.Context) error {
 func (r *Runner) AfterStep(context.Context) error { return nil }
 
 func (r *Runner) StepChain(ctx context.Context) ([]steps.Step, bool, error) {
-	return r.stepChain(), false, nil
+	return r.stepChain(), r.opts.OneShot, nil
 }
 
 func (r *Runner) SleepOnSeedError() time.Duration { return 0 }
@@ -88,32 +155,199 @@ func (r *Runner) SetLastProcessedSlot(slot uint64) {
 	r.lastProcessedSlot = slot
 }
 
+// SetLivenessEpoch seeds the last epoch checked by ValidatorLiveness (from scheduler_state on startup).
+func (r *Runner) SetLivenessEpoch(epoch uint64) {
+	r.livenessEpoch = epoch
+}
+
+// SetValidators replaces the watched validator list from the next chain pass on. Scheduled duties for
+// removed indices are dropped and duties are refetched so added indices are covered in the current epoch.
+func (r *Runner) SetValidators(validators, removed []uint64) {
+	r.validatorsMu.Lock()
+	r.validators = append([]uint64(nil), validators...)
+	r.validatorsMu.Unlock()
+	r.dutySchedule.Remove(removed)
+	r.dutySchedule.Reset()
+	r.proposerSchedule.Remove(removed)
+	r.proposerSchedule.Reset()
+}
+
+// Start runs the step chain until ctx is done and returns only after its background producers have exited.
 func (r *Runner) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if r.timedInclusionChecks() {
+		r.startProducer(ctx, func(ctx context.Context) {
+			r.inclusionTimers.Run(ctx, func(slot uint64) { r.enqueueInclusionCheck(ctx, slot) })
+		})
+	}
+	if r.opts.Events.Enabled && !r.opts.OneShot {
+		r.startProducer(ctx, r.runEventStream)
+	}
 	runner.Run(ctx, r)
+	cancel()
+	r.producers.Wait()
+}
+
+func (r *Runner) startProducer(ctx context.Context, run func(context.Context)) {
+	r.producers.Add(1)
+	go func() {
+		defer r.producers.Done()
+		run(ctx)
+	}()
+}
+
+func (r *Runner) timedInclusionChecks() bool {
+	return r.opts.AttestationDuties.Enabled && r.opts.AttestationDuties.TimedChecks && !r.opts.OneShot
+}
+
+// enqueueInclusionCheck queues the inclusion check for the duties of dutySlot as if head were at the end
+// of its inclusion window. Duties already drained by a poll are simply not found again.
+func (r *Runner) enqueueInclusionCheck(ctx context.Context, dutySlot uint64) {
+	env := steps.Env{Ctx: ctx, HeadSlot: dutySlot + r.opts.AttestationDuties.InclusionDelaySlots}
+	if err := r.enqueue(ctx, steps.Job{Step: r.attestationInclusion(), Env: env}); err != nil && ctx.Err() == nil {
+		// A dropped check is not lost: the duties stay scheduled and the next head poll drains them.
+		r.logEnqueueFailure(err, "timed inclusion check", dutySlot)
+	}
+}
+
+// logEnqueueFailure logs a job the runner enqueued outside the step chain; drops on a full queue are
+// warnings since the next poll covers the same work.
+func (r *Runner) logEnqueueFailure(err error, what string, slot uint64) {
+	if errors.Is(err, queue.ErrQueueFull) {
+		r.log.Warn().Str("job", what).Uint64("slot", slot).Msg("realtime: worker queue full; job dropped")
+		return
+	}
+	r.log.Error().Err(err).Str("job", what).Uint64("slot", slot).Msg("realtime: enqueue failed")
+}
+
+func (r *Runner) attestationInclusion() *steprt.AttestationInclusion {
+	return &steprt.AttestationInclusion{
+		Client:                 r.client,
+		Repo:                   r.repo,
+		Log:                    r.log,
+		LastProcessedSlot:      &r.lastProcessedSlot,
+		Schedule:               r.dutySchedule,
+		InclusionDelaySlots:    r.opts.AttestationDuties.InclusionDelaySlots,
+		MaxInclusionDelaySlots: r.opts.AttestationDuties.MaxInclusionDelaySlots,
+		Results:                r.opts.ResultLevels,
+	}
 }
 
 func (r *Runner) stepChain() []steps.Step {
-	return []steps.Step{
+	r.validatorsMu.Lock()
+	validators := r.validators
+	r.validatorsMu.Unlock()
+
+	var chain []steps.Step
+	syncGate := r.opts.MaxSyncDistance > 0 || r.opts.PauseWhileSyncing
+	duties := r.opts.AttestationDuties.Enabled || r.opts.ProposerDuties.Enabled
+	if syncGate || duties {
+		gate := &steprt.NodeSyncGate{
+			Client:          r.client,
+			MaxSyncDistance: r.opts.MaxSyncDistance,
+			DutiesOnly:      !syncGate,
+			Log:             r.log,
+			Lagging:         &r.nodeLagging,
+		}
+		if duties {
+			gate.ELOffline = &r.elOffline
+		}
+		chain = append(chain, gate)
+	}
+	chain = append(chain,
 		steprt.RealtimeEnvBootstrap{
-			GetHead:    r.getHead,
-			Validators: r.validators,
-			Log:        r.log,
+			GetHead:      r.getHead,
+			Validators:   validators,
+			SampleGroups: r.opts.ValidatorSampling.Groups(),
+			Priority:     r.opts.PriorityValidators,
+			Log:          r.log,
 		},
 		&steprt.AttestationRewards{
-			Client:            r.client,
-			Repo:              r.repo,
-			Log:               r.log,
-			LastProcessedSlot: &r.lastProcessedSlot,
+			Client:              r.client,
+			Repo:                r.repo,
+			Network:             r.network,
+			Log:                 r.log,
+			LastProcessedSlot:   &r.lastProcessedSlot,
+			LastFinalizedEpoch:  &r.finalizedEpoch,
+			Reconcile:           r.opts.RewardsReconcile,
+			LastReconcileEpoch:  &r.reconcileEpoch,
+			IgnoreEpochBoundary: r.opts.OneShot,
+			OnFinality:          r.opts.OnFinality,
+			FinalityOnly:        r.opts.Jobs.DisableRewards,
 		},
-		&steprt.BlockIndexer{
+	)
+	if !r.opts.Jobs.DisableBlocks {
+		chain = append(chain, &steprt.BlockIndexer{
 			Client:            r.client,
 			Execution:         r.exec,
 			Repo:              r.repo,
 			Log:               r.log,
 			LastProcessedSlot: &r.lastProcessedSlot,
-		},
-		&steprt.RecordLastProcessedSlot{
-			LastProcessedSlot: &r.lastProcessedSlot,
-		},
+		})
+	}
+	if r.opts.ValidatorLiveness.Enabled {
+		chain = append(chain, &steprt.ValidatorLiveness{
+			Client:           r.client,
+			Repo:             r.repo,
+			Log:              r.log,
+			LastCheckedEpoch: &r.livenessEpoch,
+			Results:          r.opts.ResultLevels,
+		})
+	}
+	if r.opts.AttestationDuties.Enabled {
+		if r.opts.InactiveValidators.Skip {
+			chain = append(chain, r.activeFilter)
+		}
+		var timers *steprt.InclusionTimers
+		if r.timedInclusionChecks() {
+			timers = r.inclusionTimers
+		}
+		chain = append(chain,
+			&steprt.AttesterDuties{
+				Client:              r.client,
+				Repo:                r.repo,
+				Network:             r.network,
+				Log:                 r.log,
+				LastProcessedSlot:   &r.lastProcessedSlot,
+				Schedule:            r.dutySchedule,
+				TargetCommitteeSize: r.opts.AttestationDuties.TargetCommitteeSize,
+				MaxCommitteeLength:  r.opts.AttestationDuties.MaxCommitteeLength,
+				Filter:              r.opts.AttestationDuties.Filter,
+				Timers:              timers,
+				InclusionDelaySlots: r.opts.AttestationDuties.InclusionDelaySlots,
+				VerifyCommittees:    r.opts.AttestationDuties.VerifyCommittees,
+				FromCommittees:      r.opts.AttestationDuties.Source == config.DutySourceCommittees,
+				Anomalies:           r.dutyAnomalies,
+				OnReorg:             r.recomputeRewardsAfterReorg,
+				Results:             r.opts.ResultLevels,
+			},
+			r.attestationInclusion(),
+		)
+	}
+	if r.opts.ProposerDuties.Enabled {
+		chain = append(chain,
+			&steprt.ProposerDuties{
+				Client:            r.client,
+				Repo:              r.repo,
+				Log:               r.log,
+				LastProcessedSlot: &r.lastProcessedSlot,
+				Schedule:          r.proposerSchedule,
+			},
+			&steprt.ProposalCheck{
+				Client:            r.client,
+				Repo:              r.repo,
+				Log:               r.log,
+				LastProcessedSlot: &r.lastProcessedSlot,
+				Schedule:          r.proposerSchedule,
+				CheckDelaySlots:   r.opts.ProposerDuties.CheckDelaySlots,
+				Results:           r.opts.ResultLevels,
+			},
+		)
 	}
+	return append(chain, &steprt.RecordLastProcessedSlot{
+		LastProcessedSlot: &r.lastProcessedSlot,
+		Repo:              r.repo,
+		Log:               r.log,
+	})
 }
@@ -0,0 +1,59 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	steprt "github.com/tharun/pauli/internal/monitor/steps/realtime"
+)
+
+func TestRunner_EnqueueOncePerHeadSlot(t *testing.T) {
+	var queued []steps.Job
+	r := New(nil, Options{}, nil, nil, nil, nil, nil, zerolog.Nop(), func(_ context.Context, job steps.Job) error {
+		queued = append(queued, job)
+		return nil
+	})
+	ctx := context.Background()
+	blocks := steps.Job{Step: &steprt.BlockIndexer{}, Env: steps.Env{HeadSlot: 100}}
+	rewards := steps.Job{Step: &steprt.AttestationRewards{}, Env: steps.Env{HeadSlot: 100}}
+
+	require.NoError(t, r.Enqueue(ctx, blocks))
+	require.NoError(t, r.Enqueue(ctx, rewards))
+	// Retried pass for the same head: nothing is queued again.
+	require.NoError(t, r.Enqueue(ctx, blocks))
+	require.NoError(t, r.Enqueue(ctx, rewards))
+	require.Len(t, queued, 2)
+
+	blocks.Env.HeadSlot = 101
+	require.NoError(t, r.Enqueue(ctx, blocks))
+	require.Len(t, queued, 3)
+	require.Equal(t, uint64(101), queued[2].Env.HeadSlot)
+}
+
+func TestRunner_stepChainHonorsJobSwitches(t *testing.T) {
+	chainTypes := func(opts Options) []string {
+		r := New(nil, opts, nil, nil, nil, nil, nil, zerolog.Nop(), nil)
+		var types []string
+		for _, s := range r.stepChain() {
+			types = append(types, fmt.Sprintf("%T", s))
+		}
+		return types
+	}
+
+	all := chainTypes(Options{})
+	require.Contains(t, all, "*realtime.BlockIndexer")
+
+	focused := chainTypes(Options{Jobs: config.JobsConf{DisableBlocks: true, DisableRewards: true}})
+	require.NotContains(t, focused, "*realtime.BlockIndexer")
+	r := New(nil, Options{Jobs: config.JobsConf{DisableRewards: true}}, nil, nil, nil, nil, nil, zerolog.Nop(), nil)
+	for _, s := range r.stepChain() {
+		if rewards, ok := s.(*steprt.AttestationRewards); ok {
+			require.True(t, rewards.FinalityOnly, "rewards step only tracks finality")
+		}
+	}
+}
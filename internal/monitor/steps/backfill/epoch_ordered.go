@@ -0,0 +1,134 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+)
+
+// epochResultWait bounds how long the ordered apply stage waits for the next epoch's fetch.
+const epochResultWait = 5 * time.Minute
+
+type epochResult struct {
+	seq   int
+	fetch *indexing.EpochFetch
+	err   error
+}
+
+// epochReorderBuffer holds fetch results that completed out of order until every earlier
+// sequence number has been applied.
+type epochReorderBuffer struct {
+	next    int
+	pending map[int]epochResult
+}
+
+func newEpochReorderBuffer() *epochReorderBuffer {
+	return &epochReorderBuffer{pending: make(map[int]epochResult)}
+}
+
+func (b *epochReorderBuffer) put(r epochResult) {
+	b.pending[r.seq] = r
+}
+
+// pop returns the result for the next sequence number once it has arrived.
+func (b *epochReorderBuffer) pop() (epochResult, bool) {
+	r, ok := b.pending[b.next]
+	if !ok {
+		return epochResult{}, false
+	}
+	delete(b.pending, b.next)
+	b.next++
+	return r, true
+}
+
+func (b *epochReorderBuffer) len() int { return len(b.pending) }
+
+// pendingEpochs lists up to epochs_per_pass unindexed epochs from first through target.
+func (s *EpochPass) pendingEpochs(ctx context.Context, first, target uint64) ([]uint64, error) {
+	var out []uint64
+	for i := 0; i < s.Cfg.EpochsPerPass; i++ {
+		epoch := first + uint64(i)
+		if epoch > target {
+			break
+		}
+		done, err := s.Repo.IsEpochIndexed(ctx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			out = append(out, epoch)
+		}
+	}
+	return out, nil
+}
+
+// indexConcurrent fetches epochs with up to epoch_concurrency requests in flight and applies
+// them strictly in order. At most 2×epoch_concurrency fetched results are held at once. A fetch
+// error, or a result that does not arrive within epochResultWait, ends the pass; epochs not yet
+// applied are retried by the next pass.
+func (s *EpochPass) indexConcurrent(ctx context.Context, idx *indexing.EpochIndexer, epochs []uint64) (int, error) {
+	if len(epochs) == 0 {
+		return 0, nil
+	}
+	conc := s.Cfg.EpochConcurrency
+	window := 2 * conc
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan epochResult, len(epochs))
+	buf := newEpochReorderBuffer()
+	timer := time.NewTimer(epochResultWait)
+	defer timer.Stop()
+
+	processed, launched, inflight := 0, 0, 0
+	for buf.next < len(epochs) {
+		for launched < len(epochs) && inflight < conc && launched-buf.next < window {
+			seq, epoch := launched, epochs[launched]
+			go func() {
+				f, err := indexing.FetchEpoch(fetchCtx, idx, epoch)
+				results <- epochResult{seq: seq, fetch: f, err: err}
+			}()
+			launched++
+			inflight++
+		}
+
+		timer.Reset(epochResultWait)
+		select {
+		case r := <-results:
+			inflight--
+			buf.put(r)
+		case <-timer.C:
+			s.Log.Warn().
+				Uint64("epoch", epochs[buf.next]).
+				Dur("waited", epochResultWait).
+				Int("buffered", buf.len()).
+				Msg("backfill: epoch result never arrived; deferring remaining epochs to next pass")
+			return processed, nil
+		case <-ctx.Done():
+			return processed, ctx.Err()
+		}
+
+		for {
+			r, ok := buf.pop()
+			if !ok {
+				break
+			}
+			if r.err != nil {
+				if s.skipPruned(epochs[r.seq], r.err) {
+					continue
+				}
+				return processed, r.err
+			}
+			marked, err := indexing.ApplyEpoch(ctx, idx, r.fetch)
+			if err != nil {
+				return processed, err
+			}
+			if marked {
+				processed++
+			}
+		}
+	}
+	return processed, nil
+}
@@ -0,0 +1,51 @@
+package backfill
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+)
+
+func TestEpochReorderBuffer(t *testing.T) {
+	t.Parallel()
+
+	b := newEpochReorderBuffer()
+	b.put(epochResult{seq: 2, fetch: &indexing.EpochFetch{Epoch: 12}})
+	b.put(epochResult{seq: 1, fetch: &indexing.EpochFetch{Epoch: 11}})
+
+	_, ok := b.pop()
+	require.False(t, ok, "seq 0 has not arrived")
+	require.Equal(t, 2, b.len())
+
+	b.put(epochResult{seq: 0, fetch: &indexing.EpochFetch{Epoch: 10}})
+	var got []uint64
+	for {
+		r, ok := b.pop()
+		if !ok {
+			break
+		}
+		got = append(got, r.fetch.Epoch)
+	}
+	require.Equal(t, []uint64{10, 11, 12}, got)
+	require.Equal(t, 0, b.len())
+	require.Equal(t, 3, b.next)
+}
+
+func TestEpochPass_skipPruned(t *testing.T) {
+	t.Parallel()
+
+	var floor uint64
+	s := &EpochPass{Log: zerolog.Nop(), PrunedFloor: &floor}
+	pruned := fmt.Errorf("get all validators at epoch 7: %w", &beacon.StatePrunedError{StateID: "224", Err: errors.New("404")})
+
+	require.False(t, s.skipPruned(7, errors.New("timeout")))
+	require.True(t, s.skipPruned(7, pruned))
+	require.Equal(t, uint64(8), floor)
+	require.True(t, s.skipPruned(5, pruned), "an older pruned epoch does not lower the floor")
+	require.Equal(t, uint64(8), floor)
+}
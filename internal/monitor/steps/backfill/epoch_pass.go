@@ -18,7 +18,11 @@ type EpochPass struct {
 	EndEpochOverride   *uint64
 	Client             *beacon.Client
 	Repo               storage.Repository
+	Network            *config.BlockchainNetwork
 	Log zerolog.Logger
+	// PrunedFloor (runner-owned) is raised past an epoch whose state the node has pruned, so later passes
+	// start above it instead of retrying history the node cannot serve.
+	PrunedFloor *uint64
 }
 
 // Run implements steps.Step.
@@ -48,6 +52,9 @@ func (s *EpochPass) Run(e *steps.Env) (bool, error) {
 		floor = *s.StartEpochOverride
 	}
 	// Do not raise floor from MaxIndexedEpoch; same gap-fill rationale as SlotPass.
+	if s.PrunedFloor != nil && *s.PrunedFloor > floor {
+		floor = *s.PrunedFloor
+	}
 
 	if floor > targetEpoch {
 		s.Log.Info().
@@ -71,9 +78,26 @@ func (s *EpochPass) Run(e *steps.Env) (bool, error) {
 	}
 
 	idx := &indexing.EpochIndexer{
-		Client: s.Client,
-		Repo:   s.Repo,
-		Log:    s.Log,
+		Client:  s.Client,
+		Repo:    s.Repo,
+		Network: s.Network,
+		Log:     s.Log,
+	}
+
+	if s.Cfg.EpochConcurrency > 1 {
+		epochs, err := s.pendingEpochs(ctx, first, targetEpoch)
+		if err != nil {
+			return false, err
+		}
+		processed, err := s.indexConcurrent(ctx, idx, epochs)
+		if processed > 0 {
+			s.Log.Info().
+				Uint64("from_epoch", first).
+				Int("count", processed).
+				Uint64("target_epoch", targetEpoch).
+				Msg("backfill: indexed epochs")
+		}
+		return false, err
 	}
 
 	processed := 0
@@ -90,6 +114,9 @@ func (s *EpochPass) Run(e *steps.Env) (bool, error) {
 			continue
 		}
 		if err := indexing.IndexEpochAtBoundary(ctx, idx, epoch); err != nil {
+			if s.skipPruned(epoch, err) {
+				continue
+			}
 			return false, err
 		}
 		done, err = s.Repo.IsEpochIndexed(ctx, epoch)
@@ -112,6 +139,22 @@ func (s *EpochPass) Run(e *steps.Env) (bool, error) {
 	return false, nil
 }
 
+// skipPruned reports whether err means the node pruned epoch's state. Older states are pruned as well, so
+// the pass floor is raised past epoch and the gap is logged once, with the archive-mode hint in err.
+func (s *EpochPass) skipPruned(epoch uint64, err error) bool {
+	if !beacon.IsStatePruned(err) || s.PrunedFloor == nil {
+		return false
+	}
+	if epoch+1 > *s.PrunedFloor {
+		*s.PrunedFloor = epoch + 1
+		s.Log.Warn().Err(err).
+			Uint64("epoch", epoch).
+			Uint64("resume_epoch", epoch+1).
+			Msg("backfill: node has pruned this epoch's state; skipping it and older epochs")
+	}
+	return true
+}
+
 func (s *EpochPass) Async() bool { return false }
 
 func (s *EpochPass) RunAsync(context.Context, *steps.Env) error { return nil }
@@ -7,11 +7,21 @@ type Env struct {
 	Ctx              context.Context
 	HeadSlot         uint64
 	ValidatorIndices []uint64
-	// RewardsEpoch is set by AttestationRewards in Run when it enqueues work (cloned into steps.Job for RunAsync).
-	RewardsEpoch *uint64
+	// WatchedValidators is every watched validator. ValidatorIndices is the same list, or with
+	// validator_sampling only this epoch's group; steps that must cover everyone read this one.
+	WatchedValidators []uint64
+	// RewardsEpochs are the finalized epochs AttestationRewards schedules in Run, oldest first (cloned
+	// into steps.Job for RunAsync).
+	RewardsEpochs []uint64
+	// RewardsGapEpochs are older unindexed epochs found by rewards_reconcile, oldest first. They are
+	// indexed before RewardsEpochs, but a failing gap is skipped rather than holding the rest back.
+	RewardsGapEpochs []uint64
 	// DeferLastProcessedCommit, when true, tells RecordLastProcessedSlot not to advance
 	// lastProcessedSlot this iteration (e.g. rewards epoch not finalized yet — retry same head next poll).
 	DeferLastProcessedCommit bool
+	// ELOffline is the node's el_offline from NodeSyncGate: its head may be optimistic, so the duty
+	// steps do not fetch duties this pass.
+	ELOffline bool
 }
 
 // NewEnv allocates an Env (e.g. for a Runner field).
@@ -24,8 +34,11 @@ func (e *Env) Reset(ctx context.Context) {
 	e.Ctx = ctx
 	e.HeadSlot = 0
 	e.ValidatorIndices = e.ValidatorIndices[:0]
-	e.RewardsEpoch = nil
+	e.WatchedValidators = e.WatchedValidators[:0]
+	e.RewardsEpochs = nil
+	e.RewardsGapEpochs = nil
 	e.DeferLastProcessedCommit = false
+	e.ELOffline = false
 }
 
 // Clone returns a copy of iteration fields safe to use on a worker after the runner resets Env.
@@ -33,16 +46,14 @@ func (e *Env) Clone() Env {
 	if e == nil {
 		return Env{}
 	}
-	var re *uint64
-	if e.RewardsEpoch != nil {
-		v := *e.RewardsEpoch
-		re = &v
-	}
 	return Env{
 		Ctx:                      e.Ctx,
 		HeadSlot:                 e.HeadSlot,
 		ValidatorIndices:         append([]uint64(nil), e.ValidatorIndices...),
-		RewardsEpoch:             re,
+		WatchedValidators:        append([]uint64(nil), e.WatchedValidators...),
+		RewardsEpochs:            append([]uint64(nil), e.RewardsEpochs...),
+		RewardsGapEpochs:         append([]uint64(nil), e.RewardsGapEpochs...),
 		DeferLastProcessedCommit: e.DeferLastProcessedCommit,
+		ELOffline:                e.ELOffline,
 	}
 }
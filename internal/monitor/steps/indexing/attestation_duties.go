@@ -0,0 +1,133 @@
+package indexing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// DutyIndexer fetches and validates attester duties for watched validators.
+type DutyIndexer struct {
+	Client  *beacon.Client
+	Network *config.BlockchainNetwork // optional; fills slot_time when genesis is known
+	Log     zerolog.Logger
+	// TargetCommitteeSize and MaxCommitteeLength bound plausible committee lengths
+	// (0 = mainnet preset 128 / 2048).
+	TargetCommitteeSize uint64
+	MaxCommitteeLength  uint64
+	// Filter drops duties outside the configured committee positions / indices before they are saved.
+	Filter config.DutyFilterConf
+	// Anomalies, when set, checks every fetched epoch (before Filter) for validators in zero or several committees.
+	Anomalies *DutyAnomalyDetector
+	// FromCommittees derives duties from the epoch's committees (one committees request) instead of the
+	// duties endpoint, which is still asked for the dependent_root and cross-checked against them.
+	FromCommittees bool
+	// OnMismatch, when set, receives the disagreements between the duties endpoint and the committees
+	// found while fetching with FromCommittees.
+	OnMismatch func(epoch uint64, mismatches []*storage.DutyMismatch)
+}
+
+// FetchAttesterDuties fetches duties for validators in epoch and drops assignments that fail validation
+// or the configured filter. It also returns the response's dependent_root, which changes when a reorg
+// reshuffles the epoch's duties. Callers persist the duties.
+func FetchAttesterDuties(ctx context.Context, idx *DutyIndexer, epoch uint64, validators []uint64) ([]*storage.AttestationDuty, string, error) {
+	if len(validators) == 0 {
+		return nil, "", nil
+	}
+	now := time.Now().UTC()
+	var (
+		duties []*storage.AttestationDuty
+		root   string
+		err    error
+	)
+	if idx.FromCommittees {
+		duties, root, err = fetchDutiesFromCommittees(ctx, idx, epoch, validators, now)
+	} else {
+		var resp *beacon.AttesterDutiesResponse
+		resp, err = idx.Client.GetAttesterDuties(ctx, epoch, validators)
+		if err == nil {
+			duties, root = idx.dutiesFromResponse(resp, epoch, now), resp.DependentRoot
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	for _, d := range duties {
+		d.SlotTime = slotTime(idx.Network, d.Slot)
+	}
+	warnSuspiciousCommitteeLengths(idx.Log, epoch, duties, idx.TargetCommitteeSize, idx.MaxCommitteeLength)
+	if idx.Anomalies != nil {
+		idx.Anomalies.Check(epoch, validators, duties, now)
+	}
+	return filterDuties(duties, idx.Filter), root, nil
+}
+
+// dutiesFromResponse converts a duties endpoint response, skipping (and logging) invalid assignments.
+func (idx *DutyIndexer) dutiesFromResponse(resp *beacon.AttesterDutiesResponse, epoch uint64, now time.Time) []*storage.AttestationDuty {
+	duties := make([]*storage.AttestationDuty, 0, len(resp.Data))
+	for i := range resp.Data {
+		d, err := attestationDutyFromBeacon(&resp.Data[i], epoch, resp.DependentRoot, now)
+		if err != nil {
+			idx.Log.Warn().Err(err).Uint64("epoch", epoch).Msg("skipping invalid attester duty")
+			continue
+		}
+		duties = append(duties, d)
+	}
+	return duties
+}
+
+// filterDuties keeps the duties matching filter, in place.
+func filterDuties(duties []*storage.AttestationDuty, filter config.DutyFilterConf) []*storage.AttestationDuty {
+	if len(filter.CommitteePositions) == 0 && len(filter.CommitteeIndices) == 0 {
+		return duties
+	}
+	kept := duties[:0]
+	for _, d := range duties {
+		if matchesAny(filter.CommitteePositions, d.CommitteePosition) && matchesAny(filter.CommitteeIndices, d.CommitteeIndex) {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// matchesAny reports whether v is in allowed; an empty allowed list matches everything.
+func matchesAny(allowed []uint64, v uint64) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
+// attestationDutyFromBeacon converts one API duty and checks committee_position < committee_length.
+func attestationDutyFromBeacon(d *beacon.AttesterDuty, epoch uint64, dependentRoot string, indexedAt time.Time) (*storage.AttestationDuty, error) {
+	out := &storage.AttestationDuty{
+		ValidatorIndex:    d.ValidatorIndex.Uint64(),
+		Epoch:             epoch,
+		Slot:              d.Slot.Uint64(),
+		CommitteeIndex:    d.CommitteeIndex.Uint64(),
+		CommitteePosition: d.ValidatorCommitteeIndex.Uint64(),
+		CommitteeLength:   d.CommitteeLength.Uint64(),
+		CommitteesAtSlot:  d.CommitteesAtSlot.Uint64(),
+		DependentRoot:     dependentRoot,
+		IndexedAt:         indexedAt,
+	}
+	if out.CommitteePosition >= out.CommitteeLength {
+		return nil, fmt.Errorf("validator %d slot %d: committee position %d out of range for committee length %d",
+			out.ValidatorIndex, out.Slot, out.CommitteePosition, out.CommitteeLength)
+	}
+	if out.CommitteeIndex >= out.CommitteesAtSlot {
+		return nil, fmt.Errorf("validator %d slot %d: committee index %d out of range for %d committees at slot",
+			out.ValidatorIndex, out.Slot, out.CommitteeIndex, out.CommitteesAtSlot)
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package indexing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestAttestationDutyFromBeacon(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0).UTC()
+
+	t.Run("maps committee sizing", func(t *testing.T) {
+		got, err := attestationDutyFromBeacon(&beacon.AttesterDuty{
+			ValidatorIndex:          42,
+			CommitteeIndex:          3,
+			CommitteeLength:         128,
+			CommitteesAtSlot:        64,
+			ValidatorCommitteeIndex: 17,
+			Slot:                    3205,
+		}, 100, "0xabc", now)
+		require.NoError(t, err)
+		require.Equal(t, uint64(42), got.ValidatorIndex)
+		require.Equal(t, uint64(100), got.Epoch)
+		require.Equal(t, uint64(3205), got.Slot)
+		require.Equal(t, uint64(17), got.CommitteePosition)
+		require.Equal(t, uint64(128), got.CommitteeLength)
+		require.Equal(t, uint64(64), got.CommitteesAtSlot)
+		require.Equal(t, "0xabc", got.DependentRoot)
+		require.Equal(t, now, got.IndexedAt)
+	})
+
+	t.Run("rejects position outside committee", func(t *testing.T) {
+		_, err := attestationDutyFromBeacon(&beacon.AttesterDuty{
+			CommitteeLength:         128,
+			CommitteesAtSlot:        64,
+			ValidatorCommitteeIndex: 128,
+		}, 1, "", now)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects committee index outside slot", func(t *testing.T) {
+		_, err := attestationDutyFromBeacon(&beacon.AttesterDuty{
+			CommitteeIndex:   4,
+			CommitteeLength:  10,
+			CommitteesAtSlot: 4,
+		}, 1, "", now)
+		require.Error(t, err)
+	})
+}
+
+func TestCommitteeLengthBounds(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name             string
+		committeesAtSlot uint64
+		length           uint64
+		want             int
+	}{
+		{"mainnet full committees", 64, 450, 0},
+		{"below cap", 20, 150, 0},
+		{"small devnet", 1, 40, 0},
+		{"zero length", 64, 0, 1},
+		{"above protocol max", 64, 4096, 1},
+		{"too large for committee count", 8, 600, 1},
+		{"too small for committee count", 8, 20, 1},
+	}
+	for _, tc := range cases {
+		duties := []*storage.AttestationDuty{{CommitteesAtSlot: tc.committeesAtSlot, CommitteeLength: tc.length}}
+		require.Equal(t, tc.want, warnSuspiciousCommitteeLengths(zerolog.Nop(), 1, duties, 0, 0), tc.name)
+	}
+}
+
+func TestFilterDuties(t *testing.T) {
+	t.Parallel()
+
+	duties := func() []*storage.AttestationDuty {
+		return []*storage.AttestationDuty{
+			{ValidatorIndex: 1, CommitteeIndex: 0, CommitteePosition: 0},
+			{ValidatorIndex: 2, CommitteeIndex: 3, CommitteePosition: 0},
+			{ValidatorIndex: 3, CommitteeIndex: 3, CommitteePosition: 17},
+		}
+	}
+	indices := func(ds []*storage.AttestationDuty) []uint64 {
+		out := make([]uint64, 0, len(ds))
+		for _, d := range ds {
+			out = append(out, d.ValidatorIndex)
+		}
+		return out
+	}
+
+	require.Equal(t, []uint64{1, 2, 3}, indices(filterDuties(duties(), config.DutyFilterConf{})))
+	require.Equal(t, []uint64{1, 2}, indices(filterDuties(duties(), config.DutyFilterConf{CommitteePositions: []uint64{0}})))
+	require.Equal(t, []uint64{2, 3}, indices(filterDuties(duties(), config.DutyFilterConf{CommitteeIndices: []uint64{3}})))
+	require.Equal(t, []uint64{2}, indices(filterDuties(duties(), config.DutyFilterConf{CommitteePositions: []uint64{0}, CommitteeIndices: []uint64{3}})))
+}
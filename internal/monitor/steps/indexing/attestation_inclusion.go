@@ -0,0 +1,124 @@
+package indexing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// InclusionResult is the outcome of a just-in-time inclusion check for one duty.
+type InclusionResult struct {
+	Duty     *storage.AttestationDuty
+	Included bool
+	// InclusionSlot is the first block slot that carried the attestation (valid when Included).
+	InclusionSlot uint64
+}
+
+// InclusionChecker scans recent blocks for the attestations of scheduled duties.
+type InclusionChecker struct {
+	Client *beacon.Client
+	Log    zerolog.Logger
+}
+
+// CheckAttestationInclusion reports, for each duty, whether a block in duty.Slot+1 .. duty.Slot+window
+// included an aggregate with the validator's bit set. Blocks are fetched once per call; missed slots are skipped.
+func CheckAttestationInclusion(ctx context.Context, c *InclusionChecker, duties []*storage.AttestationDuty, window uint64) ([]InclusionResult, error) {
+	blocks := make(map[uint64][]beacon.BlockAttestation)
+	committeeLens := make(map[uint64]map[uint64]uint64)
+
+	attestationsAt := func(slot uint64) ([]beacon.BlockAttestation, error) {
+		if atts, ok := blocks[slot]; ok {
+			return atts, nil
+		}
+		atts, err := c.Client.GetBlockAttestations(ctx, strconv.FormatUint(slot, 10))
+		if err != nil {
+			if !beacon.IsNotFound(err) {
+				return nil, err
+			}
+			c.Log.Debug().Uint64("slot", slot).Msg("no block at slot; skipping for inclusion check")
+			atts = nil
+		}
+		blocks[slot] = atts
+		return atts, nil
+	}
+
+	results := make([]InclusionResult, 0, len(duties))
+	for _, d := range duties {
+		lengthAt := committeeLengthLookup(ctx, c.Client, d, committeeLens)
+		res := InclusionResult{Duty: d}
+		for slot := d.Slot + 1; slot <= d.Slot+window && !res.Included; slot++ {
+			atts, err := attestationsAt(slot)
+			if err != nil {
+				return nil, err
+			}
+			for i := range atts {
+				if atts[i].Data.Slot.Uint64() != d.Slot {
+					continue
+				}
+				ok, err := atts[i].HasAttester(d.CommitteeIndex, d.CommitteePosition, lengthAt)
+				if err != nil {
+					return nil, fmt.Errorf("inclusion check validator %d slot %d: %w", d.ValidatorIndex, d.Slot, err)
+				}
+				if ok {
+					res.Included = true
+					res.InclusionSlot = slot
+					break
+				}
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// committeeLengthLookup resolves committee sizes at d.Slot for Electra aggregates. The duty's own committee
+// length is known; other committees are loaded once per slot from the head state.
+func committeeLengthLookup(ctx context.Context, client *beacon.Client, d *storage.AttestationDuty, cache map[uint64]map[uint64]uint64) func(uint64) (uint64, error) {
+	return func(committeeIndex uint64) (uint64, error) {
+		if committeeIndex == d.CommitteeIndex {
+			return d.CommitteeLength, nil
+		}
+		lens, ok := cache[d.Slot]
+		if !ok {
+			committees, err := client.GetBeaconCommittees(ctx, "head", d.Epoch, beacon.CommitteeFilter{Slot: &d.Slot})
+			if err != nil {
+				return 0, err
+			}
+			lens = make(map[uint64]uint64, len(committees))
+			for _, bc := range committees {
+				lens[bc.Index.Uint64()] = uint64(len(bc.Validators))
+			}
+			cache[d.Slot] = lens
+		}
+		n, ok := lens[committeeIndex]
+		if !ok {
+			return 0, fmt.Errorf("committee %d not found at slot %d", committeeIndex, d.Slot)
+		}
+		return n, nil
+	}
+}
+
+// LivenessRows converts inclusion results into provisional attestation_liveness rows.
+func LivenessRows(results []InclusionResult, checkedAt time.Time) []*storage.AttestationLiveness {
+	rows := make([]*storage.AttestationLiveness, 0, len(results))
+	for _, r := range results {
+		row := &storage.AttestationLiveness{
+			ValidatorIndex: r.Duty.ValidatorIndex,
+			Epoch:          r.Duty.Epoch,
+			Slot:           r.Duty.Slot,
+			Included:       r.Included,
+			CheckedAt:      checkedAt,
+		}
+		if r.Included {
+			s := r.InclusionSlot
+			row.InclusionSlot = &s
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
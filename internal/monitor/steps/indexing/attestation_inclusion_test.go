@@ -0,0 +1,33 @@
+package indexing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestLivenessRows(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0).UTC()
+	hit := &storage.AttestationDuty{ValidatorIndex: 1, Epoch: 10, Slot: 321}
+	miss := &storage.AttestationDuty{ValidatorIndex: 2, Epoch: 10, Slot: 322}
+
+	rows := LivenessRows([]InclusionResult{
+		{Duty: hit, Included: true, InclusionSlot: 322},
+		{Duty: miss},
+	}, now)
+	require.Len(t, rows, 2)
+
+	require.True(t, rows[0].Included)
+	require.NotNil(t, rows[0].InclusionSlot)
+	require.Equal(t, uint64(322), *rows[0].InclusionSlot)
+	require.Equal(t, uint64(321), rows[0].Slot)
+	require.Equal(t, now, rows[0].CheckedAt)
+
+	require.False(t, rows[1].Included)
+	require.Nil(t, rows[1].InclusionSlot)
+	require.Nil(t, rows[1].RewardIncluded, "reconciliation happens later")
+}
@@ -37,7 +37,7 @@ func IndexBlockAtSlot(ctx context.Context, idx *BlockIndexer, slot uint64) error
 
 	proposerIndex := header.Data.Header.Message.ProposerIndex.Uint64()
 
-	rewardsData, err := idx.Client.GetBlockRewards(ctx, blockID)
+	rewardsResp, err := idx.Client.GetBlockRewards(ctx, blockID)
 	if err != nil {
 		if rewardsStateNotYetAvailable(err) {
 			idx.Log.Warn().Err(err).Uint64("slot", slot).Msg("block rewards not available yet")
@@ -71,8 +71,16 @@ func IndexBlockAtSlot(ctx context.Context, idx *BlockIndexer, slot uint64) error
 		ValidatorPubkey: pubkey,
 		SlotNumber:      slot,
 		BlockNumber:     execBlock,
-		Rewards:         rewardsData.Total.Uint64(),
-		Timestamp:       time.Now().UTC(),
+		Rewards:         rewardsResp.Data.Total.Uint64(),
+		RewardComponents: &storage.BlockRewardComponents{
+			Attestations:      rewardsResp.Data.Attestations.Uint64(),
+			SyncAggregate:     rewardsResp.Data.SyncAggregate.Uint64(),
+			ProposerSlashings: rewardsResp.Data.ProposerSlashings.Uint64(),
+			AttesterSlashings: rewardsResp.Data.AttesterSlashings.Uint64(),
+		},
+		Timestamp: time.Now().UTC(),
+		// Provisional until a re-index sees the payload verified.
+		ExecutionOptimistic: header.ExecutionOptimistic || rewardsResp.ExecutionOptimistic,
 	}
 
 	if idx.Execution != nil && execBlock != nil {
@@ -94,6 +102,11 @@ func IndexBlockAtSlot(ctx context.Context, idx *BlockIndexer, slot uint64) error
 		}
 	} else {
 		row.SyncCommitteeRewards = blockSyncCommitteeRewardsFromBeacon(syncResult)
+		row.ExecutionOptimistic = row.ExecutionOptimistic || syncResult.ExecutionOptimistic
+	}
+
+	if row.ExecutionOptimistic {
+		idx.Log.Warn().Uint64("slot", slot).Msg("execution optimistic block response; row marked provisional")
 	}
 
 	if err := idx.Repo.SaveBlock(ctx, row); err != nil {
@@ -0,0 +1,96 @@
+package indexing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// fetchDutiesFromCommittees loads every committee of epoch in one request and derives the duties of
+// validators from it. The duties endpoint is still asked for the dependent_root and its answer is
+// reconciled against the committees (reported via idx.OnMismatch). When only the duties endpoint fails,
+// the derived duties are returned without a dependent_root, so reorg rechecks are skipped for the epoch.
+func fetchDutiesFromCommittees(ctx context.Context, idx *DutyIndexer, epoch uint64, validators []uint64, now time.Time) ([]*storage.AttestationDuty, string, error) {
+	committees, err := idx.Client.GetBeaconCommittees(ctx, "head", epoch, beacon.CommitteeFilter{})
+	if err != nil {
+		return nil, "", fmt.Errorf("committees epoch %d: %w", epoch, err)
+	}
+	resp, err := idx.Client.GetAttesterDuties(ctx, epoch, validators)
+	if err != nil {
+		idx.Log.Warn().Err(err).Uint64("epoch", epoch).Msg("attester duties unavailable; using committee-derived duties without dependent root")
+		return dutiesFromCommittees(committees, epoch, validators, "", now), "", nil
+	}
+	duties := dutiesFromCommittees(committees, epoch, validators, resp.DependentRoot, now)
+	if idx.OnMismatch != nil {
+		if mismatches := reconcileDuties(idx.dutiesFromResponse(resp, epoch, now), duties, committees, now); len(mismatches) > 0 {
+			idx.OnMismatch(epoch, mismatches)
+		}
+	}
+	return duties, resp.DependentRoot, nil
+}
+
+// dutiesFromCommittees returns one duty per seat of a watched validator in committees, in committee order.
+func dutiesFromCommittees(committees []beacon.BeaconCommittee, epoch uint64, validators []uint64, dependentRoot string, indexedAt time.Time) []*storage.AttestationDuty {
+	watched := make(map[uint64]struct{}, len(validators))
+	for _, v := range validators {
+		watched[v] = struct{}{}
+	}
+	committeesAtSlot := make(map[uint64]uint64)
+	for _, bc := range committees {
+		committeesAtSlot[bc.Slot.Uint64()]++
+	}
+	var out []*storage.AttestationDuty
+	for _, bc := range committees {
+		for pos, v := range bc.Validators {
+			if _, ok := watched[v.Uint64()]; !ok {
+				continue
+			}
+			out = append(out, &storage.AttestationDuty{
+				ValidatorIndex:    v.Uint64(),
+				Epoch:             epoch,
+				Slot:              bc.Slot.Uint64(),
+				CommitteeIndex:    bc.Index.Uint64(),
+				CommitteePosition: uint64(pos),
+				CommitteeLength:   uint64(len(bc.Validators)),
+				CommitteesAtSlot:  committeesAtSlot[bc.Slot.Uint64()],
+				DependentRoot:     dependentRoot,
+				IndexedAt:         indexedAt,
+			})
+		}
+	}
+	return out
+}
+
+// reconcileDuties compares the duties endpoint's answer with the committee-derived duties: an endpoint
+// duty that disagrees with the committees is a position or not_in_committees mismatch, and a derived
+// duty the endpoint did not return is missing_from_duties (expected fields zero, actual = committee seat).
+func reconcileDuties(endpoint, derived []*storage.AttestationDuty, committees []beacon.BeaconCommittee, detectedAt time.Time) []*storage.DutyMismatch {
+	var out []*storage.DutyMismatch
+	returned := make(map[uint64]struct{}, len(endpoint))
+	for _, d := range endpoint {
+		returned[d.ValidatorIndex] = struct{}{}
+		if m := dutyMismatch(d, committees); m != nil {
+			m.DetectedAt = detectedAt
+			out = append(out, m)
+		}
+	}
+	for _, d := range derived {
+		if _, ok := returned[d.ValidatorIndex]; ok {
+			continue
+		}
+		idx, pos := d.CommitteeIndex, d.CommitteePosition
+		out = append(out, &storage.DutyMismatch{
+			ValidatorIndex:       d.ValidatorIndex,
+			Epoch:                d.Epoch,
+			Slot:                 d.Slot,
+			Reason:               storage.DutyMismatchMissingDuty,
+			ActualCommitteeIndex: &idx,
+			ActualPosition:       &pos,
+			DetectedAt:           detectedAt,
+		})
+	}
+	return out
+}
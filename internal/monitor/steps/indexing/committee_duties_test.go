@@ -0,0 +1,61 @@
+package indexing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestFetchAttesterDuties_fromCommittees(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/committees") {
+			_, _ = w.Write([]byte(`{"data":[
+				{"index":"0","slot":"96","validators":["5","6","7"]},
+				{"index":"1","slot":"96","validators":["8","9"]},
+				{"index":"0","slot":"97","validators":["10","11"]}]}`))
+			return
+		}
+		// The duties endpoint misplaces validator 6 and omits validator 11.
+		_, _ = w.Write([]byte(`{"dependent_root":"0xaa","data":[
+			{"validator_index":"6","slot":"96","committee_index":"1","committee_length":"2","committees_at_slot":"2","validator_committee_index":"0"},
+			{"validator_index":"9","slot":"96","committee_index":"1","committee_length":"2","committees_at_slot":"2","validator_committee_index":"1"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	var mismatches []*storage.DutyMismatch
+	idx := &DutyIndexer{
+		Client:         client,
+		Log:            zerolog.Nop(),
+		FromCommittees: true,
+		OnMismatch:     func(_ uint64, m []*storage.DutyMismatch) { mismatches = append(mismatches, m...) },
+	}
+	duties, root, err := FetchAttesterDuties(context.Background(), idx, 3, []uint64{6, 9, 11})
+	require.NoError(t, err)
+	require.Equal(t, "0xaa", root)
+	require.Len(t, duties, 3)
+	require.Equal(t, storage.AttestationDuty{
+		ValidatorIndex: 6, Epoch: 3, Slot: 96, CommitteeIndex: 0, CommitteePosition: 1,
+		CommitteeLength: 3, CommitteesAtSlot: 2, DependentRoot: "0xaa", IndexedAt: duties[0].IndexedAt,
+	}, *duties[0])
+	require.Equal(t, uint64(1), duties[2].CommitteesAtSlot, "slot 97 has one committee")
+
+	require.Len(t, mismatches, 2)
+	require.Equal(t, uint64(6), mismatches[0].ValidatorIndex)
+	require.Equal(t, storage.DutyMismatchPosition, mismatches[0].Reason)
+	require.Equal(t, uint64(11), mismatches[1].ValidatorIndex)
+	require.Equal(t, storage.DutyMismatchMissingDuty, mismatches[1].Reason)
+	require.Equal(t, uint64(1), *mismatches[1].ActualPosition)
+}
@@ -0,0 +1,69 @@
+package indexing
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Consensus spec defaults for committee sizing (mainnet preset).
+const (
+	defaultTargetCommitteeSize = 128
+	defaultMaxCommitteeLength  = 2048
+	maxCommitteesPerSlot       = 64
+)
+
+// committeeLengthBounds returns the committee length range consistent with committeesAtSlot.
+// committees_per_slot = clamp(active / slots_per_epoch / target, 1, 64), so below the cap each
+// committee holds between target and 2*target validators; a single committee holds fewer than
+// 2*target, and at the cap committees grow up to the protocol maximum.
+func committeeLengthBounds(committeesAtSlot, target, max uint64) (lo, hi uint64) {
+	if target == 0 {
+		target = defaultTargetCommitteeSize
+	}
+	if max == 0 {
+		max = defaultMaxCommitteeLength
+	}
+	switch {
+	case committeesAtSlot <= 1:
+		return 1, min(2*target, max)
+	case committeesAtSlot < maxCommitteesPerSlot:
+		return target, min(2*target, max)
+	default:
+		return target, max
+	}
+}
+
+// warnSuspiciousCommitteeLengths logs one suspicious_committee_length warning per epoch when any duty's
+// committee length falls outside the bounds implied by its committees_at_slot. Such values usually mean
+// a broken node or a connection to the wrong network; the duties are still stored.
+func warnSuspiciousCommitteeLengths(log zerolog.Logger, epoch uint64, duties []*storage.AttestationDuty, target, max uint64) int {
+	var (
+		bad   int
+		first *storage.AttestationDuty
+	)
+	for _, d := range duties {
+		lo, hi := committeeLengthBounds(d.CommitteesAtSlot, target, max)
+		if d.CommitteeLength < lo || d.CommitteeLength > hi {
+			bad++
+			if first == nil {
+				first = d
+			}
+		}
+	}
+	if bad == 0 {
+		return 0
+	}
+	lo, hi := committeeLengthBounds(first.CommitteesAtSlot, target, max)
+	log.Warn().
+		Str("check", "suspicious_committee_length").
+		Uint64("epoch", epoch).
+		Int("duties", bad).
+		Uint64("slot", first.Slot).
+		Uint64("committee_index", first.CommitteeIndex).
+		Uint64("committee_length", first.CommitteeLength).
+		Uint64("committees_at_slot", first.CommitteesAtSlot).
+		Uint64("expected_min", lo).
+		Uint64("expected_max", hi).
+		Msg("suspicious_committee_length: attester duty committee length outside expected bounds; check the beacon node and network")
+	return bad
+}
@@ -0,0 +1,89 @@
+package indexing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// DutyAnomalyDetector checks that every watched validator is in exactly one committee per epoch, as the
+// spec guarantees for active validators. Two duties for one validator in an epoch is always an anomaly;
+// no duty is one only with Missing set and when the validator had a duty in the previous epoch (a
+// validator that just exited also has none). Anomalies accumulate until Drain.
+type DutyAnomalyDetector struct {
+	missing bool
+
+	mu      sync.Mutex
+	seen    map[uint64]map[uint64]*storage.AttestationDuty // epoch -> validator -> duty
+	pending []*storage.DutyMismatch
+}
+
+// NewDutyAnomalyDetector returns a detector for attestation_duties.anomalies.
+func NewDutyAnomalyDetector(conf config.DutyAnomaliesConf) *DutyAnomalyDetector {
+	return &DutyAnomalyDetector{missing: conf.Missing, seen: make(map[uint64]map[uint64]*storage.AttestationDuty)}
+}
+
+// Check records the anomalies in one epoch's unfiltered duties for the requested validators and
+// remembers each validator's committee for the next epoch's check.
+func (d *DutyAnomalyDetector) Check(epoch uint64, requested []uint64, duties []*storage.AttestationDuty, detectedAt time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	byValidator := make(map[uint64]*storage.AttestationDuty, len(duties))
+	for _, duty := range duties {
+		first, dup := byValidator[duty.ValidatorIndex]
+		if !dup {
+			byValidator[duty.ValidatorIndex] = duty
+			continue
+		}
+		ci, pos := duty.CommitteeIndex, duty.CommitteePosition
+		d.pending = append(d.pending, &storage.DutyMismatch{
+			ValidatorIndex:         duty.ValidatorIndex,
+			Epoch:                  epoch,
+			Slot:                   first.Slot,
+			Reason:                 storage.DutyAnomalyMultipleCommittees,
+			ExpectedCommitteeIndex: first.CommitteeIndex,
+			ExpectedPosition:       first.CommitteePosition,
+			ActualCommitteeIndex:   &ci,
+			ActualPosition:         &pos,
+			DetectedAt:             detectedAt,
+		})
+	}
+	if d.missing && epoch > 0 {
+		if prev, ok := d.seen[epoch-1]; ok {
+			for _, v := range requested {
+				last, had := prev[v]
+				if _, has := byValidator[v]; !had || has {
+					continue
+				}
+				d.pending = append(d.pending, &storage.DutyMismatch{
+					ValidatorIndex:         v,
+					Epoch:                  epoch,
+					Slot:                   epoch * config.SlotsPerEpoch(),
+					Reason:                 storage.DutyAnomalyNoCommittee,
+					ExpectedCommitteeIndex: last.CommitteeIndex,
+					ExpectedPosition:       last.CommitteePosition,
+					DetectedAt:             detectedAt,
+				})
+			}
+		}
+	}
+
+	d.seen[epoch] = byValidator
+	for e := range d.seen {
+		if e+2 < epoch {
+			delete(d.seen, e)
+		}
+	}
+}
+
+// Drain returns and clears the anomalies recorded since the last call.
+func (d *DutyAnomalyDetector) Drain() []*storage.DutyMismatch {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := d.pending
+	d.pending = nil
+	return out
+}
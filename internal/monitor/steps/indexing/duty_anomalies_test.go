@@ -0,0 +1,42 @@
+package indexing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestDutyAnomalyDetector(t *testing.T) {
+	now := time.Now().UTC()
+	duty := func(v, epoch, committee uint64) *storage.AttestationDuty {
+		return &storage.AttestationDuty{ValidatorIndex: v, Epoch: epoch, Slot: epoch*32 + committee, CommitteeIndex: committee, CommitteePosition: 1}
+	}
+	d := NewDutyAnomalyDetector(config.DutyAnomaliesConf{Enabled: true, Missing: true})
+
+	d.Check(10, []uint64{1, 2}, []*storage.AttestationDuty{duty(1, 10, 0), duty(2, 10, 3)}, now)
+	require.Empty(t, d.Drain())
+
+	// Epoch 11: validator 1 twice, validator 2 missing.
+	d.Check(11, []uint64{1, 2}, []*storage.AttestationDuty{duty(1, 11, 0), duty(1, 11, 5)}, now)
+	got := d.Drain()
+	require.Len(t, got, 2)
+	require.Equal(t, storage.DutyAnomalyMultipleCommittees, got[0].Reason)
+	require.Equal(t, uint64(1), got[0].ValidatorIndex)
+	require.Equal(t, uint64(5), *got[0].ActualCommitteeIndex)
+	require.Equal(t, storage.DutyAnomalyNoCommittee, got[1].Reason)
+	require.Equal(t, uint64(2), got[1].ValidatorIndex)
+	require.Equal(t, uint64(3), got[1].ExpectedCommitteeIndex)
+	require.Empty(t, d.Drain())
+
+	// Missing again: no duty in the previous epoch either, so not flagged twice.
+	d.Check(12, []uint64{1, 2}, []*storage.AttestationDuty{duty(1, 12, 0)}, now)
+	require.Empty(t, d.Drain())
+
+	noMissing := NewDutyAnomalyDetector(config.DutyAnomaliesConf{Enabled: true})
+	noMissing.Check(10, []uint64{2}, []*storage.AttestationDuty{duty(2, 10, 3)}, now)
+	noMissing.Check(11, []uint64{2}, nil, now)
+	require.Empty(t, noMissing.Drain())
+}
@@ -0,0 +1,91 @@
+package indexing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// VerifyIncludedDuties cross-checks the stored committee index/position of every included duty
+// against the committees at the duty slot and returns one DutyMismatch per disagreement.
+// Committees are fetched once per slot from the head state.
+func VerifyIncludedDuties(ctx context.Context, client *beacon.Client, results []InclusionResult, detectedAt time.Time) ([]*storage.DutyMismatch, error) {
+	committeesBySlot := make(map[uint64][]beacon.BeaconCommittee)
+	var out []*storage.DutyMismatch
+	for _, r := range results {
+		if !r.Included {
+			continue
+		}
+		d := r.Duty
+		committees, ok := committeesBySlot[d.Slot]
+		if !ok {
+			var err error
+			committees, err = client.GetBeaconCommittees(ctx, "head", d.Epoch, beacon.CommitteeFilter{Slot: &d.Slot})
+			if err != nil {
+				return nil, fmt.Errorf("verify duty validator %d slot %d: %w", d.ValidatorIndex, d.Slot, err)
+			}
+			committeesBySlot[d.Slot] = committees
+		}
+		if m := dutyMismatch(d, committees); m != nil {
+			m.DetectedAt = detectedAt
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// VerifyDuties cross-checks freshly fetched duties for epoch against every committee of the epoch,
+// loaded with one committees request independent of the duties endpoint, and returns one
+// DutyMismatch per disagreement.
+func VerifyDuties(ctx context.Context, client *beacon.Client, epoch uint64, duties []*storage.AttestationDuty, detectedAt time.Time) ([]*storage.DutyMismatch, error) {
+	if len(duties) == 0 {
+		return nil, nil
+	}
+	committees, err := client.GetBeaconCommittees(ctx, "head", epoch, beacon.CommitteeFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("verify duties epoch %d: %w", epoch, err)
+	}
+	var out []*storage.DutyMismatch
+	for _, d := range duties {
+		if m := dutyMismatch(d, committees); m != nil {
+			m.DetectedAt = detectedAt
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// dutyMismatch returns nil when committees place d's validator at the stored committee index and
+// position, otherwise a mismatch carrying where (if anywhere) the validator actually sits.
+func dutyMismatch(d *storage.AttestationDuty, committees []beacon.BeaconCommittee) *storage.DutyMismatch {
+	m := &storage.DutyMismatch{
+		ValidatorIndex:         d.ValidatorIndex,
+		Epoch:                  d.Epoch,
+		Slot:                   d.Slot,
+		Reason:                 storage.DutyMismatchNotInCommittees,
+		ExpectedCommitteeIndex: d.CommitteeIndex,
+		ExpectedPosition:       d.CommitteePosition,
+	}
+	for _, bc := range committees {
+		if bc.Slot.Uint64() != d.Slot {
+			continue
+		}
+		for pos, v := range bc.Validators {
+			if v.Uint64() != d.ValidatorIndex {
+				continue
+			}
+			if bc.Index.Uint64() == d.CommitteeIndex && uint64(pos) == d.CommitteePosition {
+				return nil
+			}
+			idx, p := bc.Index.Uint64(), uint64(pos)
+			m.Reason = storage.DutyMismatchPosition
+			m.ActualCommitteeIndex = &idx
+			m.ActualPosition = &p
+			return m
+		}
+	}
+	return m
+}
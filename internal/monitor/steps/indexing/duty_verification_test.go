@@ -0,0 +1,73 @@
+package indexing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestDutyMismatch(t *testing.T) {
+	t.Parallel()
+
+	committees := []beacon.BeaconCommittee{
+		{Index: 0, Slot: 100, Validators: []beacon.Uint64Str{5, 6, 7}},
+		{Index: 1, Slot: 100, Validators: []beacon.Uint64Str{8, 9}},
+	}
+	duty := func(v, ci, pos uint64) *storage.AttestationDuty {
+		return &storage.AttestationDuty{ValidatorIndex: v, Epoch: 3, Slot: 100, CommitteeIndex: ci, CommitteePosition: pos}
+	}
+
+	t.Run("matches", func(t *testing.T) {
+		require.Nil(t, dutyMismatch(duty(9, 1, 1), committees))
+	})
+
+	t.Run("wrong position", func(t *testing.T) {
+		m := dutyMismatch(duty(9, 0, 1), committees)
+		require.NotNil(t, m)
+		require.Equal(t, storage.DutyMismatchPosition, m.Reason)
+		require.Equal(t, uint64(1), *m.ActualCommitteeIndex)
+		require.Equal(t, uint64(1), *m.ActualPosition)
+		require.Equal(t, uint64(0), m.ExpectedCommitteeIndex)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		m := dutyMismatch(duty(42, 0, 0), committees)
+		require.NotNil(t, m)
+		require.Equal(t, storage.DutyMismatchNotInCommittees, m.Reason)
+		require.Nil(t, m.ActualCommitteeIndex)
+	})
+}
+
+func TestVerifyDuties(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		_, _ = w.Write([]byte(`{"data":[{"index":"0","slot":"96","validators":["5","6"]},{"index":"0","slot":"97","validators":["7","8"]}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	duties := []*storage.AttestationDuty{
+		{ValidatorIndex: 6, Epoch: 3, Slot: 96, CommitteeIndex: 0, CommitteePosition: 1},
+		{ValidatorIndex: 8, Epoch: 3, Slot: 96, CommitteeIndex: 0, CommitteePosition: 1},
+	}
+	now := time.Unix(1700000000, 0).UTC()
+	mismatches, err := VerifyDuties(context.Background(), client, 3, duties, now)
+	require.NoError(t, err)
+	require.Len(t, mismatches, 1, "validator 8 sits at slot 97, not 96")
+	require.Equal(t, uint64(8), mismatches[0].ValidatorIndex)
+	require.Equal(t, storage.DutyMismatchNotInCommittees, mismatches[0].Reason)
+	require.Equal(t, now, mismatches[0].DetectedAt)
+	require.Equal(t, []string{"/eth/v1/beacon/states/head/committees?epoch=3"}, requests, "one request for the whole epoch")
+}
@@ -3,6 +3,7 @@ package indexing
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -11,29 +12,20 @@ import (
 	"github.com/tharun/pauli/internal/storage"
 )
 
-const validatorEpochRecordBatchSize = 500
-
 // EpochIndexer fetches network-wide epoch balances and attestation rewards into one table.
 type EpochIndexer struct {
-	Client *beacon.Client
-	Repo   storage.Repository
-	Log    zerolog.Logger
+	Client  *beacon.Client
+	Repo    storage.Repository
+	Network *config.BlockchainNetwork // optional; fills slot_time when genesis is known
+	Log     zerolog.Logger
+	// Watched, when set, gets a reward distribution summary (log and metrics) for every epoch ApplyEpoch
+	// marks indexed.
+	Watched []uint64
 }
 
 // IndexEpochAtBoundary snapshots all validators at the epoch start slot, merges attestation
 // rewards when available, and marks the epoch indexed only after rewards are persisted.
 func IndexEpochAtBoundary(ctx context.Context, idx *EpochIndexer, epoch uint64) error {
-	if epoch == 0 {
-		indexed, err := idx.Repo.IsEpochIndexed(ctx, 0)
-		if err != nil {
-			return err
-		}
-		if indexed {
-			return nil
-		}
-		return idx.Repo.MarkEpochIndexed(ctx, 0)
-	}
-
 	indexed, err := idx.Repo.IsEpochIndexed(ctx, epoch)
 	if err != nil {
 		return err
@@ -42,67 +34,199 @@ func IndexEpochAtBoundary(ctx context.Context, idx *EpochIndexer, epoch uint64)
 		return nil
 	}
 
+	f, err := FetchEpoch(ctx, idx, epoch)
+	if err != nil {
+		return err
+	}
+	_, err = ApplyEpoch(ctx, idx, f)
+	return err
+}
+
+// EpochFetch is the beacon data for one epoch boundary, read by FetchEpoch and persisted by ApplyEpoch.
+// Splitting the two lets callers fetch several epochs concurrently and still write them in order.
+type EpochFetch struct {
+	Epoch      uint64
+	Records    []*storage.ValidatorEpochRecord
+	RewardsOK  bool
+	Optimistic bool
+}
+
+// FetchEpoch reads all validators at the epoch start slot and merges attestation rewards when
+// available. It does not touch storage. Epoch 0 has no rewards and yields no records.
+func FetchEpoch(ctx context.Context, idx *EpochIndexer, epoch uint64) (*EpochFetch, error) {
+	if epoch == 0 {
+		return &EpochFetch{Epoch: 0, RewardsOK: true}, nil
+	}
+
 	slot := epoch * config.SlotsPerEpoch()
 
-	validators, err := idx.Client.GetValidatorsAllAtSlot(ctx, slot)
+	validatorsResp, err := idx.Client.GetValidatorsResponse(ctx, strconv.FormatUint(slot, 10), nil)
 	if err != nil {
-		return fmt.Errorf("get all validators at epoch %d slot %d: %w", epoch, slot, err)
+		return nil, fmt.Errorf("get all validators at epoch %d slot %d: %w", epoch, slot, err)
 	}
 
-	rewardsByIndex, rewardsOK, err := fetchAttestationRewardsByIndex(ctx, idx.Client, epoch, idx.Log)
+	rewardsByIndex, rewardsOK, rewardsOptimistic, err := fetchAttestationRewardsByIndex(ctx, idx.Client, epoch, idx.Log)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	records := mergeValidatorEpochRecords(validators, epoch, slot, rewardsByIndex)
-	if err := saveValidatorEpochRecordsBatched(ctx, idx.Repo, records); err != nil {
-		return err
+	optimistic := validatorsResp.ExecutionOptimistic || rewardsOptimistic
+	return &EpochFetch{
+		Epoch:      epoch,
+		Records:    mergeValidatorEpochRecords(validatorsResp.Data, epoch, slot, slotTime(idx.Network, slot), rewardsByIndex, optimistic),
+		RewardsOK:  rewardsOK,
+		Optimistic: optimistic,
+	}, nil
+}
+
+// ApplyEpoch saves f's records and, when rewards were complete and not execution optimistic,
+// reconciles attestation liveness and marks the epoch indexed. It reports whether the epoch was marked.
+func ApplyEpoch(ctx context.Context, idx *EpochIndexer, f *EpochFetch) (bool, error) {
+	epoch := f.Epoch
+	if epoch == 0 {
+		return true, idx.Repo.MarkEpochIndexed(ctx, 0)
 	}
 
-	if !rewardsOK {
+	if err := idx.Repo.SaveValidatorEpochRecords(ctx, f.Records); err != nil {
+		return false, err
+	}
+
+	if !f.RewardsOK {
 		idx.Log.Debug().Uint64("epoch", epoch).Msg("epoch balances saved; attestation rewards pending")
-		return nil
+		return false, nil
+	}
+	if f.Optimistic {
+		// Leave the epoch unmarked so a later pass overwrites the provisional rows.
+		idx.Log.Warn().Uint64("epoch", epoch).Msg("execution optimistic epoch response; rows marked provisional")
+		return false, nil
+	}
+
+	reconciled, err := idx.Repo.ReconcileAttestationLiveness(ctx, epoch)
+	if err != nil {
+		return false, fmt.Errorf("reconcile attestation liveness epoch %d: %w", epoch, err)
+	}
+	if reconciled > 0 {
+		idx.Log.Debug().Uint64("epoch", epoch).Int64("rows", reconciled).Msg("reconciled attestation liveness")
 	}
 
 	if err := idx.Repo.MarkEpochIndexed(ctx, epoch); err != nil {
-		return fmt.Errorf("mark epoch %d indexed: %w", epoch, err)
+		return false, fmt.Errorf("mark epoch %d indexed: %w", epoch, err)
 	}
 
-	idx.Log.Debug().Uint64("epoch", epoch).Int("validators", len(records)).Msg("indexed epoch")
-	return nil
+	idx.Log.Debug().Uint64("epoch", epoch).Int("validators", len(f.Records)).Msg("indexed epoch")
+	if len(idx.Watched) > 0 {
+		if d := SummarizeRewards(epoch, f.Records, idx.Watched); d != nil {
+			d.report(idx.Log)
+		}
+	}
+	return true, nil
 }
 
-func fetchAttestationRewardsByIndex(ctx context.Context, client *beacon.Client, epoch uint64, log zerolog.Logger) (map[uint64]beacon.AttestationReward, bool, error) {
+// IndexEpochRewards fetches and saves balances and attestation rewards for exactly epoch, regardless of
+// indexer_progress (one-off audits). When validators is empty it indexes the whole network and marks the
+// epoch indexed; otherwise only the listed validators are written. Unavailable rewards are an error.
+func IndexEpochRewards(ctx context.Context, idx *EpochIndexer, epoch uint64, validators []uint64) (int, error) {
+	slot := epoch * config.SlotsPerEpoch()
+
+	var vals []beacon.Validator
+	var optimistic bool
+	if len(validators) == 0 {
+		resp, err := idx.Client.GetValidatorsResponse(ctx, strconv.FormatUint(slot, 10), nil)
+		if err != nil {
+			return 0, fmt.Errorf("get validators at epoch %d slot %d: %w", epoch, slot, err)
+		}
+		vals, optimistic = resp.Data, resp.ExecutionOptimistic
+	} else {
+		var err error
+		vals, err = idx.Client.GetValidatorsAtSlot(ctx, slot, validators)
+		if err != nil {
+			return 0, fmt.Errorf("get validators at epoch %d slot %d: %w", epoch, slot, err)
+		}
+	}
+
+	resp, err := idx.Client.GetAttestationRewards(ctx, epoch, validators)
+	if err != nil {
+		return 0, fmt.Errorf("fetch attestation rewards epoch %d: %w", epoch, err)
+	}
+	if idealRewardsOnly(resp) {
+		return 0, fmt.Errorf("attestation rewards epoch %d: ideal_rewards returned without total_rewards", epoch)
+	}
+	optimistic = optimistic || resp.ExecutionOptimistic
+	rewards := make(map[uint64]beacon.AttestationReward, len(resp.Data.TotalRewards))
+	for _, r := range resp.Data.TotalRewards {
+		rewards[r.ValidatorIndex.Uint64()] = r
+	}
+
+	records := mergeValidatorEpochRecords(vals, epoch, slot, slotTime(idx.Network, slot), rewards, optimistic)
+	if err := idx.Repo.SaveValidatorEpochRecords(ctx, records); err != nil {
+		return 0, err
+	}
+	if _, err := idx.Repo.ReconcileAttestationLiveness(ctx, epoch); err != nil {
+		return 0, fmt.Errorf("reconcile attestation liveness epoch %d: %w", epoch, err)
+	}
+	if optimistic {
+		idx.Log.Warn().Uint64("epoch", epoch).Msg("execution optimistic epoch response; rows marked provisional")
+	}
+	if len(validators) == 0 && !optimistic {
+		if err := idx.Repo.MarkEpochIndexed(ctx, epoch); err != nil {
+			return 0, fmt.Errorf("mark epoch %d indexed: %w", epoch, err)
+		}
+	}
+	return len(records), nil
+}
+
+// fetchAttestationRewardsByIndex returns rewards keyed by validator index, whether they were available,
+// and whether the response was execution optimistic.
+func fetchAttestationRewardsByIndex(ctx context.Context, client *beacon.Client, epoch uint64, log zerolog.Logger) (map[uint64]beacon.AttestationReward, bool, bool, error) {
 	resp, err := client.GetAttestationRewards(ctx, epoch, nil)
 	if err != nil {
 		if rewardsStateNotYetAvailable(err) {
 			log.Warn().Err(err).Uint64("epoch", epoch).Msg("attestation rewards not available yet")
-			return nil, false, nil
+			return nil, false, false, nil
 		}
-		return nil, false, fmt.Errorf("fetch attestation rewards epoch %d: %w", epoch, err)
+		return nil, false, false, fmt.Errorf("fetch attestation rewards epoch %d: %w", epoch, err)
+	}
+	if idealRewardsOnly(resp) {
+		// Seen right at finalization; treat as not available so the epoch stays unindexed and is retried.
+		log.Warn().
+			Uint64("epoch", epoch).
+			Int("ideal_rewards", len(resp.Data.IdealRewards)).
+			Msg("attestation rewards returned ideal_rewards only; retrying later")
+		return nil, false, false, nil
 	}
 
-	out := make(map[uint64]beacon.AttestationReward, len(resp.TotalRewards))
-	for _, r := range resp.TotalRewards {
+	out := make(map[uint64]beacon.AttestationReward, len(resp.Data.TotalRewards))
+	for _, r := range resp.Data.TotalRewards {
 		out[r.ValidatorIndex.Uint64()] = r
 	}
-	return out, true, nil
+	return out, true, resp.ExecutionOptimistic, nil
+}
+
+// idealRewardsOnly reports a rewards response with ideal_rewards but no total_rewards, which
+// would otherwise persist no rewards while looking like a successful fetch.
+func idealRewardsOnly(resp *beacon.AttestationRewardsResponse) bool {
+	return len(resp.Data.TotalRewards) == 0 && len(resp.Data.IdealRewards) > 0
 }
 
-func mergeValidatorEpochRecords(validators []beacon.Validator, epoch, slot uint64, rewards map[uint64]beacon.AttestationReward) []*storage.ValidatorEpochRecord {
+func mergeValidatorEpochRecords(validators []beacon.Validator, epoch, slot uint64, slotTime *time.Time, rewards map[uint64]beacon.AttestationReward, optimistic bool) []*storage.ValidatorEpochRecord {
 	now := time.Now().UTC()
 	records := make([]*storage.ValidatorEpochRecord, 0, len(validators))
 	for i := range validators {
 		v := validators[i]
 		idx := v.Index.Uint64()
 		rec := &storage.ValidatorEpochRecord{
-			ValidatorIndex:   idx,
-			Epoch:            epoch,
-			EpochStartSlot:   slot,
-			Status:           v.Status,
-			Balance:          v.Balance.Uint64(),
-			EffectiveBalance: v.Validator.EffectiveBalance.Uint64(),
-			IndexedAt:        now,
+			ValidatorIndex:      idx,
+			Epoch:               epoch,
+			EpochStartSlot:      slot,
+			Status:              v.Status,
+			Balance:             v.Balance.Uint64(),
+			EffectiveBalance:    v.Validator.EffectiveBalance.Uint64(),
+			ActivationEpoch:     beacon.OptionalEpoch(v.Validator.ActivationEpoch),
+			ExitEpoch:           beacon.OptionalEpoch(v.Validator.ExitEpoch),
+			WithdrawableEpoch:   beacon.OptionalEpoch(v.Validator.WithdrawableEpoch),
+			ExecutionOptimistic: optimistic,
+			SlotTime:            slotTime,
+			IndexedAt:           now,
 		}
 		if r, ok := rewards[idx]; ok {
 			head := r.Head.Int64()
@@ -119,15 +243,11 @@ func mergeValidatorEpochRecords(validators []beacon.Validator, epoch, slot uint6
 	return records
 }
 
-func saveValidatorEpochRecordsBatched(ctx context.Context, repo storage.Repository, records []*storage.ValidatorEpochRecord) error {
-	for i := 0; i < len(records); i += validatorEpochRecordBatchSize {
-		end := i + validatorEpochRecordBatchSize
-		if end > len(records) {
-			end = len(records)
-		}
-		if err := repo.SaveValidatorEpochRecords(ctx, records[i:end]); err != nil {
-			return err
-		}
+// slotTime is the chain time of slot, or nil when network or its genesis time is not set.
+func slotTime(network *config.BlockchainNetwork, slot uint64) *time.Time {
+	t := network.SlotTime(slot)
+	if t.IsZero() {
+		return nil
 	}
-	return nil
+	return &t
 }
@@ -0,0 +1,122 @@
+package indexing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestMergeValidatorEpochRecords(t *testing.T) {
+	t.Parallel()
+
+	vals := make([]beacon.Validator, 2)
+	vals[0].Index, vals[0].Balance, vals[0].Status = 7, 32_000_000_000, "active_ongoing"
+	vals[1].Index, vals[1].Balance, vals[1].Status = 9, 31_000_000_000, "active_ongoing"
+	rewards := map[uint64]beacon.AttestationReward{
+		7: {ValidatorIndex: 7, Head: 10, Source: 20, Target: -5},
+	}
+
+	t.Run("merges rewards", func(t *testing.T) {
+		got := mergeValidatorEpochRecords(vals, 3, 96, nil, rewards, false)
+		require.Len(t, got, 2)
+		require.Equal(t, uint64(7), got[0].ValidatorIndex)
+		require.Equal(t, uint64(96), got[0].EpochStartSlot)
+		require.NotNil(t, got[0].TotalReward)
+		require.Equal(t, int64(25), *got[0].TotalReward)
+		require.Nil(t, got[1].TotalReward)
+		require.False(t, got[0].ExecutionOptimistic)
+	})
+
+	t.Run("sparse unsorted indices", func(t *testing.T) {
+		sparse := make([]beacon.Validator, 3)
+		sparse[0].Index = 90000
+		sparse[1].Index = 100
+		sparse[2].Index = 5000
+		byIndex := map[uint64]beacon.AttestationReward{
+			5000:  {ValidatorIndex: 5000, Head: 1, Source: 2, Target: 3},
+			90000: {ValidatorIndex: 90000, Head: 4, Source: 5, Target: 6},
+		}
+		got := mergeValidatorEpochRecords(sparse, 3, 96, nil, byIndex, false)
+		require.Len(t, got, 3)
+		require.Equal(t, uint64(90000), got[0].ValidatorIndex)
+		require.Equal(t, int64(15), *got[0].TotalReward)
+		require.Equal(t, uint64(100), got[1].ValidatorIndex)
+		require.Nil(t, got[1].TotalReward)
+		require.Equal(t, uint64(5000), got[2].ValidatorIndex)
+		require.Equal(t, int64(6), *got[2].TotalReward)
+	})
+
+	t.Run("far-future epochs stored as unset", func(t *testing.T) {
+		pending := make([]beacon.Validator, 1)
+		pending[0].Index = 11
+		pending[0].Validator.ActivationEpoch = 4096
+		pending[0].Validator.ExitEpoch = beacon.Uint64Str(beacon.FarFutureEpoch)
+		pending[0].Validator.WithdrawableEpoch = beacon.Uint64Str(beacon.FarFutureEpoch)
+		got := mergeValidatorEpochRecords(pending, 3, 96, nil, nil, false)
+		require.Equal(t, uint64(4096), *got[0].ActivationEpoch)
+		require.Nil(t, got[0].ExitEpoch)
+		require.Nil(t, got[0].WithdrawableEpoch)
+	})
+
+	t.Run("marks optimistic rows", func(t *testing.T) {
+		got := mergeValidatorEpochRecords(vals, 3, 96, nil, rewards, true)
+		for _, rec := range got {
+			require.True(t, rec.ExecutionOptimistic)
+		}
+	})
+}
+
+func TestFetchAttestationRewardsByIndex_idealOnly(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"execution_optimistic":false,"finalized":true,"data":{` +
+			`"ideal_rewards":[{"effective_balance":"32000000000","head":"10","target":"20","source":"15"}],` +
+			`"total_rewards":[]}}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+	t.Cleanup(func() { client.Close() })
+
+	rewards, ok, optimistic, err := fetchAttestationRewardsByIndex(context.Background(), client, 5, zerolog.Nop())
+	require.NoError(t, err)
+	require.False(t, ok, "ideal-only response must not count as available")
+	require.False(t, optimistic)
+	require.Nil(t, rewards)
+}
+
+func TestSummarizeRewards(t *testing.T) {
+	t.Parallel()
+
+	reward := func(v uint64, total int64) *storage.ValidatorEpochRecord {
+		return &storage.ValidatorEpochRecord{ValidatorIndex: v, Epoch: 12, TotalReward: &total}
+	}
+	records := []*storage.ValidatorEpochRecord{
+		reward(1, 900), reward(2, -40), reward(3, 1100), reward(4, 1000),
+		reward(5, 950), reward(6, 0), reward(7, 1000), reward(8, 20),
+		{ValidatorIndex: 9, Epoch: 12}, // no reward yet
+		reward(50, -999),               // not watched
+	}
+
+	d := SummarizeRewards(12, records, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	require.NotNil(t, d)
+	require.Equal(t, 8, d.Validators)
+	require.Equal(t, int64(-40), d.MinGwei)
+	require.Equal(t, int64(1100), d.MaxGwei)
+	require.InDelta(t, 616.25, d.MeanGwei, 1e-9)
+	require.Equal(t, int64(925), d.MedianGwei)
+	require.Equal(t, []uint64{2, 6, 8, 1, 5}, d.Worst)
+
+	require.Nil(t, SummarizeRewards(12, records, []uint64{9, 10}))
+}
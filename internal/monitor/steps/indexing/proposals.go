@@ -0,0 +1,67 @@
+package indexing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// FetchProposerDuties returns epoch's proposal duties of the watched validators, with status scheduled.
+func FetchProposerDuties(ctx context.Context, client *beacon.Client, epoch uint64, validators []uint64) ([]*storage.ProposerDuty, error) {
+	resp, err := client.GetProposerDuties(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	watched := make(map[uint64]bool, len(validators))
+	for _, v := range validators {
+		watched[v] = true
+	}
+	now := time.Now().UTC()
+	var out []*storage.ProposerDuty
+	for _, d := range resp.Data {
+		if !watched[d.ValidatorIndex.Uint64()] {
+			continue
+		}
+		out = append(out, &storage.ProposerDuty{
+			ValidatorIndex: d.ValidatorIndex.Uint64(),
+			Epoch:          epoch,
+			Slot:           d.Slot.Uint64(),
+			DependentRoot:  resp.DependentRoot,
+			Status:         storage.ProposalScheduled,
+			IndexedAt:      now,
+		})
+	}
+	return out, nil
+}
+
+// CheckProposal sets d's outcome from the canonical block at its slot: proposed with the block root and
+// reward total when the duty's validator proposed it, reassigned when another validator did (a reorg
+// reshuffled the proposers), missed when the slot is empty.
+func CheckProposal(ctx context.Context, client *beacon.Client, d *storage.ProposerDuty) error {
+	blockID := strconv.FormatUint(d.Slot, 10)
+	now := time.Now().UTC()
+	header, err := client.GetBlockHeader(ctx, blockID)
+	if err != nil {
+		if !beacon.IsNotFound(err) {
+			return fmt.Errorf("proposal slot %d: %w", d.Slot, err)
+		}
+		d.Status, d.BlockRoot, d.RewardGwei, d.CheckedAt = storage.ProposalMissed, nil, nil, &now
+		return nil
+	}
+	if proposer := header.Data.Header.Message.ProposerIndex.Uint64(); proposer != d.ValidatorIndex {
+		root := header.Data.Root
+		d.Status, d.BlockRoot, d.RewardGwei, d.CheckedAt = storage.ProposalReassigned, &root, nil, &now
+		return nil
+	}
+	rewards, err := client.GetBlockRewards(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("proposal slot %d: %w", d.Slot, err)
+	}
+	root, reward := header.Data.Root, rewards.Data.Total.Uint64()
+	d.Status, d.BlockRoot, d.RewardGwei, d.CheckedAt = storage.ProposalProposed, &root, &reward, &now
+	return nil
+}
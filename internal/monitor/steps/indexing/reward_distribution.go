@@ -0,0 +1,87 @@
+package indexing
+
+import (
+	"sort"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// worstPerformers is how many of the lowest-earning watched validators a RewardDistribution names.
+const worstPerformers = 5
+
+// RewardDistribution summarizes one epoch's total attestation rewards across the watched validators.
+type RewardDistribution struct {
+	Epoch      uint64
+	Validators int
+	MinGwei    int64
+	MaxGwei    int64
+	MeanGwei   float64
+	MedianGwei int64
+	// Worst are the validators with the lowest rewards, lowest first (ties by index).
+	Worst []uint64
+}
+
+// SummarizeRewards returns the reward distribution of the watched validators among records, or nil when
+// none of them has a reward in the epoch.
+func SummarizeRewards(epoch uint64, records []*storage.ValidatorEpochRecord, watched []uint64) *RewardDistribution {
+	want := make(map[uint64]bool, len(watched))
+	for _, v := range watched {
+		want[v] = true
+	}
+	var rows []*storage.ValidatorEpochRecord
+	for _, r := range records {
+		if r.TotalReward != nil && want[r.ValidatorIndex] {
+			rows = append(rows, r)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if *rows[i].TotalReward != *rows[j].TotalReward {
+			return *rows[i].TotalReward < *rows[j].TotalReward
+		}
+		return rows[i].ValidatorIndex < rows[j].ValidatorIndex
+	})
+
+	d := &RewardDistribution{
+		Epoch:      epoch,
+		Validators: len(rows),
+		MinGwei:    *rows[0].TotalReward,
+		MaxGwei:    *rows[len(rows)-1].TotalReward,
+	}
+	var sum int64
+	for _, r := range rows {
+		sum += *r.TotalReward
+	}
+	d.MeanGwei = float64(sum) / float64(len(rows))
+	mid := len(rows) / 2
+	d.MedianGwei = *rows[mid].TotalReward
+	if len(rows)%2 == 0 {
+		d.MedianGwei = (*rows[mid-1].TotalReward + *rows[mid].TotalReward) / 2
+	}
+	for _, r := range rows[:min(worstPerformers, len(rows))] {
+		d.Worst = append(d.Worst, r.ValidatorIndex)
+	}
+	return d
+}
+
+// report logs the distribution and exports it as pauli_watched_epoch_reward_gwei.
+func (d *RewardDistribution) report(log zerolog.Logger) {
+	log.Info().
+		Uint64("epoch", d.Epoch).
+		Int("validators", d.Validators).
+		Int64("min_gwei", d.MinGwei).
+		Int64("max_gwei", d.MaxGwei).
+		Float64("mean_gwei", d.MeanGwei).
+		Int64("median_gwei", d.MedianGwei).
+		Uints64("worst_validators", d.Worst).
+		Msg("epoch reward distribution of watched validators")
+	metrics.WatchedEpochRewardEpoch.Set(float64(d.Epoch))
+	metrics.WatchedEpochReward.WithLabelValues("min").Set(float64(d.MinGwei))
+	metrics.WatchedEpochReward.WithLabelValues("max").Set(float64(d.MaxGwei))
+	metrics.WatchedEpochReward.WithLabelValues("mean").Set(d.MeanGwei)
+	metrics.WatchedEpochReward.WithLabelValues("median").Set(float64(d.MedianGwei))
+}
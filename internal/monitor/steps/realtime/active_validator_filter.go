@@ -0,0 +1,105 @@
+package realtime
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ActiveValidatorFilter (sync) drops watched validators in a terminal status (exited_slashed,
+// withdrawal_done) from Env.ValidatorIndices so later steps stop polling them. Statuses are refreshed
+// from the head state once per epoch; inactive validators are only re-queried every RecheckEpochs
+// in case they re-enter (a new deposit on a reused index). State persists across chain passes.
+type ActiveValidatorFilter struct {
+	Client        *beacon.Client
+	Log           zerolog.Logger
+	RecheckEpochs uint64
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+
+	refreshed bool
+	lastEpoch uint64
+	// inactive maps validator index to the epoch its terminal status was last confirmed.
+	inactive map[uint64]uint64
+}
+
+var _ Step = (*ActiveValidatorFilter)(nil)
+
+func (*ActiveValidatorFilter) Async() bool { return false }
+
+func (s *ActiveValidatorFilter) Run(e *steps.Env) (bool, error) {
+	if s.inactive == nil {
+		s.inactive = make(map[uint64]uint64)
+	}
+
+	epoch := e.HeadSlot / config.SlotsPerEpoch()
+	if !s.refreshed || epoch != s.lastEpoch {
+		if err := s.refresh(e.Ctx, e.HeadSlot, epoch, e.ValidatorIndices); err != nil {
+			// Keep filtering with the previous view; the next poll retries.
+			s.Log.Warn().Err(err).Uint64("epoch", epoch).Msg("realtime: validator status refresh failed")
+		} else {
+			s.refreshed = true
+			s.lastEpoch = epoch
+		}
+	}
+
+	active := e.ValidatorIndices[:0]
+	for _, v := range e.ValidatorIndices {
+		if _, ok := s.inactive[v]; !ok {
+			active = append(active, v)
+		}
+	}
+	e.ValidatorIndices = active
+	return false, nil
+}
+
+func (*ActiveValidatorFilter) RunAsync(context.Context, *steps.Env) error { return nil }
+
+// refresh queries active validators and inactive ones due for a recheck, moving them between sets.
+func (s *ActiveValidatorFilter) refresh(ctx context.Context, headSlot, epoch uint64, watched []uint64) error {
+	var query []uint64
+	for _, v := range watched {
+		checked, inactive := s.inactive[v]
+		if !inactive || epoch >= checked+s.RecheckEpochs {
+			query = append(query, v)
+		}
+	}
+	if len(query) == 0 {
+		return nil
+	}
+	vals, err := s.Client.GetValidatorsAtSlot(ctx, headSlot, query)
+	if err != nil {
+		return err
+	}
+	for i := range vals {
+		idx := vals[i].Index.Uint64()
+		status := vals[i].Status
+		_, wasInactive := s.inactive[idx]
+		switch {
+		case isTerminalStatus(status):
+			if !wasInactive {
+				s.Results.Event(s.Log, config.ResultValidatorInactive, zerolog.InfoLevel).
+					Uint64("validator_index", idx).
+					Str("status", status).
+					Uint64("recheck_epochs", s.RecheckEpochs).
+					Msg("realtime: validator moved to inactive set")
+			}
+			s.inactive[idx] = epoch
+		case wasInactive:
+			delete(s.inactive, idx)
+			s.Results.Event(s.Log, config.ResultValidatorActive, zerolog.InfoLevel).
+				Uint64("validator_index", idx).
+				Str("status", status).
+				Msg("realtime: validator re-entered active polling set")
+		}
+	}
+	return nil
+}
+
+func isTerminalStatus(status string) bool {
+	return status == storage.StatusExitedSlashed || status == storage.StatusWithdrawalDone
+}
@@ -0,0 +1,68 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+func TestActiveValidatorFilter(t *testing.T) {
+	var calls atomic.Int32
+	var lastIDs atomic.Value
+	status2 := atomic.Value{}
+	status2.Store("withdrawal_done")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		ids := r.URL.Query().Get("id")
+		lastIDs.Store(ids)
+		var rows []string
+		for _, id := range strings.Split(ids, ",") {
+			status := "active_ongoing"
+			if id == "2" {
+				status = status2.Load().(string)
+			}
+			rows = append(rows, fmt.Sprintf(`{"index":"%s","balance":"1","status":"%s","validator":{}}`, id, status))
+		}
+		_, _ = fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(rows, ","))
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	f := &ActiveValidatorFilter{Client: client, Log: zerolog.Nop(), RecheckEpochs: 10}
+	run := func(head uint64) []uint64 {
+		e := &steps.Env{Ctx: context.Background(), HeadSlot: head, ValidatorIndices: []uint64{1, 2, 3}}
+		_, err := f.Run(e)
+		require.NoError(t, err)
+		return e.ValidatorIndices
+	}
+
+	require.Equal(t, []uint64{1, 3}, run(32*5))
+	require.Equal(t, int32(1), calls.Load())
+
+	// Same epoch: no refresh.
+	require.Equal(t, []uint64{1, 3}, run(32*5+1))
+	require.Equal(t, int32(1), calls.Load())
+
+	// Next epoch: only active validators are queried.
+	require.Equal(t, []uint64{1, 3}, run(32*6))
+	require.Equal(t, "1,3", lastIDs.Load())
+
+	// Recheck due: validator 2 is queried again and re-enters when no longer terminal.
+	status2.Store("active_ongoing")
+	require.Equal(t, []uint64{1, 2, 3}, run(32*15))
+	require.Equal(t, "1,2,3", lastIDs.Load())
+}
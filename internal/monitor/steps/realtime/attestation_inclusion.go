@@ -0,0 +1,113 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// AttestationInclusion (async): once head is InclusionDelaySlots past a scheduled duty slot, checks
+// whether the watched validator's attestation was included in the blocks of that window, instead of
+// waiting for finalized epoch rewards. Results are stored as provisional attestation_liveness rows
+// (late inclusions are not seen) and reconciled when the epoch's rewards are indexed. Included duties
+// are also checked against the slot's committees; disagreements are stored as duty_mismatches.
+type AttestationInclusion struct {
+	Client              *beacon.Client
+	Repo                storage.Repository
+	Log                 zerolog.Logger
+	LastProcessedSlot   *uint64
+	Schedule            *DutySchedule
+	InclusionDelaySlots uint64
+	// MaxInclusionDelaySlots flags included attestations that landed later than this many slots after
+	// the duty slot (0 = never).
+	MaxInclusionDelaySlots uint64
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+}
+
+var _ Step = (*AttestationInclusion)(nil)
+
+func (*AttestationInclusion) Async() bool { return true }
+
+func (s *AttestationInclusion) Run(e *steps.Env) (bool, error) {
+	if s.LastProcessedSlot != nil && e.HeadSlot == *s.LastProcessedSlot {
+		return false, nil
+	}
+	if e.HeadSlot < s.InclusionDelaySlots {
+		return false, nil
+	}
+	return s.Schedule.HasDue(e.HeadSlot - s.InclusionDelaySlots), nil
+}
+
+func (s *AttestationInclusion) RunAsync(ctx context.Context, e *steps.Env) error {
+	due := s.Schedule.Due(e.HeadSlot - s.InclusionDelaySlots)
+	if len(due) == 0 {
+		return nil
+	}
+	results, err := indexing.CheckAttestationInclusion(ctx, &indexing.InclusionChecker{
+		Client: s.Client,
+		Log:    s.Log,
+	}, due, s.InclusionDelaySlots)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if err := s.Repo.SaveAttestationLiveness(ctx, indexing.LivenessRows(results, now)); err != nil {
+		return err
+	}
+	mismatches, err := indexing.VerifyIncludedDuties(ctx, s.Client, results, now)
+	if err != nil {
+		// Verification is an audit; a failed committee lookup must not drop the liveness results.
+		s.Log.Warn().Err(err).Msg("realtime: duty verification failed")
+	}
+	if err := s.Repo.SaveDutyMismatches(ctx, mismatches); err != nil {
+		return err
+	}
+	for _, m := range mismatches {
+		ev := s.Results.Event(s.Log, config.ResultDutyMismatch, zerolog.WarnLevel).
+			Uint64("validator_index", m.ValidatorIndex).
+			Uint64("duty_slot", m.Slot).
+			Str("reason", m.Reason).
+			Uint64("expected_committee_index", m.ExpectedCommitteeIndex).
+			Uint64("expected_position", m.ExpectedPosition)
+		if m.ActualCommitteeIndex != nil {
+			ev = ev.Uint64("actual_committee_index", *m.ActualCommitteeIndex).Uint64("actual_position", *m.ActualPosition)
+		}
+		ev.Msg("realtime: stored attester duty disagrees with slot committees")
+	}
+	for _, r := range results {
+		if r.Included && s.MaxInclusionDelaySlots > 0 && r.InclusionSlot-r.Duty.Slot > s.MaxInclusionDelaySlots {
+			metrics.SlowAttestationInclusions.Inc()
+			s.Results.Event(s.Log, config.ResultAttestationLate, zerolog.WarnLevel).
+				Uint64("validator_index", r.Duty.ValidatorIndex).
+				Uint64("duty_slot", r.Duty.Slot).
+				Uint64("inclusion_slot", r.InclusionSlot).
+				Uint64("inclusion_distance", r.InclusionSlot-r.Duty.Slot).
+				Uint64("max_inclusion_delay_slots", s.MaxInclusionDelaySlots).
+				Msg("realtime: attestation included late")
+			continue
+		}
+		if r.Included {
+			s.Results.Event(s.Log, config.ResultAttestationIncluded, zerolog.DebugLevel).
+				Uint64("validator_index", r.Duty.ValidatorIndex).
+				Uint64("duty_slot", r.Duty.Slot).
+				Uint64("inclusion_slot", r.InclusionSlot).
+				Msg("realtime: attestation included")
+			continue
+		}
+		s.Results.Event(s.Log, config.ResultAttestationMissed, zerolog.WarnLevel).
+			Uint64("validator_index", r.Duty.ValidatorIndex).
+			Uint64("duty_slot", r.Duty.Slot).
+			Uint64("committee_index", r.Duty.CommitteeIndex).
+			Uint64("inclusion_delay_slots", s.InclusionDelaySlots).
+			Msg("realtime: attestation not included within inclusion window")
+	}
+	return nil
+}
@@ -11,13 +11,39 @@ import (
 	"github.com/tharun/pauli/internal/storage"
 )
 
+// maxFinalityCatchUpEpochs caps how many epochs a single finalization jump schedules; older skipped
+// epochs are left to backfill.
+const maxFinalityCatchUpEpochs = 8
+
 // AttestationRewards (async): on a consensus epoch boundary slot, indexes network-wide
 // validator epoch records (balances + attestation rewards) for the finalized epoch.
+//
+// When finality advanced by several epochs since the previous boundary, every skipped epoch not
+// indexed yet is scheduled too. One job indexes them strictly oldest first, so ordered aggregate
+// updates see finalized epochs in chronological order. With Reconcile enabled, every IntervalEpochs
+// storage is also searched for older unindexed epochs within LookbackEpochs, since in-memory
+// bookkeeping alone misses epochs finalized while pauli was down. Those gaps are indexed first, but one
+// that keeps failing (e.g. a pruned state) is skipped so it never holds back the newly finalized epochs.
 type AttestationRewards struct {
 	Client            *beacon.Client
 	Repo              storage.Repository
+	Network           *config.BlockchainNetwork
 	Log               zerolog.Logger
 	LastProcessedSlot *uint64
+	// LastFinalizedEpoch is the finalized epoch seen at the previous boundary; ^uint64(0) (or nil)
+	// before the first, which then schedules only the current finalized epoch.
+	LastFinalizedEpoch *uint64
+	// Reconcile configures the search for older gaps (rewards_reconcile).
+	Reconcile config.RewardsReconcileConf
+	// LastReconcileEpoch is the head epoch of the last gap search; ^uint64(0) before the first.
+	LastReconcileEpoch *uint64
+	// IgnoreEpochBoundary schedules the finalized epoch on any head slot (one-shot mode).
+	IgnoreEpochBoundary bool
+	// OnFinality, when set, receives the head and finalized epochs on every fetch.
+	OnFinality func(ctx context.Context, headEpoch, finalizedEpoch uint64)
+	// FinalityOnly fetches the finalized epoch for OnFinality but never schedules the epoch index
+	// (jobs.disable_rewards).
+	FinalityOnly bool
 }
 
 var _ Step = (*AttestationRewards)(nil)
@@ -25,14 +51,14 @@ var _ Step = (*AttestationRewards)(nil)
 func (AttestationRewards) Async() bool { return true }
 
 func (s *AttestationRewards) Run(e *steps.Env) (bool, error) {
+	e.RewardsEpochs = nil
+	e.RewardsGapEpochs = nil
 	if s.LastProcessedSlot != nil && e.HeadSlot == *s.LastProcessedSlot {
-		e.RewardsEpoch = nil
 		return false, nil
 	}
 
 	headEpoch := e.HeadSlot / config.SlotsPerEpoch()
-	if !isConsensusEpochBoundarySlot(e.HeadSlot) || headEpoch == 0 {
-		e.RewardsEpoch = nil
+	if (!s.IgnoreEpochBoundary && !isConsensusEpochBoundarySlot(e.HeadSlot)) || headEpoch == 0 {
 		return false, nil
 	}
 
@@ -40,34 +66,120 @@ func (s *AttestationRewards) Run(e *steps.Env) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-
-	rewardsEpoch := finalized
-	indexed, err := s.Repo.IsEpochIndexed(e.Ctx, rewardsEpoch)
-	if err != nil {
-		return false, err
+	if s.OnFinality != nil {
+		s.OnFinality(e.Ctx, headEpoch, finalized)
 	}
-	if indexed {
-		e.RewardsEpoch = nil
+	if s.FinalityOnly {
 		return false, nil
 	}
 
-	e.RewardsEpoch = new(uint64)
-	*e.RewardsEpoch = rewardsEpoch
+	from := finalized
+	if s.LastFinalizedEpoch != nil && *s.LastFinalizedEpoch < finalized {
+		from = *s.LastFinalizedEpoch + 1
+		if finalized-from >= maxFinalityCatchUpEpochs {
+			from = finalized - maxFinalityCatchUpEpochs + 1
+		}
+	}
+	var epochs []uint64
+	for epoch := from; epoch <= finalized; epoch++ {
+		indexed, err := s.Repo.IsEpochIndexed(e.Ctx, epoch)
+		if err != nil {
+			return false, err
+		}
+		if !indexed {
+			epochs = append(epochs, epoch)
+		}
+	}
+	if s.reconcileDue(headEpoch) {
+		gaps, err := s.findGaps(e.Ctx, finalized, from)
+		if err != nil {
+			return false, err
+		}
+		*s.LastReconcileEpoch = headEpoch
+		if len(gaps) > 0 {
+			s.Log.Warn().
+				Uint64("finalized_epoch", finalized).
+				Uints64("epochs", gaps).
+				Msg("realtime: finalized epochs missing from storage; re-indexing")
+			e.RewardsGapEpochs = gaps
+		}
+	}
+	if s.LastFinalizedEpoch != nil {
+		*s.LastFinalizedEpoch = finalized
+	}
+	if len(epochs) == 0 && len(e.RewardsGapEpochs) == 0 {
+		return false, nil
+	}
+	e.RewardsEpochs = epochs
 
 	s.Log.Debug().
 		Uint64("head_slot", e.HeadSlot).
 		Uint64("finalized_epoch", finalized).
-		Uint64("rewards_epoch", rewardsEpoch).
+		Uints64("rewards_epochs", epochs).
+		Uints64("rewards_gap_epochs", e.RewardsGapEpochs).
 		Msg("realtime: epoch boundary — scheduling network-wide epoch index")
 
 	return true, nil
 }
 
+func (s *AttestationRewards) reconcileDue(headEpoch uint64) bool {
+	if !s.Reconcile.Enabled || s.LastReconcileEpoch == nil {
+		return false
+	}
+	last := *s.LastReconcileEpoch
+	return last == ^uint64(0) || headEpoch >= last+s.Reconcile.IntervalEpochs
+}
+
+// findGaps returns up to maxFinalityCatchUpEpochs unindexed epochs in the lookback window below before,
+// oldest first.
+func (s *AttestationRewards) findGaps(ctx context.Context, finalized, before uint64) ([]uint64, error) {
+	if before == 0 {
+		return nil, nil
+	}
+	var lo uint64
+	if finalized > s.Reconcile.LookbackEpochs {
+		lo = finalized - s.Reconcile.LookbackEpochs
+	}
+	var gaps []uint64
+	for len(gaps) < maxFinalityCatchUpEpochs && lo < before {
+		epoch, ok, err := s.Repo.FirstUnindexedEpoch(ctx, lo, before-1)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		gaps = append(gaps, epoch)
+		lo = epoch + 1
+	}
+	return gaps, nil
+}
+
+// RunAsync indexes the reconcile gaps, then the newly finalized epochs, oldest first. A failing gap is
+// logged and skipped: it stays unindexed and is retried at the next reconcile. Among the finalized
+// epochs it stops at the first failure, so a later epoch is never stored ahead of an earlier one; epochs
+// left unindexed are picked up by backfill.
 func (s *AttestationRewards) RunAsync(ctx context.Context, e *steps.Env) error {
-	epoch := *e.RewardsEpoch
-	return indexing.IndexEpochAtBoundary(ctx, &indexing.EpochIndexer{
-		Client: s.Client,
-		Repo:   s.Repo,
-		Log:    s.Log,
-	}, epoch)
+	idx := &indexing.EpochIndexer{
+		Client:  s.Client,
+		Repo:    s.Repo,
+		Network: s.Network,
+		Log:     s.Log,
+		Watched: e.WatchedValidators,
+	}
+	for _, epoch := range e.RewardsGapEpochs {
+		if err := indexing.IndexEpochAtBoundary(ctx, idx, epoch); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			beacon.LogErrorContext(s.Log.Warn().Err(err).Uint64("epoch", epoch), err).
+				Msg("realtime: reconcile gap epoch failed; skipping until the next reconcile")
+		}
+	}
+	for _, epoch := range e.RewardsEpochs {
+		if err := indexing.IndexEpochAtBoundary(ctx, idx, epoch); err != nil {
+			return err
+		}
+	}
+	return nil
 }
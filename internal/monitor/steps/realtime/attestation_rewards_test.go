@@ -0,0 +1,137 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type indexedEpochsRepo struct {
+	*noop.Repository
+	indexed map[uint64]bool
+}
+
+func (r *indexedEpochsRepo) IsEpochIndexed(_ context.Context, epoch uint64) (bool, error) {
+	return r.indexed[epoch], nil
+}
+
+func (r *indexedEpochsRepo) FirstUnindexedEpoch(_ context.Context, from, to uint64) (uint64, bool, error) {
+	for epoch := from; epoch <= to; epoch++ {
+		if !r.indexed[epoch] {
+			return epoch, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// newRewardsStep returns an AttestationRewards step against a node whose finalized epoch is set by the
+// returned boundary func, which runs the step at the first slot of epoch and returns the scheduled epochs
+// in indexing order (reconcile gaps first).
+func newRewardsStep(t *testing.T, repo *indexedEpochsRepo) (*AttestationRewards, func(epoch, finalized uint64) []uint64) {
+	var finalized atomic.Uint64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"finalized":{"epoch":"%d","root":"0x01"}}}`, finalized.Load())
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, Cache: config.BeaconCacheConf{Disabled: true}},
+	})
+	lastFinalized, lastReconcile := ^uint64(0), ^uint64(0)
+	step := &AttestationRewards{
+		Client:             client,
+		Repo:               repo,
+		Log:                zerolog.Nop(),
+		LastFinalizedEpoch: &lastFinalized,
+		LastReconcileEpoch: &lastReconcile,
+	}
+	return step, func(epoch, fin uint64) []uint64 {
+		finalized.Store(fin)
+		e := &steps.Env{Ctx: context.Background(), HeadSlot: epoch * config.SlotsPerEpoch()}
+		ok, err := step.Run(e)
+		require.NoError(t, err)
+		scheduled := append(append([]uint64(nil), e.RewardsGapEpochs...), e.RewardsEpochs...)
+		require.Equal(t, len(scheduled) > 0, ok)
+		if len(scheduled) == 0 {
+			return nil
+		}
+		return scheduled
+	}
+}
+
+func TestAttestationRewards_finalizationJumpOrder(t *testing.T) {
+	repo := &indexedEpochsRepo{Repository: noop.NewRepository(), indexed: map[uint64]bool{}}
+	_, boundary := newRewardsStep(t, repo)
+
+	require.Equal(t, []uint64{10}, boundary(12, 10), "first boundary schedules only the finalized epoch")
+	repo.indexed[10] = true
+	require.Nil(t, boundary(13, 10))
+
+	// Finality jumps 10 -> 14 with 12 already indexed: skipped epochs oldest first.
+	repo.indexed[12] = true
+	require.Equal(t, []uint64{11, 13, 14}, boundary(16, 14))
+
+	// A jump past the catch-up cap keeps only the newest epochs, still in order.
+	require.Equal(t, []uint64{23, 24, 25, 26, 27, 28, 29, 30}, boundary(32, 30))
+}
+
+func TestAttestationRewards_reconcileGaps(t *testing.T) {
+	repo := &indexedEpochsRepo{Repository: noop.NewRepository(), indexed: map[uint64]bool{}}
+	for epoch := uint64(80); epoch < 100; epoch++ {
+		repo.indexed[epoch] = epoch != 85 && epoch != 91
+	}
+	step, boundary := newRewardsStep(t, repo)
+	step.Reconcile = config.RewardsReconcileConf{Enabled: true, IntervalEpochs: 4, LookbackEpochs: 20}
+
+	// After a restart only the finalized epoch is known in memory; the gaps come from storage, oldest first.
+	require.Equal(t, []uint64{85, 91, 100}, boundary(102, 100))
+	repo.indexed[85], repo.indexed[91], repo.indexed[100] = true, true, true
+
+	repo.indexed[95] = false
+	require.Equal(t, []uint64{101}, boundary(103, 101), "no storage check before interval_epochs")
+	repo.indexed[101] = true
+	require.Equal(t, []uint64{95}, boundary(106, 101))
+}
+
+func TestAttestationRewards_failingGapDoesNotBlockFinalized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/eth/v1/beacon/states/%d/validators", 85*config.SlotsPerEpoch()):
+			http.Error(w, `{"code":404,"message":"state pruned"}`, http.StatusNotFound)
+		case fmt.Sprintf("/eth/v1/beacon/states/%d/validators", 100*config.SlotsPerEpoch()):
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "/eth/v1/beacon/rewards/attestations/100":
+			_, _ = w.Write([]byte(`{"data":{"ideal_rewards":[],"total_rewards":[]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1, Cache: config.BeaconCacheConf{Disabled: true}},
+	})
+	repo := noop.NewRepository()
+	step := &AttestationRewards{Client: client, Repo: repo, Log: zerolog.Nop()}
+
+	e := &steps.Env{RewardsGapEpochs: []uint64{85}, RewardsEpochs: []uint64{100}}
+	require.NoError(t, step.RunAsync(context.Background(), e))
+	indexed, err := repo.IsEpochIndexed(context.Background(), 100)
+	require.NoError(t, err)
+	require.True(t, indexed, "finalized epoch indexed despite the failing gap")
+	indexed, err = repo.IsEpochIndexed(context.Background(), 85)
+	require.NoError(t, err)
+	require.False(t, indexed)
+}
@@ -0,0 +1,227 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// AttesterDuties (async): when the head epoch or the next epoch has no duties loaded, fetches attester
+// duties for the watched validators, persists them, and adds them to Schedule for AttestationInclusion.
+// With Timers set, each duty slot is also queued to be checked at the start of slot
+// duty_slot+InclusionDelaySlots+1, when its whole inclusion window has been proposed.
+// Duties fetched an epoch ahead are fetched again once their epoch is the head epoch; a different
+// dependent_root means a reorg reshuffled them, and the stored and scheduled duties are replaced.
+// With VerifyCommittees set, fetched duties are also checked against the epoch's committees.
+// Nothing is fetched while Env.ELOffline is set.
+type AttesterDuties struct {
+	Client            *beacon.Client
+	Repo              storage.Repository
+	Network           *config.BlockchainNetwork
+	Log               zerolog.Logger
+	LastProcessedSlot *uint64
+	Schedule          *DutySchedule
+	// Committee sizing for the suspicious committee length check (0 = mainnet preset).
+	TargetCommitteeSize uint64
+	MaxCommitteeLength  uint64
+	Filter              config.DutyFilterConf
+	Timers              *InclusionTimers
+	InclusionDelaySlots uint64
+	VerifyCommittees    bool
+	// FromCommittees derives duties from the epoch's committees; the duties endpoint answer is reconciled
+	// against them during the fetch, so VerifyCommittees adds nothing.
+	FromCommittees bool
+	// Anomalies, when set, flags validators in zero or several committees of a fetched epoch.
+	Anomalies *indexing.DutyAnomalyDetector
+	// OnReorg, when set, is called after duties were replaced because a reorg moved their dependent root.
+	OnReorg func(ctx context.Context)
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+}
+
+var _ Step = (*AttesterDuties)(nil)
+
+func (*AttesterDuties) Async() bool { return true }
+
+func (s *AttesterDuties) Run(e *steps.Env) (bool, error) {
+	if s.LastProcessedSlot != nil && e.HeadSlot == *s.LastProcessedSlot {
+		return false, nil
+	}
+	if e.ELOffline {
+		// Duties from an optimistic head may be invalidated; the epoch stays unclaimed for the next pass.
+		return false, nil
+	}
+	if len(e.ValidatorIndices) == 0 {
+		return false, nil
+	}
+	epoch := e.HeadSlot / config.SlotsPerEpoch()
+	return !s.Schedule.HasEpoch(epoch) || !s.Schedule.HasEpoch(epoch+1) || s.Schedule.NeedsRecheck(epoch), nil
+}
+
+func (s *AttesterDuties) RunAsync(ctx context.Context, e *steps.Env) error {
+	headEpoch := e.HeadSlot / config.SlotsPerEpoch()
+	idx := &indexing.DutyIndexer{
+		Client:              s.Client,
+		Network:             s.Network,
+		Log:                 s.Log,
+		TargetCommitteeSize: s.TargetCommitteeSize,
+		MaxCommitteeLength:  s.MaxCommitteeLength,
+		Filter:              s.Filter,
+		Anomalies:           s.Anomalies,
+		FromCommittees:      s.FromCommittees,
+		OnMismatch: func(epoch uint64, mismatches []*storage.DutyMismatch) {
+			s.saveMismatches(ctx, epoch, mismatches)
+		},
+	}
+	defer s.saveAnomalies(ctx)
+	if prevRoot, ok := s.Schedule.ClaimRecheck(headEpoch); ok {
+		if err := s.recheck(ctx, idx, headEpoch, prevRoot, e.ValidatorIndices); err != nil {
+			return err
+		}
+	}
+	for _, epoch := range []uint64{headEpoch, headEpoch + 1} {
+		if !s.Schedule.Claim(epoch) {
+			continue
+		}
+		duties, root, err := indexing.FetchAttesterDuties(ctx, idx, epoch, e.ValidatorIndices)
+		if err == nil {
+			err = s.save(ctx, epoch, duties)
+		}
+		if err != nil {
+			s.Schedule.Release(epoch)
+			return err
+		}
+		s.Schedule.Add(epoch, duties)
+		s.Schedule.Track(epoch, root, epoch > headEpoch)
+		s.scheduleTimers(duties)
+		if s.VerifyCommittees && !s.FromCommittees {
+			if err := s.verify(ctx, epoch, duties); err != nil {
+				return err
+			}
+		}
+		s.Log.Debug().
+			Uint64("epoch", epoch).
+			Int("duties", len(duties)).
+			Msg("realtime: attester duties scheduled")
+	}
+	return nil
+}
+
+// recheck refetches epoch's duties and, when the dependent root moved since prevRoot, saves and schedules
+// the new assignment. A failed refetch leaves the recheck pending for the next poll.
+func (s *AttesterDuties) recheck(ctx context.Context, idx *indexing.DutyIndexer, epoch uint64, prevRoot string, validators []uint64) error {
+	duties, root, err := indexing.FetchAttesterDuties(ctx, idx, epoch, validators)
+	if err == nil && root != prevRoot && prevRoot != "" {
+		err = s.save(ctx, epoch, duties)
+	}
+	if err != nil {
+		s.Schedule.Track(epoch, prevRoot, true)
+		return err
+	}
+	if root == prevRoot || prevRoot == "" {
+		return nil
+	}
+	s.Schedule.ReplaceEpoch(epoch, root, duties)
+	s.scheduleTimers(duties)
+	s.Log.Warn().
+		Str("check", "duties_changed_reorg").
+		Uint64("epoch", epoch).
+		Str("previous_dependent_root", prevRoot).
+		Str("dependent_root", root).
+		Int("duties", len(duties)).
+		Msg("duties_changed_reorg: attester duties changed after a reorg; stored and scheduled duties replaced")
+	if s.OnReorg != nil {
+		s.OnReorg(ctx)
+	}
+	return nil
+}
+
+func (s *AttesterDuties) save(ctx context.Context, epoch uint64, duties []*storage.AttestationDuty) error {
+	if err := s.Repo.SaveAttestationDuties(ctx, duties); err != nil {
+		return fmt.Errorf("save attester duties epoch %d: %w", epoch, err)
+	}
+	return nil
+}
+
+// verify stores and logs duties that disagree with the epoch's committees. A failed committees lookup
+// is only logged: the duties are already saved and scheduled.
+func (s *AttesterDuties) verify(ctx context.Context, epoch uint64, duties []*storage.AttestationDuty) error {
+	mismatches, err := indexing.VerifyDuties(ctx, s.Client, epoch, duties, time.Now().UTC())
+	if err != nil {
+		s.Log.Warn().Err(err).Uint64("epoch", epoch).Msg("realtime: duty committee verification failed")
+		return nil
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	if err := s.Repo.SaveDutyMismatches(ctx, mismatches); err != nil {
+		return err
+	}
+	s.Log.Warn().
+		Str("check", "duty_committee_mismatch").
+		Uint64("epoch", epoch).
+		Int("mismatches", len(mismatches)).
+		Int("duties", len(duties)).
+		Msg("duty_committee_mismatch: attester duties disagree with the epoch's committees")
+	return nil
+}
+
+// saveMismatches stores and logs the disagreements between the duties endpoint and the committees found
+// while deriving duties from committees. A failed save is logged: the duties themselves are unaffected.
+func (s *AttesterDuties) saveMismatches(ctx context.Context, epoch uint64, mismatches []*storage.DutyMismatch) {
+	if err := s.Repo.SaveDutyMismatches(ctx, mismatches); err != nil {
+		s.Log.Error().Err(err).Uint64("epoch", epoch).Msg("realtime: failed to save duty mismatches")
+		return
+	}
+	s.Log.Warn().
+		Str("check", "duty_source_mismatch").
+		Uint64("epoch", epoch).
+		Int("mismatches", len(mismatches)).
+		Msg("duty_source_mismatch: attester duties endpoint disagrees with the epoch's committees")
+}
+
+// saveAnomalies stores, counts and logs the duty anomalies found by the fetches of this job. A failed
+// save is logged only: anomalies are an audit and must not fail the duties job.
+func (s *AttesterDuties) saveAnomalies(ctx context.Context) {
+	if s.Anomalies == nil {
+		return
+	}
+	anomalies := s.Anomalies.Drain()
+	if len(anomalies) == 0 {
+		return
+	}
+	for _, a := range anomalies {
+		metrics.DutyAnomalies.WithLabelValues(a.Reason).Inc()
+		s.Results.Event(s.Log, config.ResultDutyAnomaly, zerolog.WarnLevel).
+			Str("check", "duty_committee_anomaly").
+			Str("reason", a.Reason).
+			Uint64("validator_index", a.ValidatorIndex).
+			Uint64("epoch", a.Epoch).
+			Msg("duty_committee_anomaly: validator not in exactly one committee this epoch")
+	}
+	if err := s.Repo.SaveDutyMismatches(ctx, anomalies); err != nil {
+		s.Log.Error().Err(err).Int("anomalies", len(anomalies)).Msg("realtime: failed to save duty anomalies")
+	}
+}
+
+func (s *AttesterDuties) scheduleTimers(duties []*storage.AttestationDuty) {
+	if s.Timers == nil {
+		return
+	}
+	for _, d := range duties {
+		at := s.Network.SlotTime(d.Slot + s.InclusionDelaySlots + 1)
+		if at.IsZero() {
+			// Genesis unknown: the poll-driven AttestationInclusion still covers these duties.
+			return
+		}
+		s.Timers.Schedule(d.Slot, at)
+	}
+}
@@ -0,0 +1,103 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/beacon/mock"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type dutiesRepo struct {
+	*noop.Repository
+	saved []*storage.AttestationDuty
+}
+
+func (r *dutiesRepo) SaveAttestationDuties(_ context.Context, duties []*storage.AttestationDuty) error {
+	r.saved = append(r.saved, duties...)
+	return nil
+}
+
+func TestAttesterDuties_dependentRootChange(t *testing.T) {
+	// Epoch 3 duties: slot 100 under root 0xaa, slot 101 after the reorg (0xbb).
+	node := mock.New(t)
+	duty := func(slot uint64) beacon.AttesterDuty {
+		return beacon.AttesterDuty{ValidatorIndex: 7, CommitteeLength: 10, CommitteesAtSlot: 1, ValidatorCommitteeIndex: 1, Slot: beacon.Uint64Str(slot)}
+	}
+	for epoch := uint64(2); epoch <= 4; epoch++ {
+		node.SetAttesterDuties(epoch, "0xaa", duty(epoch*32+4))
+	}
+	client := node.Client()
+	repo := &dutiesRepo{Repository: noop.NewRepository()}
+	s := &AttesterDuties{Client: client, Repo: repo, Log: zerolog.Nop(), Schedule: NewDutySchedule()}
+	run := func(head uint64) {
+		e := &steps.Env{Ctx: context.Background(), HeadSlot: head, ValidatorIndices: []uint64{7}}
+		ok, err := s.Run(e)
+		require.NoError(t, err)
+		if ok {
+			require.NoError(t, s.RunAsync(context.Background(), e))
+		}
+	}
+
+	run(2 * 32) // epochs 2 and 3 fetched; 3 is due a recheck
+	require.Len(t, repo.saved, 2)
+	require.Len(t, s.Schedule.At(3*32+4), 1)
+	require.True(t, s.Schedule.NeedsRecheck(3))
+
+	node.SetAttesterDuties(3, "0xbb", duty(3*32+5))
+	run(3 * 32) // recheck epoch 3 (changed root), fetch epoch 4
+	require.False(t, s.Schedule.NeedsRecheck(3))
+	require.Empty(t, s.Schedule.At(3*32+4), "duty at the old slot dropped")
+	moved := s.Schedule.At(3*32 + 5)
+	require.Len(t, moved, 1)
+	require.Equal(t, "0xbb", moved[0].DependentRoot)
+	require.Len(t, repo.saved, 4, "changed epoch 3 and new epoch 4 saved")
+	require.Equal(t, uint64(3*32+5), repo.saved[2].Slot)
+
+	run(3*32 + 1) // nothing left to do
+	require.Len(t, repo.saved, 4)
+}
+
+func TestDutySchedule_claimRecheckOnce(t *testing.T) {
+	s := NewDutySchedule()
+	require.True(t, s.Claim(5))
+	s.Add(5, []*storage.AttestationDuty{{ValidatorIndex: 1, Epoch: 5, Slot: 160}})
+	s.Track(5, "0xaa", true)
+
+	root, ok := s.ClaimRecheck(5)
+	require.True(t, ok)
+	require.Equal(t, "0xaa", root)
+	_, ok = s.ClaimRecheck(5)
+	require.False(t, ok, "recheck handed out once")
+}
+
+func TestAttesterDuties_midEpochStartCoversCurrentEpoch(t *testing.T) {
+	node := mock.New(t)
+	node.SetAttesterDuties(2, "0xaa", beacon.AttesterDuty{ValidatorIndex: 7, CommitteeLength: 10, CommitteesAtSlot: 1, Slot: 2*32 + 20})
+	node.SetAttesterDuties(3, "0xaa", beacon.AttesterDuty{ValidatorIndex: 7, CommitteeLength: 10, CommitteesAtSlot: 1, Slot: 3*32 + 4})
+	repo := &dutiesRepo{Repository: noop.NewRepository()}
+	s := &AttesterDuties{Client: node.Client(), Repo: repo, Log: zerolog.Nop(), Schedule: NewDutySchedule()}
+	run := func(head uint64) {
+		e := &steps.Env{Ctx: context.Background(), HeadSlot: head, ValidatorIndices: []uint64{7}}
+		ok, err := s.Run(e)
+		require.NoError(t, err)
+		if ok {
+			require.NoError(t, s.RunAsync(context.Background(), e))
+		}
+	}
+
+	run(2*32 + 17) // restart mid-epoch 2
+	require.Len(t, s.Schedule.At(2*32+20), 1, "rest of the current epoch is covered without waiting for the boundary")
+	require.Len(t, s.Schedule.At(3*32+4), 1)
+	require.Equal(t, 2, node.Requests(mock.RouteAttesterDuties))
+
+	run(2*32 + 18)
+	run(2*32 + 19)
+	require.Equal(t, 2, node.Requests(mock.RouteAttesterDuties), "claimed epochs are not fetched again")
+	require.Len(t, repo.saved, 2)
+}
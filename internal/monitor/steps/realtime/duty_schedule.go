@@ -0,0 +1,209 @@
+package realtime
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// DutySchedule holds fetched attester duties keyed by slot until their inclusion check is due.
+// AttesterDuties fills it from a worker; AttestationInclusion drains it. Safe for concurrent use.
+type DutySchedule struct {
+	mu     sync.Mutex
+	bySlot map[uint64][]*storage.AttestationDuty
+	// epochs records epochs whose duties are loaded or being fetched (claimed).
+	epochs map[uint64]*epochDuties
+}
+
+// epochDuties is what the schedule knows about one epoch's fetched duties.
+type epochDuties struct {
+	dependentRoot string
+	// recheck is set for duties fetched before their epoch started: a reorg of the dependent block
+	// around the epoch boundary can still change them.
+	recheck bool
+}
+
+// NewDutySchedule returns an empty schedule.
+func NewDutySchedule() *DutySchedule {
+	return &DutySchedule{
+		bySlot: make(map[uint64][]*storage.AttestationDuty),
+		epochs: make(map[uint64]*epochDuties),
+	}
+}
+
+// HasEpoch reports whether duties for epoch are loaded or being fetched.
+func (s *DutySchedule) HasEpoch(epoch uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.epochs[epoch]
+	return ok
+}
+
+// Claim marks epoch as being fetched; false if another worker already claimed or loaded it.
+func (s *DutySchedule) Claim(epoch uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.epochs[epoch]; ok {
+		return false
+	}
+	s.epochs[epoch] = &epochDuties{}
+	return true
+}
+
+// Track records the dependent root epoch's duties were fetched at; recheck asks ClaimRecheck to
+// hand the epoch out once more for a dependent root comparison.
+func (s *DutySchedule) Track(epoch uint64, dependentRoot string, recheck bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ed, ok := s.epochs[epoch]; ok {
+		ed.dependentRoot, ed.recheck = dependentRoot, recheck
+	}
+}
+
+// ClaimRecheck returns the dependent root of an epoch whose duties still need their recheck and
+// clears the flag, so only one worker refetches it.
+func (s *DutySchedule) ClaimRecheck(epoch uint64) (dependentRoot string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ed, found := s.epochs[epoch]
+	if !found || !ed.recheck {
+		return "", false
+	}
+	ed.recheck = false
+	return ed.dependentRoot, true
+}
+
+// NeedsRecheck reports whether epoch's duties are waiting for ClaimRecheck.
+func (s *DutySchedule) NeedsRecheck(epoch uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ed, ok := s.epochs[epoch]
+	return ok && ed.recheck
+}
+
+// ReplaceEpoch swaps the pending duties of epoch for duties fetched at a new dependent root.
+// Duties already handed out by Due are not affected.
+func (s *DutySchedule) ReplaceEpoch(epoch uint64, dependentRoot string, duties []*storage.AttestationDuty) {
+	s.mu.Lock()
+	for slot, pending := range s.bySlot {
+		kept := pending[:0]
+		for _, d := range pending {
+			if d.Epoch != epoch {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.bySlot, slot)
+		} else {
+			s.bySlot[slot] = kept
+		}
+	}
+	if ed, ok := s.epochs[epoch]; ok {
+		ed.dependentRoot = dependentRoot
+	}
+	s.mu.Unlock()
+	s.Add(epoch, duties)
+}
+
+// Release drops a claim after a failed fetch so a later poll retries the epoch.
+func (s *DutySchedule) Release(epoch uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.epochs, epoch)
+}
+
+// Add schedules duties (skipping validators already scheduled at the same slot) and forgets claims for
+// epochs older than epoch-2.
+func (s *DutySchedule) Add(epoch uint64, duties []*storage.AttestationDuty) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range duties {
+		if !slotHasValidator(s.bySlot[d.Slot], d.ValidatorIndex) {
+			s.bySlot[d.Slot] = append(s.bySlot[d.Slot], d)
+		}
+	}
+	for e := range s.epochs {
+		if e+2 < epoch {
+			delete(s.epochs, e)
+		}
+	}
+}
+
+// HasDue reports whether any duty slot is <= upToSlot.
+func (s *DutySchedule) HasDue(upToSlot uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for slot := range s.bySlot {
+		if slot <= upToSlot {
+			return true
+		}
+	}
+	return false
+}
+
+// Due removes and returns every duty with slot <= upToSlot, ordered by slot then validator index.
+func (s *DutySchedule) Due(upToSlot uint64) []*storage.AttestationDuty {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*storage.AttestationDuty
+	for slot, duties := range s.bySlot {
+		if slot <= upToSlot {
+			out = append(out, duties...)
+			delete(s.bySlot, slot)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Slot != out[j].Slot {
+			return out[i].Slot < out[j].Slot
+		}
+		return out[i].ValidatorIndex < out[j].ValidatorIndex
+	})
+	return out
+}
+
+// At returns a copy of the duties scheduled at slot without removing them.
+func (s *DutySchedule) At(slot uint64) []*storage.AttestationDuty {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*storage.AttestationDuty(nil), s.bySlot[slot]...)
+}
+
+// Reset forgets every epoch claim so the next poll refetches duties (e.g. after validators were added).
+// Already scheduled duties stay; Add skips duplicates.
+func (s *DutySchedule) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.epochs = make(map[uint64]*epochDuties)
+}
+
+// Remove drops scheduled duties for the given validator indices.
+func (s *DutySchedule) Remove(validators []uint64) {
+	if len(validators) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for slot, duties := range s.bySlot {
+		kept := duties[:0]
+		for _, d := range duties {
+			if !validatorIndexWatched(validators, d.ValidatorIndex) {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.bySlot, slot)
+		} else {
+			s.bySlot[slot] = kept
+		}
+	}
+}
+
+func slotHasValidator(duties []*storage.AttestationDuty, index uint64) bool {
+	for _, d := range duties {
+		if d.ValidatorIndex == index {
+			return true
+		}
+	}
+	return false
+}
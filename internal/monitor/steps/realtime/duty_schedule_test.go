@@ -0,0 +1,52 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestDutySchedule_DueAndRemove(t *testing.T) {
+	s := NewDutySchedule()
+	require.True(t, s.Claim(10))
+	require.False(t, s.Claim(10), "already claimed")
+
+	s.Add(10, []*storage.AttestationDuty{
+		{ValidatorIndex: 2, Slot: 321},
+		{ValidatorIndex: 1, Slot: 321},
+		{ValidatorIndex: 3, Slot: 330},
+	})
+	s.Add(10, []*storage.AttestationDuty{{ValidatorIndex: 1, Slot: 321}})
+
+	s.Remove([]uint64{3})
+	require.True(t, s.HasDue(321))
+	require.False(t, s.HasDue(320))
+
+	due := s.Due(400)
+	require.Len(t, due, 2, "duplicate and removed duties are not returned")
+	require.Equal(t, uint64(1), due[0].ValidatorIndex)
+	require.Equal(t, uint64(2), due[1].ValidatorIndex)
+	require.False(t, s.HasDue(400))
+
+	s.Reset()
+	require.False(t, s.HasEpoch(10))
+}
+
+func TestDutySchedule_sparseValidatorIndices(t *testing.T) {
+	s := NewDutySchedule()
+	s.Add(10, []*storage.AttestationDuty{
+		{ValidatorIndex: 90000, Slot: 325},
+		{ValidatorIndex: 100, Slot: 321},
+		{ValidatorIndex: 5000, Slot: 321},
+	})
+
+	s.Remove([]uint64{5000})
+	require.Len(t, s.At(321), 1)
+	require.Equal(t, uint64(100), s.At(321)[0].ValidatorIndex)
+
+	due := s.Due(400)
+	require.Len(t, due, 2)
+	require.Equal(t, uint64(100), due[0].ValidatorIndex)
+	require.Equal(t, uint64(90000), due[1].ValidatorIndex)
+}
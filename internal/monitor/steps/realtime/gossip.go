@@ -0,0 +1,156 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/execution"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// GossipBlock (async) indexes the block of a "block" event at Env.HeadSlot as soon as the node imports it,
+// and logs when a watched validator proposed it. It is enqueued directly by the runner's event stream
+// consumer, never from a step chain, so Run always declines.
+type GossipBlock struct {
+	Client    *beacon.Client
+	Execution *execution.Client
+	Repo      storage.Repository
+	Log       zerolog.Logger
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+}
+
+var _ Step = (*GossipBlock)(nil)
+
+func (*GossipBlock) Async() bool { return true }
+
+func (*GossipBlock) Run(*steps.Env) (bool, error) { return false, nil }
+
+func (s *GossipBlock) RunAsync(ctx context.Context, e *steps.Env) error {
+	header, err := s.Client.GetBlockHeader(ctx, strconv.FormatUint(e.HeadSlot, 10))
+	if err != nil {
+		if beacon.IsNotFound(err) {
+			// Reorged away (or a gap fill over an empty slot).
+			return nil
+		}
+		return fmt.Errorf("block header slot %d: %w", e.HeadSlot, err)
+	}
+	if proposer := header.Data.Header.Message.ProposerIndex.Uint64(); validatorIndexWatched(e.ValidatorIndices, proposer) {
+		s.Results.Event(s.Log, config.ResultProposalSeen, zerolog.InfoLevel).
+			Uint64("validator_index", proposer).
+			Uint64("slot", e.HeadSlot).
+			Msg("realtime: proposal by watched validator seen on event stream")
+	}
+	idx := &indexing.BlockIndexer{
+		Client:    s.Client,
+		Execution: s.Execution,
+		Repo:      s.Repo,
+		Log:       s.Log,
+	}
+	if err := indexing.IndexBlockAtSlot(ctx, idx, e.HeadSlot); err != nil {
+		return err
+	}
+	return s.Repo.MarkSlotIndexed(ctx, e.HeadSlot)
+}
+
+// GossipAttestations matches "attestation" and "single_attestation" events against scheduled duties, so a
+// watched validator's attestation is noticed when it is gossiped rather than when a block includes it.
+// Sightings are logged and counted only; AttestationInclusion and epoch rewards stay authoritative.
+// Safe for concurrent use.
+type GossipAttestations struct {
+	Schedule *DutySchedule
+	Log      zerolog.Logger
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+
+	mu   sync.Mutex
+	seen map[gossipDuty]struct{}
+}
+
+type gossipDuty struct {
+	validator uint64
+	slot      uint64
+}
+
+// gossipSeenSlots bounds how long sightings are remembered for dedup.
+const gossipSeenSlots = 64
+
+// Observe handles one attestation event and returns how many scheduled duties it newly covers.
+func (g *GossipAttestations) Observe(ev beacon.Event) (int, error) {
+	var slot uint64
+	var matches func(d *storage.AttestationDuty) (bool, error)
+	switch ev.Topic {
+	case beacon.TopicSingleAttestation:
+		var a beacon.SingleAttestation
+		if err := json.Unmarshal(ev.Data, &a); err != nil {
+			return 0, fmt.Errorf("decode single_attestation event: %w", err)
+		}
+		slot = a.Data.Slot.Uint64()
+		matches = func(d *storage.AttestationDuty) (bool, error) {
+			return d.ValidatorIndex == a.AttesterIndex.Uint64() && d.CommitteeIndex == a.CommitteeIndex.Uint64(), nil
+		}
+	case beacon.TopicAttestation:
+		var a beacon.BlockAttestation
+		if err := json.Unmarshal(ev.Data, &a); err != nil {
+			return 0, fmt.Errorf("decode attestation event: %w", err)
+		}
+		slot = a.Data.Slot.Uint64()
+		matches = func(d *storage.AttestationDuty) (bool, error) {
+			return a.HasAttester(d.CommitteeIndex, d.CommitteePosition, func(ci uint64) (uint64, error) {
+				if ci == d.CommitteeIndex {
+					return d.CommitteeLength, nil
+				}
+				// Multi-committee aggregates need other committees' sizes; leave those to AttestationInclusion.
+				return 0, fmt.Errorf("committee %d length unknown", ci)
+			})
+		}
+	default:
+		return 0, nil
+	}
+
+	newly := 0
+	for _, d := range g.Schedule.At(slot) {
+		ok, err := matches(d)
+		if err != nil || !ok {
+			continue
+		}
+		if !g.markSeen(gossipDuty{validator: d.ValidatorIndex, slot: d.Slot}) {
+			continue
+		}
+		newly++
+		metrics.GossipAttestationsSeen.Inc()
+		g.Results.Event(g.Log, config.ResultAttestationSeen, zerolog.DebugLevel).
+			Uint64("validator_index", d.ValidatorIndex).
+			Uint64("duty_slot", d.Slot).
+			Str("topic", ev.Topic).
+			Msg("realtime: attestation seen on event stream")
+	}
+	return newly, nil
+}
+
+func (g *GossipAttestations) markSeen(k gossipDuty) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.seen == nil {
+		g.seen = make(map[gossipDuty]struct{})
+	}
+	if _, ok := g.seen[k]; ok {
+		return false
+	}
+	g.seen[k] = struct{}{}
+	for old := range g.seen {
+		if old.slot+gossipSeenSlots < k.slot {
+			delete(g.seen, old)
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package realtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestGossipAttestations_MatchesScheduledDuties(t *testing.T) {
+	schedule := NewDutySchedule()
+	schedule.Add(3, []*storage.AttestationDuty{
+		{ValidatorIndex: 7, Slot: 100, CommitteeIndex: 2, CommitteePosition: 1, CommitteeLength: 4},
+		{ValidatorIndex: 8, Slot: 100, CommitteeIndex: 5, CommitteePosition: 0, CommitteeLength: 4},
+	})
+	g := &GossipAttestations{Schedule: schedule, Log: zerolog.Nop()}
+
+	// Pre-Electra aggregate of committee 2 with position 1 set (0b10).
+	n, err := g.Observe(beacon.Event{Topic: beacon.TopicAttestation, Data: json.RawMessage(
+		`{"aggregation_bits":"0x12","data":{"slot":"100","index":"2"}}`)})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	n, err = g.Observe(beacon.Event{Topic: beacon.TopicAttestation, Data: json.RawMessage(
+		`{"aggregation_bits":"0x12","data":{"slot":"100","index":"2"}}`)})
+	require.NoError(t, err)
+	require.Zero(t, n, "same duty is counted once")
+
+	n, err = g.Observe(beacon.Event{Topic: beacon.TopicSingleAttestation, Data: json.RawMessage(
+		`{"committee_index":"5","attester_index":"8","data":{"slot":"100","index":"0"}}`)})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	n, err = g.Observe(beacon.Event{Topic: beacon.TopicSingleAttestation, Data: json.RawMessage(
+		`{"committee_index":"5","attester_index":"9","data":{"slot":"100","index":"0"}}`)})
+	require.NoError(t, err)
+	require.Zero(t, n, "unwatched attester")
+
+	require.Len(t, schedule.Due(100), 2, "sightings do not drain the schedule")
+}
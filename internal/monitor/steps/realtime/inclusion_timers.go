@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// InclusionTimers is a priority timer queue of duty slots keyed by the wall-clock time their inclusion
+// check is due. AttesterDuties schedules each duty slot as it loads duties; Run fires the slots in
+// check-time order, independent of the realtime polling interval. Safe for concurrent use.
+type InclusionTimers struct {
+	mu    sync.Mutex
+	queue timerQueue
+	slots map[uint64]struct{}
+	wake  chan struct{}
+}
+
+// NewInclusionTimers returns an empty timer queue.
+func NewInclusionTimers() *InclusionTimers {
+	return &InclusionTimers{
+		slots: make(map[uint64]struct{}),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Schedule queues slot to fire at at; a slot already queued is ignored.
+func (t *InclusionTimers) Schedule(slot uint64, at time.Time) {
+	t.mu.Lock()
+	if _, ok := t.slots[slot]; ok {
+		t.mu.Unlock()
+		return
+	}
+	t.slots[slot] = struct{}{}
+	heap.Push(&t.queue, inclusionTimer{slot: slot, at: at})
+	t.mu.Unlock()
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Len returns the number of queued slots.
+func (t *InclusionTimers) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.queue.Len()
+}
+
+// Run calls fire for each queued slot once its time has come, earliest first, until ctx is done.
+func (t *InclusionTimers) Run(ctx context.Context, fire func(slot uint64)) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		for _, slot := range t.popDue(time.Now()) {
+			fire(slot)
+		}
+		wait := time.Hour
+		if next, ok := t.next(); ok {
+			wait = time.Until(next)
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *InclusionTimers) popDue(now time.Time) []uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var due []uint64
+	for t.queue.Len() > 0 && !t.queue[0].at.After(now) {
+		it := heap.Pop(&t.queue).(inclusionTimer)
+		delete(t.slots, it.slot)
+		due = append(due, it.slot)
+	}
+	return due
+}
+
+func (t *InclusionTimers) next() (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.queue.Len() == 0 {
+		return time.Time{}, false
+	}
+	return t.queue[0].at, true
+}
+
+type inclusionTimer struct {
+	slot uint64
+	at   time.Time
+}
+
+// timerQueue is a container/heap min-heap ordered by check time, then slot.
+type timerQueue []inclusionTimer
+
+func (q timerQueue) Len() int { return len(q) }
+
+func (q timerQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	return q[i].slot < q[j].slot
+}
+
+func (q timerQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *timerQueue) Push(x any) { *q = append(*q, x.(inclusionTimer)) }
+
+func (q *timerQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,45 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInclusionTimers_FireInCheckTimeOrder(t *testing.T) {
+	timers := NewInclusionTimers()
+	now := time.Now()
+	timers.Schedule(40, now.Add(30*time.Millisecond))
+	timers.Schedule(10, now.Add(-time.Second))
+	timers.Schedule(20, now.Add(10*time.Millisecond))
+	timers.Schedule(20, now.Add(time.Hour)) // already queued
+	require.Equal(t, 3, timers.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fired := make(chan uint64, 4)
+	go timers.Run(ctx, func(slot uint64) { fired <- slot })
+
+	var got []uint64
+	for len(got) < 3 {
+		select {
+		case slot := <-fired:
+			got = append(got, slot)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timers fired %v, want 3 slots", got)
+		}
+	}
+	require.Equal(t, []uint64{10, 20, 40}, got)
+	require.Zero(t, timers.Len())
+
+	// A slot scheduled while Run waits wakes it up.
+	timers.Schedule(50, time.Now())
+	select {
+	case slot := <-fired:
+		require.Equal(t, uint64(50), slot)
+	case <-time.After(2 * time.Second):
+		t.Fatal("late schedule did not fire")
+	}
+}
@@ -0,0 +1,85 @@
+package realtime
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+// NodeSyncGate (sync) runs first in the realtime chain when the sync status is needed: unless
+// poll_while_syncing is set and max_sync_distance is 0, or whenever a duty step is enabled. It checks
+// /eth/v1/node/syncing on every pass and ends the pass (steps.ErrSkipChain) while the node is syncing
+// or its sync_distance exceeds MaxSyncDistance (0 = distance not checked), so head data from a
+// catching-up node is not stored. The node_lagging warning is logged once when the node falls behind,
+// and again when it catches up.
+//
+// It also copies el_offline into Env.ELOffline: with the execution layer offline the head may be
+// optimistic, so AttesterDuties and ProposerDuties skip fetching duties until it is back (the
+// el_offline_skipping_duties warning is logged once per outage when ELOffline is set).
+type NodeSyncGate struct {
+	Client          *beacon.Client
+	MaxSyncDistance uint64
+	// DutiesOnly only records el_offline and never skips the pass (poll_while_syncing with
+	// max_sync_distance 0, kept in the chain for the duty steps).
+	DutiesOnly bool
+	Log        zerolog.Logger
+	// Lagging is runner-owned state: whether the previous pass was skipped.
+	Lagging *bool
+	// ELOffline is runner-owned state: whether the previous pass saw el_offline. Nil = not logged
+	// (no duty step enabled).
+	ELOffline *bool
+}
+
+var _ Step = (*NodeSyncGate)(nil)
+
+func (*NodeSyncGate) Async() bool { return false }
+
+func (s *NodeSyncGate) Run(e *steps.Env) (bool, error) {
+	status, err := s.Client.GetSyncStatus(e.Ctx)
+	if err != nil {
+		return false, err
+	}
+	e.ELOffline = status.Data.ELOffline
+	s.logELOffline(status.Data.ELOffline)
+	if s.DutiesOnly {
+		return false, nil
+	}
+	distance := status.Data.SyncDistance.Uint64()
+	if status.Data.IsSyncing || (s.MaxSyncDistance > 0 && distance > s.MaxSyncDistance) {
+		ev := s.Log.Debug()
+		if !*s.Lagging {
+			ev = s.Log.Warn()
+		}
+		ev.Str("warning", "node_lagging").
+			Uint64("sync_distance", distance).
+			Uint64("max_sync_distance", s.MaxSyncDistance).
+			Bool("is_syncing", status.Data.IsSyncing).
+			Uint64("node_head_slot", status.Data.HeadSlot.Uint64()).
+			Msg("node_lagging: beacon node behind; skipping head processing")
+		*s.Lagging = true
+		return false, steps.ErrSkipChain
+	}
+	if *s.Lagging {
+		s.Log.Info().Uint64("sync_distance", distance).Msg("beacon node caught up; resuming head processing")
+		*s.Lagging = false
+	}
+	return false, nil
+}
+
+// logELOffline warns when the execution layer goes offline and logs when it is back.
+func (s *NodeSyncGate) logELOffline(offline bool) {
+	if s.ELOffline == nil || offline == *s.ELOffline {
+		return
+	}
+	*s.ELOffline = offline
+	if offline {
+		s.Log.Warn().Str("warning", "el_offline_skipping_duties").
+			Msg("el_offline_skipping_duties: execution layer offline, head may be optimistic; skipping duty fetching")
+		return
+	}
+	s.Log.Info().Msg("execution layer back online; resuming duty fetching")
+}
+
+func (*NodeSyncGate) RunAsync(context.Context, *steps.Env) error { return nil }
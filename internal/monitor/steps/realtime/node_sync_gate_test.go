@@ -0,0 +1,112 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+func TestNodeSyncGate(t *testing.T) {
+	var distance atomic.Uint64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"head_slot":"100","sync_distance":"%d","is_syncing":false}}`, distance.Load())
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	var lagging bool
+	g := &NodeSyncGate{Client: client, MaxSyncDistance: 4, Log: zerolog.Nop(), Lagging: &lagging}
+	e := &steps.Env{Ctx: context.Background()}
+
+	distance.Store(2)
+	_, err := g.Run(e)
+	require.NoError(t, err)
+
+	distance.Store(40)
+	_, err = g.Run(e)
+	require.ErrorIs(t, err, steps.ErrSkipChain)
+	require.True(t, lagging)
+
+	distance.Store(0)
+	_, err = g.Run(e)
+	require.NoError(t, err)
+	require.False(t, lagging)
+}
+
+func TestNodeSyncGate_syncingOnly(t *testing.T) {
+	var syncing atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"head_slot":"100","sync_distance":"500","is_syncing":%t}}`, syncing.Load())
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	var lagging bool
+	g := &NodeSyncGate{Client: client, Log: zerolog.Nop(), Lagging: &lagging}
+	e := &steps.Env{Ctx: context.Background()}
+
+	_, err := g.Run(e)
+	require.NoError(t, err, "sync_distance is not checked with MaxSyncDistance 0")
+
+	syncing.Store(true)
+	_, err = g.Run(e)
+	require.ErrorIs(t, err, steps.ErrSkipChain)
+	require.True(t, lagging)
+
+	syncing.Store(false)
+	_, err = g.Run(e)
+	require.NoError(t, err)
+	require.False(t, lagging)
+}
+
+func TestNodeSyncGate_elOffline(t *testing.T) {
+	var elOffline atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"head_slot":"100","sync_distance":"0","is_syncing":false,"el_offline":%t}}`, elOffline.Load())
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	var lagging, offline bool
+	g := &NodeSyncGate{Client: client, DutiesOnly: true, Log: zerolog.Nop(), Lagging: &lagging, ELOffline: &offline}
+	duties := &ProposerDuties{Schedule: NewProposerSchedule()}
+	e := &steps.Env{Ctx: context.Background(), HeadSlot: 64, WatchedValidators: []uint64{1}}
+
+	elOffline.Store(true)
+	_, err := g.Run(e)
+	require.NoError(t, err, "el_offline does not end the pass")
+	require.True(t, e.ELOffline)
+	require.True(t, offline)
+	queued, err := duties.Run(e)
+	require.NoError(t, err)
+	require.False(t, queued, "duties are not fetched from an optimistic head")
+
+	elOffline.Store(false)
+	_, err = g.Run(e)
+	require.NoError(t, err)
+	require.False(t, offline)
+	queued, err = duties.Run(e)
+	require.NoError(t, err)
+	require.True(t, queued, "duties are re-attempted once the execution layer is back")
+}
@@ -0,0 +1,179 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ProposerDuties (async): when the head epoch has no proposer duties loaded, fetches the epoch's proposers,
+// stores every watched validator's duties as scheduled and adds them to Schedule for ProposalCheck. It
+// reads Env.WatchedValidators, so validator_sampling does not apply: one request covers everyone.
+type ProposerDuties struct {
+	Client            *beacon.Client
+	Repo              storage.Repository
+	Log               zerolog.Logger
+	LastProcessedSlot *uint64
+	Schedule          *ProposerSchedule
+}
+
+var _ Step = (*ProposerDuties)(nil)
+
+func (*ProposerDuties) Async() bool { return true }
+
+func (s *ProposerDuties) Run(e *steps.Env) (bool, error) {
+	if s.LastProcessedSlot != nil && e.HeadSlot == *s.LastProcessedSlot {
+		return false, nil
+	}
+	if e.ELOffline {
+		// Optimistic head (see NodeSyncGate); retried once the execution layer is back.
+		return false, nil
+	}
+	if len(e.WatchedValidators) == 0 {
+		return false, nil
+	}
+	return !s.Schedule.HasEpoch(e.HeadSlot / config.SlotsPerEpoch()), nil
+}
+
+func (s *ProposerDuties) RunAsync(ctx context.Context, e *steps.Env) error {
+	epoch := e.HeadSlot / config.SlotsPerEpoch()
+	if !s.Schedule.Claim(epoch) {
+		return nil
+	}
+	duties, err := indexing.FetchProposerDuties(ctx, s.Client, epoch, e.WatchedValidators)
+	if err == nil {
+		err = s.Repo.SaveProposerDuties(ctx, duties)
+	}
+	if err != nil {
+		s.Schedule.Release(epoch)
+		return fmt.Errorf("proposer duties epoch %d: %w", epoch, err)
+	}
+	s.Schedule.Add(epoch, duties)
+	for _, d := range duties {
+		s.Log.Info().
+			Uint64("validator_index", d.ValidatorIndex).
+			Uint64("slot", d.Slot).
+			Msg("realtime: block proposal scheduled")
+	}
+	return nil
+}
+
+// ProposalCheck (async): once head is CheckDelaySlots past a scheduled proposal slot, looks up the
+// canonical block at the slot and stores the proposer reward, or a missed proposal when the slot is
+// empty. The delay lets a late block or a short reorg at the slot settle; the result is still provisional
+// and is checked again once the epoch is finalized, when it can no longer change.
+type ProposalCheck struct {
+	Client            *beacon.Client
+	Repo              storage.Repository
+	Log               zerolog.Logger
+	LastProcessedSlot *uint64
+	Schedule          *ProposerSchedule
+	CheckDelaySlots   uint64
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+}
+
+var _ Step = (*ProposalCheck)(nil)
+
+func (*ProposalCheck) Async() bool { return true }
+
+func (s *ProposalCheck) Run(e *steps.Env) (bool, error) {
+	if s.LastProcessedSlot != nil && e.HeadSlot == *s.LastProcessedSlot {
+		return false, nil
+	}
+	if s.Schedule.HasProvisional() {
+		return true, nil
+	}
+	return e.HeadSlot >= s.CheckDelaySlots && s.Schedule.HasDue(e.HeadSlot-s.CheckDelaySlots), nil
+}
+
+func (s *ProposalCheck) RunAsync(ctx context.Context, e *steps.Env) error {
+	var due []*storage.ProposerDuty
+	if e.HeadSlot >= s.CheckDelaySlots {
+		due = s.Schedule.Due(e.HeadSlot - s.CheckDelaySlots)
+	}
+	var final []*storage.ProposerDuty
+	if len(due) > 0 || s.Schedule.HasProvisional() {
+		finalized, err := s.Client.FinalizedEpoch(ctx)
+		if err != nil {
+			s.Schedule.Requeue(due)
+			return err
+		}
+		final = s.Schedule.Finalized(finalized)
+		for _, d := range due {
+			d.Finalized = d.Epoch < finalized
+		}
+	}
+
+	var saved []*storage.ProposerDuty
+	for i, d := range due {
+		if err := indexing.CheckProposal(ctx, s.Client, d); err != nil {
+			s.Schedule.Requeue(append(due[i:], final...))
+			return errors.Join(err, s.save(ctx, saved))
+		}
+		saved = append(saved, d)
+		metrics.ProposalOutcomes.WithLabelValues(d.Status).Inc()
+		s.logOutcome(d)
+		if !d.Finalized {
+			s.Schedule.Checked(d)
+		}
+	}
+	for i, d := range final {
+		was := d.Status
+		if err := indexing.CheckProposal(ctx, s.Client, d); err != nil {
+			s.Schedule.Requeue(final[i:])
+			return errors.Join(err, s.save(ctx, saved))
+		}
+		d.Finalized = true
+		saved = append(saved, d)
+		if d.Status != was {
+			s.Log.Warn().
+				Uint64("validator_index", d.ValidatorIndex).
+				Uint64("slot", d.Slot).
+				Str("provisional_status", was).
+				Str("status", d.Status).
+				Msg("realtime: proposal outcome changed by a reorg before finalization")
+			s.logOutcome(d)
+		}
+	}
+	return s.save(ctx, saved)
+}
+
+func (s *ProposalCheck) save(ctx context.Context, duties []*storage.ProposerDuty) error {
+	if err := s.Repo.SaveProposerDuties(ctx, duties); err != nil {
+		return fmt.Errorf("save proposal outcomes: %w", err)
+	}
+	return nil
+}
+
+func (s *ProposalCheck) logOutcome(d *storage.ProposerDuty) {
+	switch d.Status {
+	case storage.ProposalProposed:
+		s.Results.Event(s.Log, config.ResultProposalProposed, zerolog.InfoLevel).
+			Uint64("validator_index", d.ValidatorIndex).
+			Uint64("slot", d.Slot).
+			Uint64("reward_gwei", *d.RewardGwei).
+			Bool("finalized", d.Finalized).
+			Msg("realtime: block proposed")
+	case storage.ProposalMissed:
+		s.Results.Event(s.Log, config.ResultProposalMissed, zerolog.WarnLevel).
+			Uint64("validator_index", d.ValidatorIndex).
+			Uint64("slot", d.Slot).
+			Bool("finalized", d.Finalized).
+			Msg("realtime: block proposal missed")
+	case storage.ProposalReassigned:
+		s.Log.Warn().
+			Uint64("validator_index", d.ValidatorIndex).
+			Uint64("slot", d.Slot).
+			Str("block_root", *d.BlockRoot).
+			Msg("realtime: proposal slot went to another validator after a reorg")
+	}
+}
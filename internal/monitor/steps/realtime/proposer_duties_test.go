@@ -0,0 +1,146 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type proposerRepo struct {
+	*noop.Repository
+	saved []storage.ProposerDuty
+}
+
+func (r *proposerRepo) SaveProposerDuties(_ context.Context, duties []*storage.ProposerDuty) error {
+	for _, d := range duties {
+		r.saved = append(r.saved, *d)
+	}
+	return nil
+}
+
+// proposalNode serves proposer duties for epoch 7, block headers by proposer (0 = empty slot), block
+// rewards and the finalized epoch.
+type proposalNode struct {
+	mu        sync.Mutex
+	proposers map[uint64]uint64
+	finalized uint64
+}
+
+func (n *proposalNode) set(slot, proposer uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.proposers[slot] = proposer
+}
+
+func (n *proposalNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var slot uint64
+	switch {
+	case r.URL.Path == "/eth/v1/validator/duties/proposer/7":
+		_, _ = w.Write([]byte(`{"dependent_root":"0xd7","data":[` +
+			`{"pubkey":"0x05","validator_index":"5","slot":"225"},` +
+			`{"pubkey":"0x09","validator_index":"9","slot":"226"},` +
+			`{"pubkey":"0x64","validator_index":"100","slot":"227"}]}`))
+	case r.URL.Path == "/eth/v1/beacon/states/head/finality_checkpoints":
+		_, _ = fmt.Fprintf(w, `{"data":{"finalized":{"epoch":"%d","root":"0x01"}}}`, n.finalized)
+	case sscanf(r.URL.Path, "/eth/v1/beacon/headers/%d", &slot):
+		proposer := n.proposers[slot]
+		if proposer == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"NOT_FOUND: beacon block"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data":{"root":"0xb%d","canonical":true,"header":{"message":{"slot":"%d","proposer_index":"%d"}}}}`, slot, slot, proposer)
+	case sscanf(r.URL.Path, "/eth/v1/beacon/rewards/blocks/%d", &slot):
+		_, _ = fmt.Fprintf(w, `{"data":{"proposer_index":"%d","total":"1234"}}`, n.proposers[slot])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func sscanf(path, format string, slot *uint64) bool {
+	n, err := fmt.Sscanf(path, format, slot)
+	return err == nil && n == 1
+}
+
+func TestProposalCheck(t *testing.T) {
+	node := &proposalNode{proposers: map[uint64]uint64{225: 5}, finalized: 6}
+	srv := httptest.NewServer(node)
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, Cache: config.BeaconCacheConf{Disabled: true}},
+	})
+	repo := &proposerRepo{Repository: noop.NewRepository()}
+	schedule := NewProposerSchedule()
+	duties := &ProposerDuties{Client: client, Repo: repo, Log: zerolog.Nop(), Schedule: schedule}
+	check := &ProposalCheck{Client: client, Repo: repo, Log: zerolog.Nop(), Schedule: schedule, CheckDelaySlots: 2}
+	ctx := context.Background()
+
+	e := &steps.Env{Ctx: ctx, HeadSlot: 224, WatchedValidators: []uint64{5, 9}}
+	ok, err := duties.Run(e)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, duties.RunAsync(ctx, e))
+	require.Len(t, repo.saved, 2, "unwatched proposer 100 is not stored")
+	require.Equal(t, storage.ProposalScheduled, repo.saved[0].Status)
+	require.Equal(t, "0xd7", repo.saved[0].DependentRoot)
+
+	// Head 226: slot 225 is not CheckDelaySlots old yet.
+	e.HeadSlot = 226
+	ok, err = check.Run(e)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	repo.saved = nil
+	e.HeadSlot = 228
+	ok, err = check.Run(e)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, check.RunAsync(ctx, e))
+	require.Len(t, repo.saved, 2)
+	proposed, missed := repo.saved[0], repo.saved[1]
+	require.Equal(t, uint64(225), proposed.Slot)
+	require.Equal(t, storage.ProposalProposed, proposed.Status)
+	require.Equal(t, uint64(1234), *proposed.RewardGwei)
+	require.Equal(t, "0xb225", *proposed.BlockRoot)
+	require.False(t, proposed.Finalized)
+	require.Equal(t, uint64(226), missed.Slot)
+	require.Equal(t, storage.ProposalMissed, missed.Status)
+	require.Nil(t, missed.RewardGwei)
+
+	// Provisional results are rechecked once epoch 7 is finalized; a reorg gave slot 225 to another
+	// validator and filled slot 226 with the duty holder's block.
+	repo.saved = nil
+	node.set(225, 77)
+	node.set(226, 9)
+	e.HeadSlot = 229
+	require.NoError(t, check.RunAsync(ctx, e))
+	require.Empty(t, repo.saved, "epoch 7 not finalized yet")
+
+	node.finalized = 8
+	e.HeadSlot = 290
+	ok, err = check.Run(e)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, check.RunAsync(ctx, e))
+	require.Len(t, repo.saved, 2)
+	require.Equal(t, storage.ProposalReassigned, repo.saved[0].Status)
+	require.True(t, repo.saved[0].Finalized)
+	require.Equal(t, storage.ProposalProposed, repo.saved[1].Status)
+	require.True(t, repo.saved[1].Finalized)
+	require.False(t, schedule.HasProvisional())
+}
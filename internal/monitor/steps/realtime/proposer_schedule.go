@@ -0,0 +1,168 @@
+package realtime
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ProposerSchedule holds the watched validators' proposal duties from fetch until their outcome is final.
+// ProposerDuties fills it from a worker; ProposalCheck drains it, first a few slots after each proposal
+// slot and again once the slot's epoch is finalized. Safe for concurrent use.
+type ProposerSchedule struct {
+	mu sync.Mutex
+	// epochs records epochs whose duties are loaded or being fetched (claimed).
+	epochs map[uint64]bool
+	// pending are duties not checked yet, by slot.
+	pending map[uint64]*storage.ProposerDuty
+	// provisional are checked duties waiting for their epoch to finalize, by slot.
+	provisional map[uint64]*storage.ProposerDuty
+}
+
+// NewProposerSchedule returns an empty schedule.
+func NewProposerSchedule() *ProposerSchedule {
+	return &ProposerSchedule{
+		epochs:      make(map[uint64]bool),
+		pending:     make(map[uint64]*storage.ProposerDuty),
+		provisional: make(map[uint64]*storage.ProposerDuty),
+	}
+}
+
+// HasEpoch reports whether duties for epoch are loaded or being fetched.
+func (s *ProposerSchedule) HasEpoch(epoch uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.epochs[epoch]
+}
+
+// Claim marks epoch as being fetched; false if another worker already claimed or loaded it.
+func (s *ProposerSchedule) Claim(epoch uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.epochs[epoch] {
+		return false
+	}
+	s.epochs[epoch] = true
+	return true
+}
+
+// Release drops a claim after a failed fetch so a later poll retries the epoch.
+func (s *ProposerSchedule) Release(epoch uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.epochs, epoch)
+}
+
+// Add schedules duties for their first check and forgets claims for epochs older than epoch-2.
+func (s *ProposerSchedule) Add(epoch uint64, duties []*storage.ProposerDuty) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range duties {
+		if _, checked := s.provisional[d.Slot]; !checked {
+			s.pending[d.Slot] = d
+		}
+	}
+	for e := range s.epochs {
+		if e+2 < epoch {
+			delete(s.epochs, e)
+		}
+	}
+}
+
+// HasDue reports whether any unchecked duty has slot <= upToSlot.
+func (s *ProposerSchedule) HasDue(upToSlot uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for slot := range s.pending {
+		if slot <= upToSlot {
+			return true
+		}
+	}
+	return false
+}
+
+// Due removes and returns every unchecked duty with slot <= upToSlot, by slot.
+func (s *ProposerSchedule) Due(upToSlot uint64) []*storage.ProposerDuty {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*storage.ProposerDuty
+	for slot, d := range s.pending {
+		if slot <= upToSlot {
+			out = append(out, d)
+			delete(s.pending, slot)
+		}
+	}
+	sortProposerDuties(out)
+	return out
+}
+
+// HasProvisional reports whether any checked duty waits for its epoch to finalize.
+func (s *ProposerSchedule) HasProvisional() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.provisional) > 0
+}
+
+// Finalized removes and returns the provisional duties of epochs before finalizedEpoch, by slot.
+func (s *ProposerSchedule) Finalized(finalizedEpoch uint64) []*storage.ProposerDuty {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*storage.ProposerDuty
+	for slot, d := range s.provisional {
+		if d.Epoch < finalizedEpoch {
+			out = append(out, d)
+			delete(s.provisional, slot)
+		}
+	}
+	sortProposerDuties(out)
+	return out
+}
+
+// Checked keeps a checked, not yet final duty for its recheck after finalization.
+func (s *ProposerSchedule) Checked(d *storage.ProposerDuty) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provisional[d.Slot] = d
+}
+
+// Requeue puts back duties whose check failed: pending ones for the next poll, checked ones for the
+// next finalization recheck.
+func (s *ProposerSchedule) Requeue(duties []*storage.ProposerDuty) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range duties {
+		if d.Status == storage.ProposalScheduled {
+			s.pending[d.Slot] = d
+		} else {
+			s.provisional[d.Slot] = d
+		}
+	}
+}
+
+// Remove drops duties of the given validator indices.
+func (s *ProposerSchedule) Remove(validators []uint64) {
+	if len(validators) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range []map[uint64]*storage.ProposerDuty{s.pending, s.provisional} {
+		for slot, d := range m {
+			if validatorIndexWatched(validators, d.ValidatorIndex) {
+				delete(m, slot)
+			}
+		}
+	}
+}
+
+// Reset forgets every epoch claim so the next poll refetches duties (e.g. after validators were added).
+func (s *ProposerSchedule) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.epochs = make(map[uint64]bool)
+}
+
+func sortProposerDuties(duties []*storage.ProposerDuty) {
+	sort.Slice(duties, func(i, j int) bool { return duties[i].Slot < duties[j].Slot })
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/config"
 	"github.com/tharun/pauli/internal/monitor/steps"
 )
 
@@ -15,7 +16,11 @@ import (
 type RealtimeEnvBootstrap struct {
 	GetHead    func(context.Context) (uint64, error)
 	Validators []uint64
-	Log        zerolog.Logger
+	// SampleGroups > 1 narrows Env.ValidatorIndices to the head epoch's group (validator_sampling).
+	SampleGroups uint64
+	// Priority validators (priority_validators) lead both validator lists and skip sampling.
+	Priority []uint64
+	Log      zerolog.Logger
 }
 
 var _ Step = (*RealtimeEnvBootstrap)(nil)
@@ -28,11 +33,13 @@ func (s RealtimeEnvBootstrap) Run(e *steps.Env) (bool, error) {
 		return false, err
 	}
 	e.HeadSlot = head
-	e.ValidatorIndices = append([]uint64(nil), s.Validators...)
+	e.WatchedValidators = PrioritizeValidators(s.Validators, append([]uint64(nil), s.Validators...), s.Priority)
+	e.ValidatorIndices = PrioritizeValidators(s.Validators, SampleValidators(s.Validators, head/config.SlotsPerEpoch(), s.SampleGroups), s.Priority)
 
 	s.Log.Debug().
 		Uint64("head_slot", head).
 		Int("validators_count", len(e.ValidatorIndices)).
+		Int("watched_count", len(e.WatchedValidators)).
 		Msg("realtime: head and validator indices on env")
 
 	return false, nil
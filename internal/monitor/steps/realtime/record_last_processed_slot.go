@@ -2,16 +2,22 @@ package realtime
 
 import (
 	"context"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
 )
 
 // RecordLastProcessedSlot (sync) runs last in the realtime chain. After all prior
 // steps have run and enqueued without error, it stores Env.HeadSlot so the next
 // poll can skip re-processing the same head — unless Env.DeferLastProcessedCommit is set
 // (e.g. attestation rewards waiting for finalization), in which case the slot cursor is unchanged.
+// When Repo is set the cursor is also persisted to scheduler_state so a restart resumes from it.
 type RecordLastProcessedSlot struct {
 	LastProcessedSlot *uint64
+	Repo              storage.Repository
+	Log               zerolog.Logger
 }
 
 var _ Step = (*RecordLastProcessedSlot)(nil)
@@ -19,10 +25,16 @@ var _ Step = (*RecordLastProcessedSlot)(nil)
 func (*RecordLastProcessedSlot) Async() bool { return false }
 
 func (s *RecordLastProcessedSlot) Run(e *steps.Env) (bool, error) {
-	if e.DeferLastProcessedCommit {
+	if e.DeferLastProcessedCommit || *s.LastProcessedSlot == e.HeadSlot {
 		return false, nil
 	}
 	*s.LastProcessedSlot = e.HeadSlot
+	if s.Repo != nil {
+		state := &storage.SchedulerState{Kind: storage.SchedulerStateRealtimeSlot, Position: e.HeadSlot, UpdatedAt: time.Now().UTC()}
+		if err := s.Repo.SaveSchedulerState(e.Ctx, state); err != nil {
+			s.Log.Warn().Err(err).Uint64("slot", e.HeadSlot).Msg("realtime: persist last processed slot failed")
+		}
+	}
 	return false, nil
 }
 
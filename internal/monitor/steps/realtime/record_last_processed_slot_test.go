@@ -0,0 +1,34 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+func TestRecordLastProcessedSlotPersists(t *testing.T) {
+	repo := noop.NewRepository()
+	last := ^uint64(0)
+	s := &RecordLastProcessedSlot{LastProcessedSlot: &last, Repo: repo, Log: zerolog.Nop()}
+	ctx := context.Background()
+
+	_, err := s.Run(&steps.Env{Ctx: ctx, HeadSlot: 100, DeferLastProcessedCommit: true})
+	require.NoError(t, err)
+	_, ok, err := repo.GetSchedulerState(ctx, storage.SchedulerStateRealtimeSlot)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = s.Run(&steps.Env{Ctx: ctx, HeadSlot: 101})
+	require.NoError(t, err)
+	require.Equal(t, uint64(101), last)
+	state, ok, err := repo.GetSchedulerState(ctx, storage.SchedulerStateRealtimeSlot)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(101), state.Position)
+	require.False(t, state.UpdatedAt.IsZero())
+}
@@ -0,0 +1,66 @@
+package realtime
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/monitor/steps/indexing"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// RewardsRecompute (async) re-fetches balances and attestation rewards of the last LookbackEpochs
+// finalized epochs and overwrites the stored epoch records, after a reorg may have changed which
+// attestations were included. Like GossipBlock it is enqueued directly by the runner, so Run declines.
+type RewardsRecompute struct {
+	Client         *beacon.Client
+	Repo           storage.Repository
+	Network        *config.BlockchainNetwork
+	Log            zerolog.Logger
+	LookbackEpochs uint64
+	// Running, when set, is cleared once the job finishes, so the runner keeps one recompute in flight.
+	Running *atomic.Bool
+}
+
+var _ Step = (*RewardsRecompute)(nil)
+
+func (*RewardsRecompute) Async() bool { return true }
+
+func (*RewardsRecompute) Run(*steps.Env) (bool, error) { return false, nil }
+
+func (s *RewardsRecompute) RunAsync(ctx context.Context, _ *steps.Env) error {
+	if s.Running != nil {
+		defer s.Running.Store(false)
+	}
+	finalized, err := s.Client.FinalizedEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	from := uint64(1)
+	if finalized+1 > s.LookbackEpochs+from {
+		from = finalized + 1 - s.LookbackEpochs
+	}
+	idx := &indexing.EpochIndexer{
+		Client:  s.Client,
+		Repo:    s.Repo,
+		Network: s.Network,
+		Log:     s.Log,
+	}
+	for epoch := from; epoch <= finalized; epoch++ {
+		f, err := indexing.FetchEpoch(ctx, idx, epoch)
+		if err != nil {
+			return err
+		}
+		if _, err := indexing.ApplyEpoch(ctx, idx, f); err != nil {
+			return err
+		}
+	}
+	s.Log.Info().
+		Uint64("from_epoch", from).
+		Uint64("to_epoch", finalized).
+		Msg("realtime: re-indexed finalized epoch rewards after reorg")
+	return nil
+}
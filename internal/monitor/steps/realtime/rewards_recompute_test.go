@@ -0,0 +1,49 @@
+package realtime
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/beacon/mock"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type epochRecordsRepo struct {
+	*noop.Repository
+	epochs []uint64
+}
+
+func (r *epochRecordsRepo) SaveValidatorEpochRecords(_ context.Context, records []*storage.ValidatorEpochRecord) error {
+	for _, rec := range records {
+		r.epochs = append(r.epochs, rec.Epoch)
+	}
+	return nil
+}
+
+func TestRewardsRecompute_reindexesLookbackEpochs(t *testing.T) {
+	node := mock.New(t)
+	node.SetFinalizedEpoch(10)
+	node.SetValidators(beacon.Validator{Index: 3, Balance: 32_000_000_000, Status: "active_ongoing"})
+	for epoch := uint64(8); epoch <= 10; epoch++ {
+		node.SetAttestationRewards(epoch, beacon.AttestationReward{ValidatorIndex: 3, Head: 1, Source: 2, Target: 3})
+	}
+	repo := &epochRecordsRepo{Repository: noop.NewRepository()}
+	var running atomic.Bool
+	running.Store(true)
+	s := &RewardsRecompute{Client: node.Client(), Repo: repo, Log: zerolog.Nop(), LookbackEpochs: 2, Running: &running}
+
+	require.NoError(t, s.RunAsync(context.Background(), nil))
+	require.Equal(t, []uint64{9, 10}, repo.epochs)
+	require.False(t, running.Load())
+
+	for _, epoch := range []uint64{9, 10} {
+		indexed, err := repo.IsEpochIndexed(context.Background(), epoch)
+		require.NoError(t, err)
+		require.True(t, indexed)
+	}
+}
@@ -0,0 +1,75 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ValidatorLiveness (async): on the first poll of each head epoch, asks the node whether the watched
+// validators were live in the previous (now complete) epoch and stores the verdicts. The liveness
+// endpoint only serves the current and previous epoch, so an epoch not checked before the head moves
+// on two epochs is skipped rather than retried.
+type ValidatorLiveness struct {
+	Client *beacon.Client
+	Repo   storage.Repository
+	Log    zerolog.Logger
+	// LastCheckedEpoch is the previous epoch most recently claimed (runner-owned, ^0 before the first check).
+	LastCheckedEpoch *uint64
+	// Results overrides the log level of per-validator results by event type.
+	Results steps.ResultLevels
+}
+
+var _ Step = (*ValidatorLiveness)(nil)
+
+func (*ValidatorLiveness) Async() bool { return true }
+
+func (s *ValidatorLiveness) Run(e *steps.Env) (bool, error) {
+	if len(e.ValidatorIndices) == 0 {
+		return false, nil
+	}
+	headEpoch := e.HeadSlot / config.SlotsPerEpoch()
+	if headEpoch == 0 || *s.LastCheckedEpoch == headEpoch-1 {
+		return false, nil
+	}
+	*s.LastCheckedEpoch = headEpoch - 1
+	return true, nil
+}
+
+func (s *ValidatorLiveness) RunAsync(ctx context.Context, e *steps.Env) error {
+	epoch := e.HeadSlot/config.SlotsPerEpoch() - 1
+	live, err := s.Client.GetValidatorLiveness(ctx, epoch, e.ValidatorIndices)
+	if err != nil {
+		return fmt.Errorf("validator liveness epoch %d: %w", epoch, err)
+	}
+	now := time.Now().UTC()
+	rows := make([]*storage.ValidatorLiveness, 0, len(live))
+	for _, l := range live {
+		rows = append(rows, &storage.ValidatorLiveness{
+			ValidatorIndex: l.Index.Uint64(),
+			Epoch:          epoch,
+			IsLive:         l.IsLive,
+			CheckedAt:      now,
+		})
+		if !l.IsLive {
+			s.Results.Event(s.Log, config.ResultValidatorNotLive, zerolog.WarnLevel).
+				Uint64("validator_index", l.Index.Uint64()).
+				Uint64("epoch", epoch).
+				Msg("realtime: validator not live in epoch")
+		}
+	}
+	if err := s.Repo.SaveValidatorLiveness(ctx, rows); err != nil {
+		return err
+	}
+	state := &storage.SchedulerState{Kind: storage.SchedulerStateLivenessEpoch, Position: epoch, UpdatedAt: now}
+	if err := s.Repo.SaveSchedulerState(ctx, state); err != nil {
+		s.Log.Warn().Err(err).Uint64("epoch", epoch).Msg("realtime: persist liveness epoch failed")
+	}
+	return nil
+}
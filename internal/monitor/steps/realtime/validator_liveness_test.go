@@ -0,0 +1,70 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type livenessRepo struct {
+	*noop.Repository
+	saved []*storage.ValidatorLiveness
+}
+
+func (r *livenessRepo) SaveValidatorLiveness(_ context.Context, rows []*storage.ValidatorLiveness) error {
+	r.saved = append(r.saved, rows...)
+	return nil
+}
+
+func TestValidatorLiveness(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var ids []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ids))
+		var rows []string
+		for _, id := range ids {
+			rows = append(rows, fmt.Sprintf(`{"index":"%s","is_live":%t}`, id, id != "2"))
+		}
+		_, _ = fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(rows, ","))
+	}))
+	t.Cleanup(srv.Close)
+	client := beacon.NewClient(&config.Config{
+		BeaconNodeURL: srv.URL,
+		RateLimit:     config.RateLimitConf{RequestsPerSecond: 1000, Burst: 1000},
+		HTTP:          config.HTTPConf{TimeoutSeconds: 5, MaxIdleConns: 1, MaxRetries: 1},
+	})
+
+	repo := &livenessRepo{Repository: noop.NewRepository()}
+	last := ^uint64(0)
+	s := &ValidatorLiveness{Client: client, Repo: repo, Log: zerolog.Nop(), LastCheckedEpoch: &last}
+	e := &steps.Env{Ctx: context.Background(), HeadSlot: 32*7 + 3, ValidatorIndices: []uint64{1, 2}}
+
+	ok, err := s.Run(e)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, s.RunAsync(context.Background(), e))
+	require.Equal(t, "/eth/v1/validator/liveness/6", gotPath)
+	require.Len(t, repo.saved, 2)
+	require.Equal(t, uint64(6), repo.saved[0].Epoch)
+	require.True(t, repo.saved[0].IsLive)
+	require.False(t, repo.saved[1].IsLive)
+
+	// Same head epoch: already claimed.
+	e.HeadSlot++
+	ok, err = s.Run(e)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -1,5 +1,50 @@
 package realtime
 
+import "sort"
+
+// SampleValidators returns the group of validators polled in epoch when they are split into groups by
+// position in index order: the validator at sorted position i belongs to group i % groups, and epoch
+// polls group epoch % groups. Every validator is in exactly one group, so groups consecutive epochs
+// cover each validator once. groups <= 1 returns every validator.
+func SampleValidators(validators []uint64, epoch, groups uint64) []uint64 {
+	if groups <= 1 {
+		return append([]uint64(nil), validators...)
+	}
+	sorted := append([]uint64(nil), validators...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	group := epoch % groups
+	out := make([]uint64, 0, uint64(len(sorted))/groups+1)
+	for i := group; i < uint64(len(sorted)); i += groups {
+		out = append(out, sorted[i])
+	}
+	return out
+}
+
+// PrioritizeValidators returns sampled with the priority validators (priority_validators) that are in
+// validators moved to the front, in priority order. Priority validators are added even when sampling
+// left them out of this epoch's group, so they are polled every epoch and served by the first
+// per-validator beacon request.
+func PrioritizeValidators(validators, sampled, priority []uint64) []uint64 {
+	if len(priority) == 0 {
+		return sampled
+	}
+	prio := make(map[uint64]struct{}, len(priority))
+	out := make([]uint64, 0, len(sampled)+len(priority))
+	for _, v := range priority {
+		if _, dup := prio[v]; dup || !validatorIndexWatched(validators, v) {
+			continue
+		}
+		prio[v] = struct{}{}
+		out = append(out, v)
+	}
+	for _, v := range sampled {
+		if _, ok := prio[v]; !ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func validatorIndexWatched(validators []uint64, index uint64) bool {
 	for _, v := range validators {
 		if v == index {
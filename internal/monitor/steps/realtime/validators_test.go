@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/monitor/steps"
+)
+
+func TestSampleValidators_coversEachOncePerWindow(t *testing.T) {
+	validators := []uint64{40, 3, 17, 9, 1000, 5, 8, 21, 64, 2, 77}
+	const groups = 4
+
+	seen := make(map[uint64]int)
+	for epoch := uint64(100); epoch < 100+groups; epoch++ {
+		group := SampleValidators(validators, epoch, groups)
+		require.LessOrEqual(t, len(group), len(validators)/groups+1, "groups are balanced")
+		for _, v := range group {
+			seen[v]++
+		}
+	}
+	require.Len(t, seen, len(validators))
+	for v, n := range seen {
+		require.Equal(t, 1, n, "validator %d", v)
+	}
+
+	reordered := []uint64{1000, 2, 3, 5, 8, 9, 17, 21, 40, 64, 77}
+	require.Equal(t, SampleValidators(validators, 7, groups), SampleValidators(reordered, 7, groups), "rotation ignores list order")
+	require.Equal(t, SampleValidators(validators, 7, groups), SampleValidators(validators, 7+groups, groups))
+	require.ElementsMatch(t, validators, SampleValidators(validators, 7, 1))
+}
+
+func TestRealtimeEnvBootstrap_sampling(t *testing.T) {
+	head := uint64(10*32 + 5)
+	s := RealtimeEnvBootstrap{
+		GetHead:      func(context.Context) (uint64, error) { return head, nil },
+		Validators:   []uint64{1, 2, 3, 4, 5, 6},
+		SampleGroups: config.ValidatorSamplingConf{WindowSlots: 96}.Groups(),
+		Log:          zerolog.Nop(),
+	}
+	e := &steps.Env{Ctx: context.Background()}
+	_, err := s.Run(e)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 5}, e.ValidatorIndices, "epoch 10 polls group 10 %% 3")
+	require.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, e.WatchedValidators)
+}
+
+func TestRealtimeEnvBootstrap_priority(t *testing.T) {
+	s := RealtimeEnvBootstrap{
+		GetHead:      func(context.Context) (uint64, error) { return 10 * 32, nil },
+		Validators:   []uint64{1, 2, 3, 4, 5, 6},
+		SampleGroups: 3,
+		Priority:     []uint64{6, 99, 5},
+		Log:          zerolog.Nop(),
+	}
+	e := &steps.Env{Ctx: context.Background()}
+	_, err := s.Run(e)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{6, 5, 2}, e.ValidatorIndices, "priority validators first and outside the rotation; unwatched 99 ignored")
+	require.Equal(t, []uint64{6, 5, 1, 2, 3, 4}, e.WatchedValidators)
+}
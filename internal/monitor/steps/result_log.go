@@ -0,0 +1,36 @@
+package steps
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// ResultLevels maps a per-validator result event type (config.ResultLogEvents) to the level it is
+// logged at. Event types without an entry keep the level the step passes as default.
+type ResultLevels map[string]zerolog.Level
+
+// NewResultLevels converts the validated result_log_levels config; "off" disables the event's log.
+func NewResultLevels(conf map[string]string) ResultLevels {
+	levels := make(ResultLevels, len(conf))
+	for event, name := range conf {
+		if strings.EqualFold(name, "off") {
+			levels[event] = zerolog.Disabled
+			continue
+		}
+		if lvl, err := zerolog.ParseLevel(strings.ToLower(name)); err == nil {
+			levels[event] = lvl
+		}
+	}
+	return levels
+}
+
+// Event starts a log event for a result of the given type at its configured level, or at def. The
+// returned event is nil (a no-op) when disabled, so callers log unconditionally and keep writing
+// rows and metrics independently of it.
+func (l ResultLevels) Event(log zerolog.Logger, event string, def zerolog.Level) *zerolog.Event {
+	if lvl, ok := l[event]; ok {
+		def = lvl
+	}
+	return log.WithLevel(def)
+}
@@ -0,0 +1,25 @@
+package steps
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultLevels_Event(t *testing.T) {
+	var buf bytes.Buffer
+	log := zerolog.New(&buf)
+	levels := NewResultLevels(map[string]string{"attestation_included": "off", "attestation_missed": "ERROR"})
+
+	levels.Event(log, "attestation_included", zerolog.DebugLevel).Uint64("validator_index", 1).Msg("included")
+	require.Empty(t, buf.String(), "off suppresses the line")
+
+	levels.Event(log, "attestation_missed", zerolog.WarnLevel).Msg("missed")
+	require.Contains(t, buf.String(), `"level":"error"`)
+	buf.Reset()
+
+	levels.Event(log, "proposal_seen", zerolog.InfoLevel).Msg("seen")
+	require.Contains(t, buf.String(), `"level":"info"`, "unlisted events keep the default level")
+}
@@ -1,6 +1,13 @@
 package steps
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrSkipChain, returned from Run, ends the current chain pass without error logging; later steps do
+// not run and the runner continues with its next iteration.
+var ErrSkipChain = errors.New("skip remaining steps")
 
 // Step is the contract for each unit in a linear chain. The runner passes *Env so steps share iteration context.
 type Step interface {
@@ -2,6 +2,8 @@ package monitor
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,6 +18,9 @@ func InitBeaconNetworkClock(ctx context.Context, client *beacon.Client, network
 		return err
 	}
 
+	if err := verifyGenesisValidatorsRoot(network, genesis.Data.GenesisValidatorsRoot, log); err != nil {
+		return err
+	}
 	network.SetGenesisTime(time.Unix(int64(genesis.Data.GenesisTime.Uint64()), 0))
 
 	checkpoints, err := client.GetFinalityCheckpoints(ctx, "head")
@@ -34,6 +39,23 @@ func InitBeaconNetworkClock(ctx context.Context, client *beacon.Client, network
 	return nil
 }
 
+// verifyGenesisValidatorsRoot compares the node's genesis validators root with the configured network. A
+// mismatch is logged, and returned as an error under strict_network so pauli does not index another chain.
+func verifyGenesisValidatorsRoot(network *config.BlockchainNetwork, got string, log zerolog.Logger) error {
+	want, strict := network.ExpectedGenesisValidatorsRoot()
+	if want == "" || strings.EqualFold(got, want) {
+		return nil
+	}
+	if strict {
+		return fmt.Errorf("beacon node genesis_validators_root %s does not match expected %s (strict_network)", got, want)
+	}
+	log.Error().
+		Str("genesis_validators_root", got).
+		Str("expected", want).
+		Msg("beacon init: node serves a different chain than configured; continuing (strict_network is off)")
+	return nil
+}
+
 func (m *Monitor) logNodeSyncStatus(ctx context.Context) {
 	// Check node sync status.
 	synced, err := m.client.IsNodeSynced(ctx)
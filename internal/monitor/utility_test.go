@@ -0,0 +1,25 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+)
+
+func TestVerifyGenesisValidatorsRoot(t *testing.T) {
+	const mainnet = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	const other = "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"
+
+	lenient := config.NewBlockchainNetwork(&config.Config{Network: "mainnet"})
+	require.NoError(t, verifyGenesisValidatorsRoot(lenient, mainnet, zerolog.Nop()))
+	require.NoError(t, verifyGenesisValidatorsRoot(lenient, other, zerolog.Nop()), "a mismatch is only logged by default")
+
+	strict := config.NewBlockchainNetwork(&config.Config{Network: "mainnet", StrictNetwork: true})
+	require.NoError(t, verifyGenesisValidatorsRoot(strict, mainnet, zerolog.Nop()))
+	require.Error(t, verifyGenesisValidatorsRoot(strict, other, zerolog.Nop()))
+
+	unchecked := config.NewBlockchainNetwork(&config.Config{StrictNetwork: true})
+	require.NoError(t, verifyGenesisValidatorsRoot(unchecked, other, zerolog.Nop()))
+}
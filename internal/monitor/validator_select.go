@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+)
+
+// ResolveValidatorSelection lists the head state's validators and returns the indices matching sel,
+// ascending. It fetches every validator in one request, so it is meant for startup and reload only.
+func ResolveValidatorSelection(ctx context.Context, client *beacon.Client, sel config.ValidatorSelectConf) ([]uint64, error) {
+	if !sel.Enabled() {
+		return nil, nil
+	}
+	all, err := client.GetValidators(ctx, "head", nil)
+	if err != nil {
+		return nil, fmt.Errorf("resolve validator_select: %w", err)
+	}
+	var out []uint64
+	for i := range all {
+		if validatorSelected(sel, all[i].Validator.Pubkey, all[i].Validator.WithdrawalCredentials) {
+			out = append(out, all[i].Index.Uint64())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+// validatorSelected reports whether a validator with pubkey and withdrawal credentials matches sel.
+// sel values are normalized (lowercase, 0x-prefixed) by config validation.
+func validatorSelected(sel config.ValidatorSelectConf, pubkey, withdrawalCredentials string) bool {
+	pubkey = strings.ToLower(pubkey)
+	for _, p := range sel.PubkeyPrefixes {
+		if strings.HasPrefix(pubkey, p) {
+			return true
+		}
+	}
+	wc := strings.ToLower(withdrawalCredentials)
+	for _, want := range sel.WithdrawalCredentials {
+		if wc == want {
+			return true
+		}
+		// 20-byte execution address: the last 20 bytes of 0x01 / 0x02 credentials.
+		if len(want) == 2+40 && len(wc) == 2+64 && (strings.HasPrefix(wc, "0x01") || strings.HasPrefix(wc, "0x02")) &&
+			wc[2+24:] == want[2:] {
+			return true
+		}
+	}
+	return false
+}
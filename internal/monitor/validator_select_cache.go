@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/tharun/pauli/internal/config"
+)
+
+// validatorSelectionCache is the validator_select.cache_file content. The selectors are stored so a
+// changed validator_select never reuses indices resolved for another one.
+type validatorSelectionCache struct {
+	PubkeyPrefixes        []string  `json:"pubkey_prefixes"`
+	WithdrawalCredentials []string  `json:"withdrawal_credentials"`
+	Indices               []uint64  `json:"indices"`
+	ResolvedAt            time.Time `json:"resolved_at"`
+}
+
+// LoadValidatorSelection returns the indices cached in path for sel and when they were resolved.
+// ok is false when the file does not exist or was written for other selectors.
+func LoadValidatorSelection(path string, sel config.ValidatorSelectConf) (indices []uint64, resolvedAt time.Time, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("read validator_select cache: %w", err)
+	}
+	var c validatorSelectionCache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("parse validator_select cache %s: %w", path, err)
+	}
+	if !slices.Equal(c.PubkeyPrefixes, sel.PubkeyPrefixes) || !slices.Equal(c.WithdrawalCredentials, sel.WithdrawalCredentials) {
+		return nil, time.Time{}, false, nil
+	}
+	return c.Indices, c.ResolvedAt, true, nil
+}
+
+// SaveValidatorSelection writes indices resolved for sel to path, replacing it atomically.
+func SaveValidatorSelection(path string, sel config.ValidatorSelectConf, indices []uint64) error {
+	data, err := json.Marshal(validatorSelectionCache{
+		PubkeyPrefixes:        sel.PubkeyPrefixes,
+		WithdrawalCredentials: sel.WithdrawalCredentials,
+		Indices:               indices,
+		ResolvedAt:            time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("write validator_select cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write validator_select cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write validator_select cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write validator_select cache: %w", err)
+	}
+	return nil
+}
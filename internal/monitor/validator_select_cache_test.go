@@ -0,0 +1,34 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+)
+
+func TestValidatorSelectionCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "select.json")
+	sel := config.ValidatorSelectConf{PubkeyPrefixes: []string{"0xa1"}}
+
+	_, _, ok, err := LoadValidatorSelection(path, sel)
+	require.NoError(t, err)
+	require.False(t, ok, "missing file is a miss")
+
+	require.NoError(t, SaveValidatorSelection(path, sel, []uint64{3, 9}))
+	indices, at, ok, err := LoadValidatorSelection(path, sel)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []uint64{3, 9}, indices)
+	require.False(t, at.IsZero())
+
+	_, _, ok, err = LoadValidatorSelection(path, config.ValidatorSelectConf{PubkeyPrefixes: []string{"0xb2"}})
+	require.NoError(t, err)
+	require.False(t, ok, "cache for other selectors is ignored")
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, _, _, err = LoadValidatorSelection(path, sel)
+	require.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/config"
+)
+
+func TestValidatorSelected(t *testing.T) {
+	const addr = "0x00000000219ab540356cbb839cbe05303d7705fa"
+	wc01 := "0x010000000000000000000000" + addr[2:]
+	sel := config.ValidatorSelectConf{
+		PubkeyPrefixes:        []string{"0xa1b2"},
+		WithdrawalCredentials: []string{addr},
+	}
+
+	require.True(t, validatorSelected(sel, "0xA1B2ffff", "0x00"))
+	require.True(t, validatorSelected(sel, "0x9999", wc01))
+	require.False(t, validatorSelected(sel, "0x9999", "0x000000000000000000000000"+addr[2:]), "BLS credentials carry no address")
+	require.False(t, validatorSelected(sel, "0x9999", "0x01"+strings.Repeat("0", 62)))
+
+	full := config.ValidatorSelectConf{WithdrawalCredentials: []string{wc01}}
+	require.True(t, validatorSelected(full, "0x9999", wc01))
+}
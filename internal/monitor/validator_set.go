@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Validator set event sources.
+const (
+	validatorSetSourceStartup = "startup"
+	validatorSetSourceReload  = "reload"
+)
+
+// DiffValidators returns indices present only in next (added) and only in prev (removed), each ascending.
+// Duplicates are ignored.
+func DiffValidators(prev, next []uint64) (added, removed []uint64) {
+	inPrev := make(map[uint64]struct{}, len(prev))
+	for _, v := range prev {
+		inPrev[v] = struct{}{}
+	}
+	inNext := make(map[uint64]struct{}, len(next))
+	for _, v := range next {
+		if _, dup := inNext[v]; dup {
+			continue
+		}
+		inNext[v] = struct{}{}
+		if _, ok := inPrev[v]; !ok {
+			added = append(added, v)
+		}
+	}
+	for v := range inPrev {
+		if _, ok := inNext[v]; !ok {
+			removed = append(removed, v)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+	return added, removed
+}
+
+// ReloadValidators applies a new validators list at runtime (SIGHUP): the realtime runner stops scheduling
+// work for removed indices, and validator_added / validator_removed events are recorded.
+func (m *Monitor) ReloadValidators(ctx context.Context, next []uint64) error {
+	m.validatorsMu.Lock()
+	defer m.validatorsMu.Unlock()
+
+	added, removed := DiffValidators(m.cfg.Validators, next)
+	if len(added) == 0 && len(removed) == 0 {
+		m.logger.Info().Int("validators", len(next)).Msg("validator set unchanged on reload")
+		return nil
+	}
+
+	m.cfg.Validators = append([]uint64(nil), next...)
+	m.setWatched(next)
+	if m.realtimeR != nil {
+		m.realtimeR.SetValidators(next, removed)
+	}
+	if m.metricsRepo != nil {
+		m.metricsRepo.Forget(removed)
+	}
+	return m.recordValidatorSetChanges(ctx, added, removed, validatorSetSourceReload)
+}
+
+// syncValidatorSetOnStartup diffs the configured validators against the last recorded watched set,
+// so config edits made while pauli was stopped still appear in the audit trail.
+func (m *Monitor) syncValidatorSetOnStartup(ctx context.Context) error {
+	watched, err := m.repo.ListWatchedValidators(ctx)
+	if err != nil {
+		return err
+	}
+	added, removed := DiffValidators(watched, m.cfg.Validators)
+	return m.recordValidatorSetChanges(ctx, added, removed, validatorSetSourceStartup)
+}
+
+func (m *Monitor) recordValidatorSetChanges(ctx context.Context, added, removed []uint64, source string) error {
+	now := time.Now().UTC()
+	events := make([]*storage.ValidatorSetEvent, 0, len(added)+len(removed))
+	for _, v := range added {
+		events = append(events, &storage.ValidatorSetEvent{ValidatorIndex: v, Event: storage.ValidatorSetEventAdded, Source: source, Timestamp: now})
+	}
+	for _, v := range removed {
+		events = append(events, &storage.ValidatorSetEvent{ValidatorIndex: v, Event: storage.ValidatorSetEventRemoved, Source: source, Timestamp: now})
+	}
+	for _, ev := range events {
+		m.logger.Info().
+			Str("event", ev.Event).
+			Str("source", source).
+			Uint64("validator_index", ev.ValidatorIndex).
+			Msg("validator set changed")
+	}
+	if err := m.repo.SaveValidatorSetEvents(ctx, events); err != nil {
+		return fmt.Errorf("save validator set events: %w", err)
+	}
+	if m.cfg.PurgeRemovedValidators {
+		for _, v := range removed {
+			if err := m.repo.DeleteValidatorHistory(ctx, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValidators(t *testing.T) {
+	t.Parallel()
+
+	added, removed := DiffValidators([]uint64{3, 1, 2}, []uint64{2, 4, 4, 3, 5})
+	require.Equal(t, []uint64{4, 5}, added)
+	require.Equal(t, []uint64{1}, removed)
+
+	added, removed = DiffValidators(nil, nil)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+
+	added, removed = DiffValidators([]uint64{7}, []uint64{7})
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
@@ -0,0 +1,85 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/notifier"
+)
+
+// Watchdog raises a critical stale_data alert when Touch has not been called for maxSilence,
+// and a resolved alert once results flow again.
+type Watchdog struct {
+	maxSilence time.Duration
+	notify     notifier.Notifier
+	log        zerolog.Logger
+	now        func() time.Time
+
+	last  atomic.Int64 // unix nanos of the last Touch
+	stale bool         // only read/written by Run
+}
+
+// NewWatchdog creates a watchdog whose silence clock starts now.
+func NewWatchdog(maxSilence time.Duration, notify notifier.Notifier, log zerolog.Logger) *Watchdog {
+	w := &Watchdog{maxSilence: maxSilence, notify: notify, log: log, now: time.Now}
+	w.last.Store(w.now().UnixNano())
+	return w
+}
+
+// Touch records a successfully processed result.
+func (w *Watchdog) Touch() {
+	now := w.now()
+	w.last.Store(now.UnixNano())
+	metrics.LastResultTimestamp.Set(float64(now.Unix()))
+}
+
+// Run checks for silence until ctx is cancelled.
+func (w *Watchdog) Run(ctx context.Context) {
+	interval := w.maxSilence / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+func (w *Watchdog) check(ctx context.Context) {
+	silence := w.now().Sub(time.Unix(0, w.last.Load()))
+	switch {
+	case silence > w.maxSilence && !w.stale:
+		w.stale = true
+		metrics.Stale.Set(1)
+		w.send(ctx, notifier.Event{
+			Type:     notifier.EventStaleData,
+			Severity: notifier.SeverityCritical,
+			Message:  fmt.Sprintf("no indexing results for %s (limit %s)", silence.Truncate(time.Second), w.maxSilence),
+		})
+	case silence <= w.maxSilence && w.stale:
+		w.stale = false
+		metrics.Stale.Set(0)
+		w.send(ctx, notifier.Event{
+			Type:     notifier.EventStaleData,
+			Severity: notifier.SeverityCritical,
+			Message:  "indexing results are flowing again",
+			Resolved: true,
+		})
+	}
+}
+
+func (w *Watchdog) send(ctx context.Context, ev notifier.Event) {
+	if err := w.notify.Notify(ctx, ev); err != nil {
+		w.log.Error().Err(err).Str("alert", ev.Type).Msg("watchdog notification failed")
+	}
+}
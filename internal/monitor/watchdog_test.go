@@ -0,0 +1,45 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/notifier"
+)
+
+type recordingNotifier struct{ events []notifier.Event }
+
+func (*recordingNotifier) Name() string { return "recording" }
+
+func (r *recordingNotifier) Notify(_ context.Context, ev notifier.Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestWatchdog_firesOnceAndResolves(t *testing.T) {
+	rec := &recordingNotifier{}
+	now := time.Unix(1700000000, 0)
+	w := NewWatchdog(time.Minute, rec, zerolog.Nop())
+	w.now = func() time.Time { return now }
+	w.Touch()
+
+	now = now.Add(30 * time.Second)
+	w.check(context.Background())
+	require.Empty(t, rec.events)
+
+	now = now.Add(time.Minute)
+	w.check(context.Background())
+	w.check(context.Background())
+	require.Len(t, rec.events, 1, "alert fires once while stale")
+	require.Equal(t, notifier.EventStaleData, rec.events[0].Type)
+	require.Equal(t, notifier.SeverityCritical, rec.events[0].Severity)
+	require.False(t, rec.events[0].Resolved)
+
+	w.Touch()
+	w.check(context.Background())
+	require.Len(t, rec.events, 2)
+	require.True(t, rec.events[1].Resolved)
+}
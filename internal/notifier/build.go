@@ -0,0 +1,38 @@
+package notifier
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/tharun/pauli/internal/config"
+)
+
+// New builds the configured notifier chain. The log notifier is always included. Each destination
+// formats messages with its configured template (see DefaultTemplate). Repeated alerts are
+// collapsed by Dedup unless the cooldown is disabled.
+func New(cfg config.NotificationsConf, log zerolog.Logger) Notifier {
+	templated := func(n Notifier, text string) Notifier {
+		t, err := NewTemplated(n, text)
+		if err != nil {
+			log.Error().Err(err).Msg("notification template ignored")
+			return n
+		}
+		return t
+	}
+	chain := Multi{templated(Log{Logger: log}, cfg.Template)}
+	if cfg.WebhookURL != "" {
+		chain = append(chain, templated(NewWebhook(cfg.WebhookURL, cfg.Timeout()), cfg.Template))
+	}
+	if cfg.PagerDuty.RoutingKey != "" {
+		tmpl := cfg.PagerDuty.Template
+		if tmpl == "" {
+			tmpl = DefaultPagerDutyTemplate
+		}
+		chain = append(chain, templated(NewPagerDuty(cfg.PagerDuty.RoutingKey, cfg.PagerDuty.Severity, cfg.Timeout()), tmpl))
+	}
+	if cfg.Discord.WebhookURL != "" {
+		chain = append(chain, templated(NewDiscord(cfg.Discord.WebhookURL, cfg.Discord.BatchWindow(), cfg.Timeout(), log), cfg.Discord.Template))
+	}
+	if cooldown := cfg.Cooldown(); cooldown > 0 {
+		return NewDedup(chain, cooldown)
+	}
+	return chain
+}
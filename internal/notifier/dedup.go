@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Dedup collapses repeated alerts for the same (Type, ValidatorIndex) within Cooldown. The first
+// alert is delivered; repeats are counted and dropped until Cooldown has passed since the last
+// delivery, when one "still failing" reminder carrying the suppressed count is sent. A Resolved
+// event clears the key and is delivered with a summary of how long the condition lasted.
+type Dedup struct {
+	Next     Notifier
+	Cooldown time.Duration
+	// Now defaults to time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	active map[dedupKey]*dedupState
+}
+
+type dedupKey struct {
+	typ       string
+	validator uint64
+	hasIndex  bool
+}
+
+type dedupState struct {
+	first      time.Time
+	lastSent   time.Time
+	count      int
+	suppressed int
+}
+
+// NewDedup wraps next with a cooldown window.
+func NewDedup(next Notifier, cooldown time.Duration) *Dedup {
+	return &Dedup{Next: next, Cooldown: cooldown, active: make(map[dedupKey]*dedupState)}
+}
+
+func (d *Dedup) Name() string { return "dedup(" + d.Next.Name() + ")" }
+
+func (d *Dedup) Notify(ctx context.Context, ev Event) error {
+	ev, ok := d.filter(ev)
+	if !ok {
+		return nil
+	}
+	return d.Next.Notify(ctx, ev)
+}
+
+// filter updates the per-key state and returns the event to deliver, if any.
+func (d *Dedup) filter(ev Event) (Event, bool) {
+	now := time.Now()
+	if d.Now != nil {
+		now = d.Now()
+	}
+	key := dedupKey{typ: ev.Type}
+	if ev.ValidatorIndex != nil {
+		key.validator, key.hasIndex = *ev.ValidatorIndex, true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st := d.active[key]
+	if ev.Resolved {
+		if st == nil {
+			return ev, true
+		}
+		delete(d.active, key)
+		ev.Message = fmt.Sprintf("%s (cleared after %s, %d alerts)", ev.Message, now.Sub(st.first).Truncate(time.Second), st.count)
+		return ev, true
+	}
+	if st == nil {
+		d.active[key] = &dedupState{first: now, lastSent: now, count: 1}
+		return ev, true
+	}
+	st.count++
+	if now.Sub(st.lastSent) < d.Cooldown {
+		st.suppressed++
+		return ev, false
+	}
+	ev.Message = fmt.Sprintf("still failing since %s: %s (%d repeats suppressed)", st.first.UTC().Format(time.RFC3339), ev.Message, st.suppressed)
+	st.lastSent = now
+	st.suppressed = 0
+	return ev, true
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct{ events []Event }
+
+func (*recorder) Name() string { return "recorder" }
+
+func (r *recorder) Notify(_ context.Context, ev Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestDedup(t *testing.T) {
+	rec := &recorder{}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewDedup(rec, time.Hour)
+	d.Now = func() time.Time { return now }
+	ctx := context.Background()
+	v1, v2 := uint64(1), uint64(2)
+	miss := func(v *uint64) Event {
+		return Event{Type: "missed_attestation", ValidatorIndex: v, Message: "missed"}
+	}
+
+	require.NoError(t, d.Notify(ctx, miss(&v1)))
+	require.NoError(t, d.Notify(ctx, miss(&v2)))
+	for i := 0; i < 5; i++ {
+		now = now.Add(6 * time.Minute)
+		require.NoError(t, d.Notify(ctx, miss(&v1)))
+	}
+	require.Len(t, rec.events, 2)
+
+	// Cooldown elapsed: one reminder with the suppressed count.
+	now = now.Add(31 * time.Minute)
+	require.NoError(t, d.Notify(ctx, miss(&v1)))
+	require.Len(t, rec.events, 3)
+	require.Contains(t, rec.events[2].Message, "still failing")
+	require.Contains(t, rec.events[2].Message, "5 repeats suppressed")
+
+	// Clearing emits a summary and resets the key.
+	resolved := miss(&v1)
+	resolved.Resolved, resolved.Message = true, "attesting again"
+	require.NoError(t, d.Notify(ctx, resolved))
+	require.Contains(t, rec.events[3].Message, "cleared after 1h1m0s, 7 alerts")
+	require.NoError(t, d.Notify(ctx, miss(&v1)))
+	require.Len(t, rec.events, 5)
+	require.Equal(t, "missed", rec.events[4].Message)
+}
@@ -0,0 +1,186 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// discordMaxEmbeds is the per-message embed limit of Discord webhooks.
+const discordMaxEmbeds = 10
+
+// discordMaxRetries bounds how often one message is re-sent after a 429.
+const discordMaxRetries = 3
+
+// Embed colours by severity.
+const (
+	discordColorCritical = 0xE74C3C
+	discordColorWarning  = 0xF1C40F
+	discordColorInfo     = 0x3498DB
+	discordColorResolved = 0x2ECC71
+)
+
+// Discord posts alerts to a Discord webhook as embeds. Events arriving within BatchWindow are sent
+// together (up to ten embeds per message), so a mass event is one message rather than a flood.
+// Delivery happens in the background; failures are logged and the batch is dropped.
+type Discord struct {
+	URL         string
+	BatchWindow time.Duration
+	Client      *http.Client
+	Log         zerolog.Logger
+
+	mu      sync.Mutex
+	pending []Event
+	timer   *time.Timer
+}
+
+// NewDiscord returns a Discord notifier with a bounded request timeout.
+func NewDiscord(url string, batchWindow, timeout time.Duration, log zerolog.Logger) *Discord {
+	return &Discord{URL: url, BatchWindow: batchWindow, Client: &http.Client{Timeout: timeout}, Log: log}
+}
+
+func (*Discord) Name() string { return "discord" }
+
+// Notify queues ev for the next batch; it never blocks on the network.
+func (d *Discord) Notify(_ context.Context, ev Event) error {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = append(d.pending, ev)
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.BatchWindow, d.flush)
+	}
+	return nil
+}
+
+// flush sends everything queued so far.
+func (d *Discord) flush() {
+	d.mu.Lock()
+	events := d.pending
+	d.pending, d.timer = nil, nil
+	d.mu.Unlock()
+
+	ctx := context.Background()
+	for start := 0; start < len(events); start += discordMaxEmbeds {
+		end := min(start+discordMaxEmbeds, len(events))
+		if err := d.send(ctx, events[start:end]); err != nil {
+			d.Log.Error().Err(err).Int("alerts", end-start).Msg("discord notification failed")
+		}
+	}
+}
+
+type discordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+	Fields      []discordField `json:"fields,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func discordEmbedFor(ev Event) discordEmbed {
+	emb := discordEmbed{
+		Title:       ev.Type,
+		Description: ev.Message,
+		Timestamp:   ev.Time.UTC().Format(time.RFC3339),
+	}
+	switch {
+	case ev.Resolved:
+		emb.Title += " (resolved)"
+		emb.Color = discordColorResolved
+	case ev.Severity == SeverityCritical:
+		emb.Color = discordColorCritical
+	case ev.Severity == SeverityInfo:
+		emb.Color = discordColorInfo
+	default:
+		emb.Color = discordColorWarning
+	}
+	if ev.ValidatorIndex != nil {
+		emb.Fields = append(emb.Fields, discordField{Name: "Validator", Value: strconv.FormatUint(*ev.ValidatorIndex, 10), Inline: true})
+	}
+	if ev.Epoch != nil {
+		emb.Fields = append(emb.Fields, discordField{Name: "Epoch", Value: strconv.FormatUint(*ev.Epoch, 10), Inline: true})
+	}
+	if ev.Slot != nil {
+		emb.Fields = append(emb.Fields, discordField{Name: "Slot", Value: strconv.FormatUint(*ev.Slot, 10), Inline: true})
+	}
+	emb.Fields = append(emb.Fields, discordField{Name: "Severity", Value: string(ev.Severity), Inline: true})
+	return emb
+}
+
+// send posts one message, waiting out 429 responses (Retry-After / retry_after) a few times.
+func (d *Discord) send(ctx context.Context, events []Event) error {
+	msg := discordMessage{Embeds: make([]discordEmbed, 0, len(events))}
+	for _, ev := range events {
+		msg.Embeds = append(msg.Embeds, discordEmbedFor(ev))
+	}
+	if len(events) > 1 {
+		msg.Content = fmt.Sprintf("%d alerts", len(events))
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("discord: encode message: %w", err)
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("discord: create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := d.Client.Do(req)
+		if err != nil {
+			return fmt.Errorf("discord: %w", err)
+		}
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < discordMaxRetries:
+			wait := discordRetryAfter(resp.Header, b, attempt)
+			d.Log.Warn().Dur("retry_after", wait).Msg("discord rate limited")
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		default:
+			return fmt.Errorf("discord: unexpected status %d: %s", resp.StatusCode, string(b))
+		}
+	}
+}
+
+// discordRetryAfter reads the wait from the Retry-After header or the JSON retry_after field
+// (seconds), falling back to exponential backoff.
+func discordRetryAfter(h http.Header, body []byte, attempt int) time.Duration {
+	if s, err := strconv.ParseFloat(h.Get("Retry-After"), 64); err == nil && s > 0 {
+		return time.Duration(s * float64(time.Second))
+	}
+	var rl struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if json.Unmarshal(body, &rl) == nil && rl.RetryAfter > 0 {
+		return time.Duration(rl.RetryAfter * float64(time.Second))
+	}
+	return time.Second << attempt
+}
@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscordBatchesAndRetries(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		messages []discordMessage
+		limited  bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !limited {
+			limited = true
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var m discordMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+		messages = append(messages, m)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	d := NewDiscord(srv.URL, 20*time.Millisecond, time.Second, zerolog.Nop())
+	for i := uint64(0); i < 12; i++ {
+		v := i
+		require.NoError(t, d.Notify(context.Background(), Event{Type: "missed_attestation", Severity: SeverityWarning, ValidatorIndex: &v, Message: "missed"}))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(messages) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, messages[0].Embeds, 10)
+	require.Len(t, messages[1].Embeds, 2)
+	require.Equal(t, discordColorWarning, messages[0].Embeds[0].Color)
+	require.Equal(t, "0", messages[0].Embeds[0].Fields[0].Value)
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// Log writes events to the process log; it is always part of the notifier chain.
+type Log struct {
+	Logger zerolog.Logger
+}
+
+func (Log) Name() string { return "log" }
+
+func (l Log) Notify(_ context.Context, ev Event) error {
+	e := l.Logger.Warn()
+	switch {
+	case ev.Resolved:
+		e = l.Logger.Info()
+	case ev.Severity == SeverityCritical:
+		e = l.Logger.Error()
+	case ev.Severity == SeverityInfo:
+		e = l.Logger.Info()
+	}
+	if ev.ValidatorIndex != nil {
+		e = e.Uint64("validator_index", *ev.ValidatorIndex)
+	}
+	if ev.Epoch != nil {
+		e = e.Uint64("epoch", *ev.Epoch)
+	}
+	if ev.Slot != nil {
+		e = e.Uint64("slot", *ev.Slot)
+	}
+	e.Str("alert", ev.Type).
+		Str("severity", string(ev.Severity)).
+		Bool("resolved", ev.Resolved).
+		Msg(ev.Message)
+	return nil
+}
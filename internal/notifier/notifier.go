@@ -0,0 +1,66 @@
+// Package notifier delivers operational and validator alerts (log, webhook, PagerDuty, Discord) behind one interface.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Severity orders alerts for routing and formatting.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Event types raised by pauli.
+const (
+	EventStaleData        = "stale_data"
+	EventValidatorSlashed = "validator_slashed"
+	EventFinalityLag      = "finality_lag"
+	EventNodeDivergence   = "beacon_node_divergence"
+)
+
+// Event is one alert. Resolved marks the clearing of a condition previously raised with the same Type
+// (and ValidatorIndex, when set).
+type Event struct {
+	Type           string   `json:"type"`
+	Severity       Severity `json:"severity"`
+	ValidatorIndex *uint64  `json:"validator_index,omitempty"`
+	Epoch          *uint64  `json:"epoch,omitempty"`
+	Slot           *uint64  `json:"slot,omitempty"`
+	// Status is the validator status (Beacon API) when the alert concerns one.
+	Status string `json:"status,omitempty"`
+	// PenaltyGwei is the negative reward behind the alert, when there is one.
+	PenaltyGwei *int64    `json:"penalty_gwei,omitempty"`
+	Message     string    `json:"message"`
+	Resolved    bool      `json:"resolved"`
+	Time        time.Time `json:"time"`
+}
+
+// Notifier delivers events to one destination.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, ev Event) error
+}
+
+// Multi fans an event out to every notifier and joins their errors.
+type Multi []Notifier
+
+func (m Multi) Name() string { return "multi" }
+
+func (m Multi) Notify(ctx context.Context, ev Event) error {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// PagerDutyEventsURL is the Events API v2 enqueue endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty triggers and resolves incidents through the Events API v2. Incidents are keyed by
+// event type and validator, so a Resolved event closes the incident its trigger opened.
+type PagerDuty struct {
+	URL        string
+	RoutingKey string
+	// Severity overrides the PagerDuty severity per event type (critical, error, warning, info).
+	Severity map[string]string
+	Client   *http.Client
+}
+
+// NewPagerDuty returns a PagerDuty notifier with a bounded request timeout. Severity entries are
+// layered over the defaults (validator slashing is critical).
+func NewPagerDuty(routingKey string, severity map[string]string, timeout time.Duration) *PagerDuty {
+	sev := map[string]string{EventValidatorSlashed: "critical"}
+	for k, v := range severity {
+		sev[k] = v
+	}
+	return &PagerDuty{
+		URL:        PagerDutyEventsURL,
+		RoutingKey: routingKey,
+		Severity:   sev,
+		Client:     &http.Client{Timeout: timeout},
+	}
+}
+
+func (*PagerDuty) Name() string { return "pagerduty" }
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Timestamp     string `json:"timestamp,omitempty"`
+	Class         string `json:"class"`
+	CustomDetails Event  `json:"custom_details"`
+}
+
+// pagerDutyDedupKey identifies one condition: the event type plus the validator, when set.
+func pagerDutyDedupKey(ev Event) string {
+	key := "pauli/" + ev.Type
+	if ev.ValidatorIndex != nil {
+		key += "/" + strconv.FormatUint(*ev.ValidatorIndex, 10)
+	}
+	return key
+}
+
+func (p *PagerDuty) severity(ev Event) string {
+	if s, ok := p.Severity[ev.Type]; ok {
+		return s
+	}
+	switch ev.Severity {
+	case SeverityCritical:
+		return "critical"
+	case SeverityInfo:
+		return "info"
+	}
+	return "warning"
+}
+
+func (p *PagerDuty) Notify(ctx context.Context, ev Event) error {
+	msg := pagerDutyEvent{RoutingKey: p.RoutingKey, DedupKey: pagerDutyDedupKey(ev)}
+	if ev.Resolved {
+		msg.EventAction = "resolve"
+	} else {
+		msg.EventAction = "trigger"
+		msg.Payload = &pagerDutyPayload{
+			Summary:       ev.Message,
+			Source:        "pauli",
+			Severity:      p.severity(ev),
+			Class:         ev.Type,
+			CustomDetails: ev,
+		}
+		if !ev.Time.IsZero() {
+			msg.Payload.Timestamp = ev.Time.UTC().Format(time.RFC3339)
+		}
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("pagerduty: encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("pagerduty: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pagerduty: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pagerduty: unexpected status %d: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
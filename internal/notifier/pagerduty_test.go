@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerDutyTriggerAndResolve(t *testing.T) {
+	var got []pagerDutyEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev pagerDutyEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ev))
+		got = append(got, ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+
+	pd := NewPagerDuty("rk", map[string]string{EventStaleData: "error"}, time.Second)
+	pd.URL = srv.URL
+	ctx := context.Background()
+	v := uint64(42)
+
+	require.NoError(t, pd.Notify(ctx, Event{Type: EventValidatorSlashed, Severity: SeverityWarning, ValidatorIndex: &v, Message: "slashed"}))
+	require.NoError(t, pd.Notify(ctx, Event{Type: EventStaleData, Severity: SeverityCritical, Message: "stale"}))
+	require.NoError(t, pd.Notify(ctx, Event{Type: EventValidatorSlashed, ValidatorIndex: &v, Resolved: true}))
+
+	require.Len(t, got, 3)
+	require.Equal(t, "trigger", got[0].EventAction)
+	require.Equal(t, "rk", got[0].RoutingKey)
+	require.Equal(t, "pauli/validator_slashed/42", got[0].DedupKey)
+	require.Equal(t, "critical", got[0].Payload.Severity)
+	require.Equal(t, "error", got[1].Payload.Severity)
+	require.Equal(t, "resolve", got[2].EventAction)
+	require.Equal(t, got[0].DedupKey, got[2].DedupKey)
+	require.Nil(t, got[2].Payload)
+}
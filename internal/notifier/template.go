@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Default message templates. Templates are Go text/template executed against the Event, so every
+// Event field is available (pointer fields print their value and are false in if/with when unset),
+// e.g. `{{.Type}} {{with .ValidatorIndex}}validator {{.}} {{end}}{{with .PenaltyGwei}}-{{.}} gwei{{end}}`.
+const (
+	DefaultTemplate          = `{{.Message}}`
+	DefaultPagerDutyTemplate = `{{with .ValidatorIndex}}validator {{.}}: {{end}}{{.Message}}`
+)
+
+// Templated rewrites Event.Message with Tmpl before handing the event to Next. When the template
+// fails to execute, the event is still delivered with its original message.
+type Templated struct {
+	Next Notifier
+	Tmpl *template.Template
+}
+
+// NewTemplated parses text (DefaultTemplate when empty) and wraps next with it.
+func NewTemplated(next Notifier, text string) (*Templated, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New(next.Name()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s template: %w", next.Name(), err)
+	}
+	return &Templated{Next: next, Tmpl: tmpl}, nil
+}
+
+func (t *Templated) Name() string { return t.Next.Name() }
+
+func (t *Templated) Notify(ctx context.Context, ev Event) error {
+	var b strings.Builder
+	if err := t.Tmpl.Execute(&b, ev); err != nil {
+		return errors.Join(fmt.Errorf("%s template: %w", t.Next.Name(), err), t.Next.Notify(ctx, ev))
+	}
+	ev.Message = b.String()
+	return t.Next.Notify(ctx, ev)
+}
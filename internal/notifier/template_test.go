@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplated(t *testing.T) {
+	rec := &recorder{}
+	tn, err := NewTemplated(rec, `[{{.Severity}}] {{.Type}}{{with .ValidatorIndex}} validator={{.}}{{end}}{{with .Epoch}} epoch={{.}}{{end}}{{with .PenaltyGwei}} penalty={{.}}{{end}}: {{.Message}}`)
+	require.NoError(t, err)
+
+	v, epoch, penalty := uint64(7), uint64(100), int64(-1200)
+	require.NoError(t, tn.Notify(context.Background(), Event{
+		Type: "missed_attestation", Severity: SeverityWarning, ValidatorIndex: &v, Epoch: &epoch, PenaltyGwei: &penalty, Message: "missed",
+	}))
+	require.NoError(t, tn.Notify(context.Background(), Event{Type: EventStaleData, Severity: SeverityCritical, Message: "stale"}))
+	require.Equal(t, "[warning] missed_attestation validator=7 epoch=100 penalty=-1200: missed", rec.events[0].Message)
+	require.Equal(t, "[critical] stale_data: stale", rec.events[1].Message)
+
+	// Execution errors still deliver the original message.
+	bad, err := NewTemplated(rec, `{{.Nope}}`)
+	require.NoError(t, err)
+	require.Error(t, bad.Notify(context.Background(), Event{Message: "raw"}))
+	require.Equal(t, "raw", rec.events[2].Message)
+
+	_, err = NewTemplated(rec, `{{.Message`)
+	require.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GweiPerETH is the number of Gwei in one ether.
+const GweiPerETH = 1_000_000_000
+
+// GweiToETH renders gwei as an exact decimal ETH amount (nine fractional digits).
+func GweiToETH(gwei int64) string {
+	sign := ""
+	u := uint64(gwei)
+	if gwei < 0 {
+		sign = "-"
+		u = uint64(-gwei)
+	}
+	return fmt.Sprintf("%s%d.%09d", sign, u/GweiPerETH, u%GweiPerETH)
+}
+
+// GweiToFiat converts gwei at price (fiat per ETH).
+func GweiToFiat(gwei int64, price float64) float64 {
+	return float64(gwei) / GweiPerETH * price
+}
+
+// PriceSource fetches the ETH price from an HTTP endpoint and caches it for CacheTTL.
+// Field is a dot-separated path into the JSON response (e.g. "ethereum.usd" for CoinGecko's
+// simple/price); when empty the body must be a bare number.
+type PriceSource struct {
+	URL      string
+	Field    string
+	CacheTTL time.Duration
+	Client   *http.Client
+
+	mu        sync.Mutex
+	price     float64
+	fetchedAt time.Time
+}
+
+// NewPriceSource returns a price source with a bounded request timeout.
+func NewPriceSource(url, field string, cacheTTL, timeout time.Duration) *PriceSource {
+	return &PriceSource{URL: url, Field: field, CacheTTL: cacheTTL, Client: &http.Client{Timeout: timeout}}
+}
+
+// Price returns the cached price when it is younger than CacheTTL, otherwise fetches a new one.
+func (p *PriceSource) Price(ctx context.Context) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.fetchedAt.IsZero() && time.Since(p.fetchedAt) < p.CacheTTL {
+		return p.price, nil
+	}
+	price, err := p.fetch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	p.price, p.fetchedAt = price, time.Now()
+	return price, nil
+}
+
+func (p *PriceSource) fetch(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("price source: create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("price source: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, fmt.Errorf("price source: read body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("price source: unexpected status %d", resp.StatusCode)
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return 0, fmt.Errorf("price source: decode: %w", err)
+	}
+	if p.Field != "" {
+		for _, key := range strings.Split(p.Field, ".") {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return 0, fmt.Errorf("price source: field %q not found", p.Field)
+			}
+			v = obj[key]
+		}
+	}
+	var price float64
+	switch n := v.(type) {
+	case float64:
+		price = n
+	case string:
+		if price, err = strconv.ParseFloat(n, 64); err != nil {
+			return 0, fmt.Errorf("price source: field %q: %w", p.Field, err)
+		}
+	default:
+		return 0, fmt.Errorf("price source: field %q is not a number", p.Field)
+	}
+	if price <= 0 {
+		return 0, fmt.Errorf("price source: non-positive price %v", price)
+	}
+	return price, nil
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// ValidatorStateSource fetches a validator's state at a historical slot (beacon.Client).
+type ValidatorStateSource interface {
+	GetValidatorAtSlot(ctx context.Context, slot uint64, validatorID uint64) (*beacon.Validator, error)
+}
+
+// FillEffectiveBalanceGaps fetches each storage.EffectiveBalanceMissing point from the beacon state at its
+// epoch start slot and returns how many were filled. Epochs the node has no state for (pruned, or before
+// the validator existed) stay missing.
+func FillEffectiveBalanceGaps(ctx context.Context, src ValidatorStateSource, points []*storage.EffectiveBalancePoint) (int, error) {
+	filled := 0
+	for _, p := range points {
+		if p.Source != storage.EffectiveBalanceMissing {
+			continue
+		}
+		v, err := src.GetValidatorAtSlot(ctx, p.Epoch*config.SlotsPerEpoch(), p.ValidatorIndex)
+		if beacon.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return filled, err
+		}
+		p.EffectiveBalance = v.Validator.EffectiveBalance.Uint64()
+		p.Balance = v.Balance.Uint64()
+		p.Status = v.Status
+		p.Source = storage.EffectiveBalanceBeacon
+		filled++
+	}
+	return filled, nil
+}
+
+// WriteEffectiveBalance renders an effective balance series as a table or CSV, one row per epoch, with
+// epoch start times shown in loc (nil = UTC).
+func WriteEffectiveBalance(w io.Writer, points []*storage.EffectiveBalancePoint, format string, loc *time.Location) error {
+	header := []string{"epoch", "epoch_time", "effective_balance_gwei", "effective_balance_eth", "balance_gwei", "status", "source"}
+	records := make([][]string, 0, len(points))
+	for _, p := range points {
+		records = append(records, []string{
+			strconv.FormatUint(p.Epoch, 10),
+			formatTime(p.SlotTime, loc),
+			strconv.FormatUint(p.EffectiveBalance, 10),
+			GweiToETH(int64(p.EffectiveBalance)),
+			strconv.FormatUint(p.Balance, 10),
+			p.Status,
+			p.Source,
+		})
+	}
+	return writeRecords(w, header, records, format)
+}
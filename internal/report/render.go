@@ -0,0 +1,107 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats accepted by Write.
+const (
+	FormatTable = "table"
+	FormatCSV   = "csv"
+)
+
+// Fiat is an ETH price used to add a fiat column to the report.
+type Fiat struct {
+	Currency string  // e.g. "USD"
+	Price    float64 // fiat per ETH
+}
+
+// Write renders rows as a table or CSV. Components are in Gwei; the total is also shown in ETH,
+// plus fiat when fiat is non-nil.
+func Write(w io.Writer, rows []ValidatorRewards, format string, fiat *Fiat) error {
+	header := []string{
+		"validator_index", "epochs", "attestation_gwei",
+		"blocks", "block_gwei", "block_attestations_gwei", "block_sync_aggregate_gwei",
+		"block_proposer_slashings_gwei", "block_attester_slashings_gwei",
+		"total_gwei", "total_eth",
+	}
+	if fiat != nil {
+		header = append(header, "total_"+strings.ToLower(fiat.Currency))
+	}
+	records := make([][]string, 0, len(rows)+1)
+	for _, r := range rows {
+		total := r.TotalGwei()
+		rec := []string{
+			strconv.FormatUint(r.ValidatorIndex, 10),
+			strconv.Itoa(r.Epochs),
+			strconv.FormatInt(r.AttestationGwei, 10),
+			strconv.Itoa(r.Blocks),
+			strconv.FormatInt(r.BlockGwei, 10),
+			strconv.FormatInt(r.BlockAttestationsGwei, 10),
+			strconv.FormatInt(r.BlockSyncAggregateGwei, 10),
+			strconv.FormatInt(r.BlockProposerSlashingsGwei, 10),
+			strconv.FormatInt(r.BlockAttesterSlashingsGwei, 10),
+			strconv.FormatInt(total, 10),
+			GweiToETH(total),
+		}
+		if fiat != nil {
+			rec = append(rec, strconv.FormatFloat(GweiToFiat(total, fiat.Price), 'f', 2, 64))
+		}
+		records = append(records, rec)
+	}
+	return writeRecords(w, header, records, format)
+}
+
+// writeRecords renders header and records as a right-aligned table or CSV.
+func writeRecords(w io.Writer, header []string, records [][]string, format string) error {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(records); err != nil {
+			return err
+		}
+		return cw.Error()
+	case FormatTable, "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, strings.Join(header, "\t")+"\t")
+		for _, rec := range records {
+			fmt.Fprintln(tw, strings.Join(rec, "\t")+"\t")
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unsupported report format %q (use %s or %s)", format, FormatTable, FormatCSV)
+	}
+}
+
+// LoadTimezone resolves a -tz value against the Go tz database ("" is UTC). Only rendering uses it;
+// stored timestamps stay UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// formatTime renders t in loc as RFC 3339 with its offset, or "" when t is nil.
+func formatTime(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return ""
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
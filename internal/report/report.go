@@ -0,0 +1,122 @@
+// Package report aggregates stored rewards per validator for the pauli-report command.
+// Storage keeps raw Gwei; unit and currency conversion happen here at render time.
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// pageSize is the number of rows read per repository call.
+const pageSize = 5000
+
+// ValidatorRewards is one validator's income over an epoch range: attestation rewards plus proposer
+// rewards with their breakdown (blocks indexed before components were stored count toward
+// BlockGwei only).
+type ValidatorRewards struct {
+	ValidatorIndex  uint64
+	Epochs          int
+	HeadGwei        int64
+	SourceGwei      int64
+	TargetGwei      int64
+	AttestationGwei int64
+
+	Blocks                     int
+	BlockGwei                  int64
+	BlockAttestationsGwei      int64
+	BlockSyncAggregateGwei     int64
+	BlockProposerSlashingsGwei int64
+	BlockAttesterSlashingsGwei int64
+}
+
+// TotalGwei is attestation plus proposer income.
+func (r ValidatorRewards) TotalGwei() int64 {
+	return r.AttestationGwei + r.BlockGwei
+}
+
+// Summarize adds up rewards and proposed blocks per validator, ordered by validator index.
+func Summarize(rewards []*storage.AttestationReward, blocks []*storage.Block) []ValidatorRewards {
+	byIndex := make(map[uint64]*ValidatorRewards)
+	get := func(idx uint64) *ValidatorRewards {
+		row := byIndex[idx]
+		if row == nil {
+			row = &ValidatorRewards{ValidatorIndex: idx}
+			byIndex[idx] = row
+		}
+		return row
+	}
+	for _, r := range rewards {
+		row := get(r.ValidatorIndex)
+		row.Epochs++
+		row.HeadGwei += r.HeadReward
+		row.SourceGwei += r.SourceReward
+		row.TargetGwei += r.TargetReward
+		row.AttestationGwei += r.TotalReward
+	}
+	for _, b := range blocks {
+		row := get(b.ValidatorIndex)
+		row.Blocks++
+		row.BlockGwei += int64(b.Rewards)
+		if c := b.RewardComponents; c != nil {
+			row.BlockAttestationsGwei += int64(c.Attestations)
+			row.BlockSyncAggregateGwei += int64(c.SyncAggregate)
+			row.BlockProposerSlashingsGwei += int64(c.ProposerSlashings)
+			row.BlockAttesterSlashingsGwei += int64(c.AttesterSlashings)
+		}
+	}
+	out := make([]ValidatorRewards, 0, len(byIndex))
+	for _, row := range byIndex {
+		out = append(out, *row)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ValidatorIndex < out[j].ValidatorIndex })
+	return out
+}
+
+// LoadValidatorRewards reads attestation rewards and proposed blocks in [fromEpoch, toEpoch] for
+// validators (all validators when empty) and summarizes them.
+func LoadValidatorRewards(ctx context.Context, repo storage.Repository, validators []uint64, fromEpoch, toEpoch uint64) ([]ValidatorRewards, error) {
+	fromSlot := fromEpoch * config.SlotsPerEpoch()
+	toSlot := (toEpoch+1)*config.SlotsPerEpoch() - 1
+	var (
+		rewards []*storage.AttestationReward
+		blocks  []*storage.Block
+	)
+	load := func(idx *uint64) error {
+		for offset := 0; ; offset += pageSize {
+			page, err := repo.ListAttestationRewards(ctx, idx, fromEpoch, toEpoch, pageSize, offset)
+			if err != nil {
+				return fmt.Errorf("load rewards: %w", err)
+			}
+			rewards = append(rewards, page...)
+			if len(page) < pageSize {
+				break
+			}
+		}
+		for offset := 0; ; offset += pageSize {
+			page, err := repo.ListBlocks(ctx, idx, fromSlot, toSlot, pageSize, offset)
+			if err != nil {
+				return fmt.Errorf("load blocks: %w", err)
+			}
+			blocks = append(blocks, page...)
+			if len(page) < pageSize {
+				return nil
+			}
+		}
+	}
+	if len(validators) == 0 {
+		if err := load(nil); err != nil {
+			return nil, err
+		}
+		return Summarize(rewards, blocks), nil
+	}
+	for _, v := range validators {
+		if err := load(&v); err != nil {
+			return nil, err
+		}
+	}
+	return Summarize(rewards, blocks), nil
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/beacon"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+func TestGweiToETH(t *testing.T) {
+	require.Equal(t, "0.000000000", GweiToETH(0))
+	require.Equal(t, "1.500000000", GweiToETH(1_500_000_000))
+	require.Equal(t, "-0.000012345", GweiToETH(-12345))
+}
+
+func TestSummarizeAndWriteCSV(t *testing.T) {
+	rows := Summarize([]*storage.AttestationReward{
+		{ValidatorIndex: 2, Epoch: 10, TotalReward: 10_000},
+		{ValidatorIndex: 1, Epoch: 10, TotalReward: 12_000},
+		{ValidatorIndex: 2, Epoch: 11, TotalReward: -3_000},
+	}, []*storage.Block{
+		{ValidatorIndex: 2, SlotNumber: 330, Rewards: 5_000, RewardComponents: &storage.BlockRewardComponents{
+			Attestations: 4_000, SyncAggregate: 900, ProposerSlashings: 100,
+		}},
+		{ValidatorIndex: 2, SlotNumber: 340, Rewards: 1_000},
+	})
+	require.Equal(t, []ValidatorRewards{
+		{ValidatorIndex: 1, Epochs: 1, AttestationGwei: 12_000},
+		{ValidatorIndex: 2, Epochs: 2, AttestationGwei: 7_000, Blocks: 2, BlockGwei: 6_000,
+			BlockAttestationsGwei: 4_000, BlockSyncAggregateGwei: 900, BlockProposerSlashingsGwei: 100},
+	}, rows)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, rows, FormatCSV, &Fiat{Currency: "USD", Price: 2_000_000}))
+	require.Equal(t, "validator_index,epochs,attestation_gwei,blocks,block_gwei,block_attestations_gwei,"+
+		"block_sync_aggregate_gwei,block_proposer_slashings_gwei,block_attester_slashings_gwei,total_gwei,total_eth,total_usd\n"+
+		"1,1,12000,0,0,0,0,0,0,12000,0.000012000,24.00\n"+
+		"2,2,7000,2,6000,4000,900,100,0,13000,0.000013000,26.00\n", buf.String())
+}
+
+func TestPriceSourceCachesAndFails(t *testing.T) {
+	var calls atomic.Int32
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"ethereum":{"usd":3150.5}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	p := NewPriceSource(srv.URL, "ethereum.usd", time.Minute, time.Second)
+	price, err := p.Price(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3150.5, price)
+	_, err = p.Price(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), calls.Load())
+
+	fail.Store(true)
+	q := NewPriceSource(srv.URL, "ethereum.usd", time.Minute, time.Second)
+	_, err = q.Price(context.Background())
+	require.Error(t, err)
+}
+
+type stateSource map[uint64]*beacon.Validator
+
+func (s stateSource) GetValidatorAtSlot(_ context.Context, slot uint64, _ uint64) (*beacon.Validator, error) {
+	if v, ok := s[slot]; ok {
+		return v, nil
+	}
+	return nil, &beacon.HTTPResponseError{StatusCode: http.StatusNotFound}
+}
+
+func TestEffectiveBalanceSeriesFillsGaps(t *testing.T) {
+	epochTime := time.Date(2024, 3, 31, 0, 30, 0, 0, time.UTC)
+	points := storage.EffectiveBalanceSeries(7, 10, 12, map[uint64]*storage.EffectiveBalancePoint{
+		10: {ValidatorIndex: 7, Epoch: 10, EffectiveBalance: 32_000_000_000, SlotTime: &epochTime, Source: storage.EffectiveBalanceIndexed},
+	})
+	require.Len(t, points, 3)
+	require.Equal(t, storage.EffectiveBalanceMissing, points[1].Source)
+
+	consolidated := &beacon.Validator{Balance: 64_100_000_000, Status: "active_ongoing"}
+	consolidated.Validator.EffectiveBalance = 64_000_000_000
+	filled, err := FillEffectiveBalanceGaps(context.Background(), stateSource{11 * 32: consolidated}, points)
+	require.NoError(t, err)
+	require.Equal(t, 1, filled)
+	require.Equal(t, storage.EffectiveBalanceBeacon, points[1].Source)
+	require.Equal(t, uint64(64_000_000_000), points[1].EffectiveBalance)
+	require.Equal(t, storage.EffectiveBalanceMissing, points[2].Source, "no state at epoch 12")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteEffectiveBalance(&buf, points, FormatCSV, nil))
+	require.Equal(t, "epoch,epoch_time,effective_balance_gwei,effective_balance_eth,balance_gwei,status,source\n"+
+		"10,2024-03-31T00:30:00Z,32000000000,32.000000000,0,,indexed\n"+
+		"11,,64000000000,64.000000000,64100000000,active_ongoing,beacon\n"+
+		"12,,0,0.000000000,0,,missing\n", buf.String())
+
+	// Display timezone only changes rendering; the DST switch in Berlin that night is applied.
+	berlin, err := LoadTimezone("Europe/Berlin")
+	require.NoError(t, err)
+	require.Equal(t, "2024-03-31T01:30:00+01:00", formatTime(&epochTime, berlin))
+	later := epochTime.Add(2 * time.Hour)
+	require.Equal(t, "2024-03-31T04:30:00+02:00", formatTime(&later, berlin))
+	_, err = LoadTimezone("Mars/Olympus_Mons")
+	require.Error(t, err)
+}
@@ -0,0 +1,194 @@
+// Package changeonly skips validator epoch records that repeat the last written values
+// (snapshot_writes.change_only), cutting write volume for idle validators. A heartbeat record is still
+// written every snapshot_writes.full_snapshot_interval_slots so "no change" stays distinguishable
+// from "not indexed".
+package changeonly
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Fields compared between a record and the last one written for its validator.
+const (
+	FieldBalance          = "balance"
+	FieldEffectiveBalance = "effective_balance"
+	FieldStatus           = "status"
+)
+
+// last is the most recent record written for a validator and when it was last seen unchanged.
+type last struct {
+	epoch            uint64
+	slot             uint64
+	balance          uint64
+	effectiveBalance uint64
+	status           string
+	seenEpoch        uint64
+	seenAt           time.Time
+}
+
+// Repository wraps a storage.Repository and drops epoch records equal to the last written one
+// on the configured fields. A record carrying a non-zero attestation reward is always written, since
+// the epoch's rewards are not repeated anywhere else. State is in memory: the first record per validator after a restart
+// is always written, and records older than the last written epoch (backfill) are never skipped.
+type Repository struct {
+	storage.Repository
+
+	balance, effectiveBalance, status bool
+	fullInterval                      uint64
+
+	mu   sync.Mutex
+	last map[uint64]*last
+}
+
+// Options configures the write policy.
+type Options struct {
+	// Fields compared (all of FieldBalance, FieldEffectiveBalance and FieldStatus when empty).
+	Fields []string
+	// FullSnapshotIntervalSlots forces a write when the last written record is at least this many
+	// slots older, even if nothing changed (0 = only on change).
+	FullSnapshotIntervalSlots uint64
+}
+
+// NewRepository returns inner with change-only epoch record writes.
+func NewRepository(inner storage.Repository, opts Options) *Repository {
+	r := &Repository{Repository: inner, fullInterval: opts.FullSnapshotIntervalSlots, last: make(map[uint64]*last)}
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = []string{FieldBalance, FieldEffectiveBalance, FieldStatus}
+	}
+	for _, f := range fields {
+		switch f {
+		case FieldBalance:
+			r.balance = true
+		case FieldEffectiveBalance:
+			r.effectiveBalance = true
+		case FieldStatus:
+			r.status = true
+		}
+	}
+	return r
+}
+
+// SaveValidatorEpochRecords writes only records that changed since the last written epoch and
+// records the rest as seen.
+func (r *Repository) SaveValidatorEpochRecords(ctx context.Context, records []*storage.ValidatorEpochRecord) error {
+	r.mu.Lock()
+	changed := make([]*storage.ValidatorEpochRecord, 0, len(records))
+	for _, rec := range records {
+		if r.unchanged(rec) {
+			continue
+		}
+		changed = append(changed, rec)
+	}
+	r.mu.Unlock()
+
+	if len(changed) > 0 {
+		if err := r.Repository.SaveValidatorEpochRecords(ctx, changed); err != nil {
+			return err
+		}
+	}
+	if skipped := len(records) - len(changed); skipped > 0 {
+		metrics.SnapshotWritesSkipped.Add(float64(skipped))
+	}
+
+	now := time.Now().UTC()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range records {
+		l, ok := r.last[rec.ValidatorIndex]
+		if !ok {
+			l = &last{}
+			r.last[rec.ValidatorIndex] = l
+		}
+		if !ok || (rec.Epoch > l.epoch && !r.unchangedSince(l, rec)) {
+			l.epoch, l.slot, l.balance, l.effectiveBalance, l.status = rec.Epoch, rec.EpochStartSlot, rec.Balance, rec.EffectiveBalance, rec.Status
+		}
+		if rec.Epoch >= l.seenEpoch {
+			l.seenEpoch, l.seenAt = rec.Epoch, now
+		}
+	}
+	return nil
+}
+
+// LastSeen returns the newest epoch a record was indexed for the validator (written or skipped)
+// and when it was processed.
+func (r *Repository) LastSeen(validatorIndex uint64) (epoch uint64, at time.Time, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.last[validatorIndex]
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	return l.seenEpoch, l.seenAt, true
+}
+
+// Forget drops the remembered values, so the next record for each index is written.
+func (r *Repository) Forget(validatorIndices []uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range validatorIndices {
+		delete(r.last, v)
+	}
+}
+
+// PurgeValidator deletes the validator's rows and forgets its last written values.
+func (r *Repository) PurgeValidator(ctx context.Context, validatorIndex uint64) (int64, error) {
+	n, err := r.Repository.PurgeValidator(ctx, validatorIndex)
+	r.Forget([]uint64{validatorIndex})
+	return n, err
+}
+
+// unchanged reports whether rec is newer than the last written record, equal to it, and within
+// the full snapshot interval.
+func (r *Repository) unchanged(rec *storage.ValidatorEpochRecord) bool {
+	l, ok := r.last[rec.ValidatorIndex]
+	return ok && rec.Epoch > l.epoch && r.unchangedSince(l, rec)
+}
+
+// unchangedSince reports whether rec may be skipped relative to the last written record l.
+func (r *Repository) unchangedSince(l *last, rec *storage.ValidatorEpochRecord) bool {
+	if hasRewards(rec) {
+		return false
+	}
+	if r.fullInterval > 0 && rec.EpochStartSlot >= l.slot+r.fullInterval {
+		return false
+	}
+	return r.equal(l, rec)
+}
+
+func (r *Repository) equal(l *last, rec *storage.ValidatorEpochRecord) bool {
+	return (!r.balance || l.balance == rec.Balance) &&
+		(!r.effectiveBalance || l.effectiveBalance == rec.EffectiveBalance) &&
+		(!r.status || l.status == rec.Status)
+}
+
+// hasRewards reports whether rec carries a non-zero attestation reward component.
+func hasRewards(rec *storage.ValidatorEpochRecord) bool {
+	for _, v := range []*int64{rec.HeadReward, rec.SourceReward, rec.TargetReward, rec.TotalReward} {
+		if v != nil && *v != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Store wraps a storage.Store so Repository() returns the change-only repository.
+type Store struct {
+	storage.Store
+	repo *Repository
+}
+
+// NewStore wraps inner so epoch records are written only when they change (or the interval is due).
+func NewStore(inner storage.Store, opts Options) *Store {
+	return &Store{Store: inner, repo: NewRepository(inner.Repository(), opts)}
+}
+
+// Repository returns the change-only repository.
+func (s *Store) Repository() storage.Repository {
+	return s.repo
+}
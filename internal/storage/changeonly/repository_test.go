@@ -0,0 +1,95 @@
+package changeonly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type recordingRepo struct {
+	*noop.Repository
+	saved []uint64 // epochs written
+}
+
+func (r *recordingRepo) SaveValidatorEpochRecords(_ context.Context, records []*storage.ValidatorEpochRecord) error {
+	for _, rec := range records {
+		r.saved = append(r.saved, rec.Epoch)
+	}
+	return nil
+}
+
+func TestRepository_skipsUnchangedRecords(t *testing.T) {
+	inner := &recordingRepo{Repository: noop.NewRepository()}
+	repo := NewRepository(inner, Options{})
+	ctx := context.Background()
+	save := func(epoch, balance uint64, status string) {
+		require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+			{ValidatorIndex: 7, Epoch: epoch, Balance: balance, EffectiveBalance: 32, Status: status},
+		}))
+	}
+
+	save(10, 100, storage.StatusActiveOngoing)
+	save(11, 100, storage.StatusActiveOngoing) // unchanged
+	save(12, 101, storage.StatusActiveOngoing) // balance changed
+	save(13, 101, storage.StatusActiveOngoing) // unchanged
+	save(5, 101, storage.StatusActiveOngoing)  // older epoch (backfill) always written
+	save(14, 101, storage.StatusActiveExiting) // status changed
+	require.Equal(t, []uint64{10, 12, 5, 14}, inner.saved)
+
+	epoch, at, ok := repo.LastSeen(7)
+	require.True(t, ok)
+	require.Equal(t, uint64(14), epoch)
+	require.False(t, at.IsZero())
+
+	repo.Forget([]uint64{7})
+	save(15, 101, storage.StatusActiveExiting)
+	require.Equal(t, []uint64{10, 12, 5, 14, 15}, inner.saved, "first record after Forget is written")
+}
+
+func TestRepository_comparesConfiguredFieldsOnly(t *testing.T) {
+	inner := &recordingRepo{Repository: noop.NewRepository()}
+	repo := NewRepository(inner, Options{Fields: []string{FieldStatus}})
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 1, Epoch: 1, Balance: 1, Status: storage.StatusActiveOngoing},
+		{ValidatorIndex: 2, Epoch: 1, Balance: 1, Status: storage.StatusActiveOngoing},
+	}))
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 1, Epoch: 2, Balance: 2, Status: storage.StatusActiveOngoing},
+		{ValidatorIndex: 2, Epoch: 2, Balance: 1, Status: storage.StatusActiveExiting},
+	}))
+	require.Equal(t, []uint64{1, 1, 2}, inner.saved)
+}
+
+func TestRepository_fullSnapshotInterval(t *testing.T) {
+	inner := &recordingRepo{Repository: noop.NewRepository()}
+	repo := NewRepository(inner, Options{FullSnapshotIntervalSlots: 64})
+	ctx := context.Background()
+
+	for epoch := uint64(10); epoch <= 15; epoch++ {
+		require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+			{ValidatorIndex: 3, Epoch: epoch, EpochStartSlot: epoch * 32, Balance: 1, Status: storage.StatusActiveOngoing},
+		}))
+	}
+	require.Equal(t, []uint64{10, 12, 14}, inner.saved, "unchanged record written every 64 slots")
+}
+
+func TestRepository_writesRecordsWithRewards(t *testing.T) {
+	inner := &recordingRepo{Repository: noop.NewRepository()}
+	repo := NewRepository(inner, Options{Fields: []string{FieldStatus}})
+	ctx := context.Background()
+	save := func(epoch uint64, source int64) {
+		require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+			{ValidatorIndex: 3, Epoch: epoch, Balance: 100, Status: storage.StatusActiveOngoing, SourceReward: &source, TotalReward: &source},
+		}))
+	}
+
+	save(20, 0)
+	save(21, 12) // status and balance unchanged, but the epoch's rewards must be stored
+	save(22, 0)  // zero rewards of an idle validator are still skipped
+	require.Equal(t, []uint64{20, 21}, inner.saved)
+}
@@ -0,0 +1,48 @@
+package storage
+
+import "time"
+
+// Sources of an EffectiveBalancePoint.
+const (
+	// EffectiveBalanceIndexed points come from validator_epoch_records.
+	EffectiveBalanceIndexed = "indexed"
+	// EffectiveBalanceBeacon points were fetched from the beacon node's state at the epoch start slot.
+	EffectiveBalanceBeacon = "beacon"
+	// EffectiveBalanceMissing marks an epoch with no indexed row (a gap).
+	EffectiveBalanceMissing = "missing"
+)
+
+// EffectiveBalancePoint is one epoch of a validator's effective balance series.
+type EffectiveBalancePoint struct {
+	ValidatorIndex   uint64     `json:"validator_index"`
+	Epoch            uint64     `json:"epoch"`
+	EffectiveBalance uint64     `json:"effective_balance"` // Gwei (MaxEB aware, up to 2048 ETH)
+	Balance          uint64     `json:"balance"`           // Gwei
+	Status           string     `json:"status,omitempty"`
+	SlotTime         *time.Time `json:"slot_time,omitempty"` // epoch start slot time (UTC), indexed points only
+	Source           string     `json:"source"`
+}
+
+// EffectiveBalanceSeries returns one point per epoch in fromEpoch..toEpoch (ascending), taking values from
+// indexed (keyed by epoch) and marking epochs without a row as EffectiveBalanceMissing.
+func EffectiveBalanceSeries(validatorIndex, fromEpoch, toEpoch uint64, indexed map[uint64]*EffectiveBalancePoint) []*EffectiveBalancePoint {
+	if fromEpoch > toEpoch {
+		return nil
+	}
+	out := make([]*EffectiveBalancePoint, 0, toEpoch-fromEpoch+1)
+	for epoch := fromEpoch; ; epoch++ {
+		if p, ok := indexed[epoch]; ok {
+			out = append(out, p)
+		} else {
+			out = append(out, &EffectiveBalancePoint{
+				ValidatorIndex: validatorIndex,
+				Epoch:          epoch,
+				Source:         EffectiveBalanceMissing,
+			})
+		}
+		if epoch == toEpoch {
+			break
+		}
+	}
+	return out
+}
@@ -0,0 +1,18 @@
+package storage
+
+// Aggregates for epoch_processed.aggregate.
+const (
+	// AggregateBlocks is the proposed blocks and block rewards counters.
+	AggregateBlocks = "blocks"
+	// AggregateAttestationLiveness is the attestation hit and miss counters.
+	AggregateAttestationLiveness = "attestation_liveness"
+)
+
+// EpochProcessed marks one validator's row at slot as applied to an aggregate. Aggregate updates are
+// made only for marks that were not recorded before, so reprocessing an epoch is a no-op for them.
+type EpochProcessed struct {
+	Aggregate      string `json:"aggregate"`
+	Epoch          uint64 `json:"epoch"`
+	ValidatorIndex uint64 `json:"validator_index"`
+	Slot           uint64 `json:"slot"`
+}
@@ -0,0 +1,203 @@
+// Package jsonl tees indexed rows to a writer as JSON Lines (output_jsonl) after the wrapped
+// repository persists them. See doc/jsonl-output.md for the event schema.
+package jsonl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SchemaVersion is bumped only on breaking changes to an event's data shape.
+const SchemaVersion = 1
+
+// Event types written in the "type" field.
+const (
+	EventValidatorEpochRecord = "validator_epoch_record"
+	EventBlock                = "block"
+	EventAttestationDuty      = "attestation_duty"
+	EventAttestationLiveness  = "attestation_liveness"
+	EventProposerDuty         = "proposer_duty"
+	EventDutyMismatch         = "duty_mismatch"
+	EventValidatorLiveness    = "validator_liveness"
+	EventValidatorSet         = "validator_set_event"
+)
+
+// Event is one JSON line.
+type Event struct {
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+	Data    any    `json:"data"`
+}
+
+// Repository wraps a storage.Repository and emits one line per saved row.
+// Reads and indexer progress are delegated unchanged.
+type Repository struct {
+	storage.Repository
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRepository returns inner with save methods teed to w.
+func NewRepository(inner storage.Repository, w io.Writer) *Repository {
+	return &Repository{Repository: inner, enc: json.NewEncoder(w)}
+}
+
+// SaveValidatorEpochRecords persists records, then emits validator_epoch_record events.
+func (r *Repository) SaveValidatorEpochRecords(ctx context.Context, records []*storage.ValidatorEpochRecord) error {
+	if err := r.Repository.SaveValidatorEpochRecords(ctx, records); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range records {
+		if err := r.write(EventValidatorEpochRecord, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveBlock persists row, then emits a block event.
+func (r *Repository) SaveBlock(ctx context.Context, row *storage.Block) error {
+	if err := r.Repository.SaveBlock(ctx, row); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.write(EventBlock, row)
+}
+
+// SaveBlocks persists rows, then emits one block event per row.
+func (r *Repository) SaveBlocks(ctx context.Context, rows []*storage.Block) error {
+	if err := r.Repository.SaveBlocks(ctx, rows); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range rows {
+		if err := r.write(EventBlock, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveAttestationDuties persists duties, then emits attestation_duty events.
+func (r *Repository) SaveAttestationDuties(ctx context.Context, duties []*storage.AttestationDuty) error {
+	if err := r.Repository.SaveAttestationDuties(ctx, duties); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range duties {
+		if err := r.write(EventAttestationDuty, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveProposerDuties persists duties, then emits proposer_duty events.
+func (r *Repository) SaveProposerDuties(ctx context.Context, duties []*storage.ProposerDuty) error {
+	if err := r.Repository.SaveProposerDuties(ctx, duties); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range duties {
+		if err := r.write(EventProposerDuty, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveAttestationLiveness persists rows, then emits attestation_liveness events.
+func (r *Repository) SaveAttestationLiveness(ctx context.Context, rows []*storage.AttestationLiveness) error {
+	if err := r.Repository.SaveAttestationLiveness(ctx, rows); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range rows {
+		if err := r.write(EventAttestationLiveness, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveValidatorLiveness persists rows, then emits validator_liveness events.
+func (r *Repository) SaveValidatorLiveness(ctx context.Context, rows []*storage.ValidatorLiveness) error {
+	if err := r.Repository.SaveValidatorLiveness(ctx, rows); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range rows {
+		if err := r.write(EventValidatorLiveness, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveDutyMismatches persists rows, then emits duty_mismatch events.
+func (r *Repository) SaveDutyMismatches(ctx context.Context, rows []*storage.DutyMismatch) error {
+	if err := r.Repository.SaveDutyMismatches(ctx, rows); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range rows {
+		if err := r.write(EventDutyMismatch, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveValidatorSetEvents persists events, then emits validator_set_event lines.
+func (r *Repository) SaveValidatorSetEvents(ctx context.Context, events []*storage.ValidatorSetEvent) error {
+	if err := r.Repository.SaveValidatorSetEvents(ctx, events); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ev := range events {
+		if err := r.write(EventValidatorSet, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write must be called with mu held so lines from concurrent workers never interleave.
+func (r *Repository) write(eventType string, data any) error {
+	if err := r.enc.Encode(Event{Type: eventType, Version: SchemaVersion, Data: data}); err != nil {
+		return fmt.Errorf("write jsonl %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// Store wraps a storage.Store so Repository() returns the teeing repository.
+type Store struct {
+	storage.Store
+	repo *Repository
+}
+
+// NewStore wraps inner so every saved row is also written to w.
+func NewStore(inner storage.Store, w io.Writer) *Store {
+	return &Store{Store: inner, repo: NewRepository(inner.Repository(), w)}
+}
+
+// Repository returns the teeing repository.
+func (s *Store) Repository() storage.Repository {
+	return s.repo
+}
@@ -0,0 +1,43 @@
+package jsonl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+func TestRepository_emitsOneLinePerRow(t *testing.T) {
+	var buf bytes.Buffer
+	repo := NewRepository(noop.NewRepository(), &buf)
+
+	err := repo.SaveValidatorEpochRecords(context.Background(), []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 1, Epoch: 10, Status: storage.StatusActiveOngoing, Balance: 32000000000},
+		{ValidatorIndex: 2, Epoch: 10, Status: storage.StatusActiveOngoing, Balance: 31000000000},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveBlock(context.Background(), &storage.Block{ValidatorIndex: 7, SlotNumber: 320}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var ev struct {
+		Type    string          `json:"type"`
+		Version int             `json:"version"`
+		Data    json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ev))
+	require.Equal(t, EventValidatorEpochRecord, ev.Type)
+	require.Equal(t, SchemaVersion, ev.Version)
+
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &ev))
+	require.Equal(t, EventBlock, ev.Type)
+	var block storage.Block
+	require.NoError(t, json.Unmarshal(ev.Data, &block))
+	require.Equal(t, uint64(320), block.SlotNumber)
+}
@@ -4,27 +4,33 @@ import "time"
 
 // ValidatorEpochRecord is the canonical per-validator epoch row (balance + optional attestation rewards).
 type ValidatorEpochRecord struct {
-	ValidatorIndex   uint64    `json:"validator_index"`
-	Epoch            uint64    `json:"epoch"`
-	EpochStartSlot   uint64    `json:"epoch_start_slot"`
-	Status           string    `json:"status"`
-	Balance          uint64    `json:"balance"`
-	EffectiveBalance uint64    `json:"effective_balance"`
-	HeadReward       *int64    `json:"head_reward,omitempty"`
-	SourceReward     *int64    `json:"source_reward,omitempty"`
-	TargetReward     *int64    `json:"target_reward,omitempty"`
-	TotalReward      *int64    `json:"total_reward,omitempty"`
-	IndexedAt        time.Time `json:"indexed_at"`
+	ValidatorIndex      uint64     `json:"validator_index"`
+	Epoch               uint64     `json:"epoch"`
+	EpochStartSlot      uint64     `json:"epoch_start_slot"`
+	Status              string     `json:"status"`
+	Balance             uint64     `json:"balance"`
+	EffectiveBalance    uint64     `json:"effective_balance"`
+	ActivationEpoch     *uint64    `json:"activation_epoch,omitempty"`   // nil while not scheduled (FAR_FUTURE_EPOCH)
+	ExitEpoch           *uint64    `json:"exit_epoch,omitempty"`         // nil while not scheduled (FAR_FUTURE_EPOCH)
+	WithdrawableEpoch   *uint64    `json:"withdrawable_epoch,omitempty"` // nil while not scheduled (FAR_FUTURE_EPOCH)
+	HeadReward          *int64     `json:"head_reward,omitempty"`
+	SourceReward        *int64     `json:"source_reward,omitempty"`
+	TargetReward        *int64     `json:"target_reward,omitempty"`
+	TotalReward         *int64     `json:"total_reward,omitempty"`
+	ExecutionOptimistic bool       `json:"execution_optimistic"` // Source response was not backed by a verified execution payload
+	SlotTime            *time.Time `json:"slot_time,omitempty"`  // Chain time of epoch_start_slot; nil when genesis is unknown
+	IndexedAt           time.Time  `json:"indexed_at"`
 }
 
 // ValidatorSnapshot is the API view of epoch balance state (slot = epoch start slot).
 type ValidatorSnapshot struct {
-	ValidatorIndex   uint64    `json:"validator_index"`
-	Slot             uint64    `json:"slot"`
-	Status           string    `json:"status"`
-	Balance          uint64    `json:"balance"`           // Actual balance in Gwei
-	EffectiveBalance uint64    `json:"effective_balance"` // Effective balance in Gwei (MaxEB aware, up to 2048 ETH)
-	Timestamp        time.Time `json:"timestamp"`
+	ValidatorIndex   uint64     `json:"validator_index"`
+	Slot             uint64     `json:"slot"`
+	Status           string     `json:"status"`
+	Balance          uint64     `json:"balance"`             // Actual balance in Gwei
+	EffectiveBalance uint64     `json:"effective_balance"`   // Effective balance in Gwei (MaxEB aware, up to 2048 ETH)
+	SlotTime         *time.Time `json:"slot_time,omitempty"` // Chain time of slot; Timestamp is ingestion time
+	Timestamp        time.Time  `json:"timestamp"`
 }
 
 // AttestationReward represents a validator's attestation rewards for an epoch.
@@ -47,15 +53,25 @@ type BlockSyncCommitteeRewards struct {
 
 // Block is one indexed canonical beacon block at slot_number (proposer CL rewards and optional EL fee fields).
 type Block struct {
-	ValidatorIndex           uint64                    `json:"validator_index"`
-	ValidatorPubkey          string                    `json:"validator_pubkey"`
-	SlotNumber               uint64                    `json:"slot_number"`
-	BlockNumber              *uint64                   `json:"block_number,omitempty"`                // Execution layer block number when available
-	Rewards                  uint64                    `json:"rewards"`                               // Proposer reward total (gwei)
-	ExecutionPriorityFeesWei *string                   `json:"execution_priority_fees_wei,omitempty"` // Sum of priority tips (wei), decimal string
-	ExecutionMevFeesWei      *string                   `json:"execution_mev_fees_wei,omitempty"`      // Reserved; NULL in v1
+	ValidatorIndex           uint64                     `json:"validator_index"`
+	ValidatorPubkey          string                     `json:"validator_pubkey"`
+	SlotNumber               uint64                     `json:"slot_number"`
+	BlockNumber              *uint64                    `json:"block_number,omitempty"`                // Execution layer block number when available
+	Rewards                  uint64                     `json:"rewards"`                               // Proposer reward total (gwei)
+	ExecutionPriorityFeesWei *string                    `json:"execution_priority_fees_wei,omitempty"` // Sum of priority tips (wei), decimal string
+	ExecutionMevFeesWei      *string                    `json:"execution_mev_fees_wei,omitempty"`      // Reserved; NULL in v1
 	SyncCommitteeRewards     *BlockSyncCommitteeRewards `json:"sync_committee_rewards,omitempty"`
-	Timestamp                time.Time                 `json:"timestamp"`
+	RewardComponents         *BlockRewardComponents     `json:"reward_components,omitempty"` // Breakdown of Rewards; nil for older rows
+	ExecutionOptimistic      bool                       `json:"execution_optimistic"`        // Header or rewards response was execution-optimistic
+	Timestamp                time.Time                  `json:"timestamp"`
+}
+
+// BlockRewardComponents splits a block's proposer reward (gwei) by source.
+type BlockRewardComponents struct {
+	Attestations      uint64 `json:"attestations"`
+	SyncAggregate     uint64 `json:"sync_aggregate"`
+	ProposerSlashings uint64 `json:"proposer_slashings"`
+	AttesterSlashings uint64 `json:"attester_slashings"`
 }
 
 // SyncCommitteeReward is one row of sync committee reward for a validator at a beacon block slot.
@@ -68,6 +84,107 @@ type SyncCommitteeReward struct {
 	Timestamp           time.Time `json:"timestamp"`
 }
 
+// AttestationDuty is one attester duty assignment for a watched validator (from /eth/v1/validator/duties/attester).
+type AttestationDuty struct {
+	ValidatorIndex    uint64     `json:"validator_index"`
+	Epoch             uint64     `json:"epoch"`
+	Slot              uint64     `json:"slot"`
+	CommitteeIndex    uint64     `json:"committee_index"`
+	CommitteePosition uint64     `json:"committee_position"` // validator_committee_index; always < CommitteeLength
+	CommitteeLength   uint64     `json:"committee_length"`
+	CommitteesAtSlot  uint64     `json:"committees_at_slot"`
+	DependentRoot     string     `json:"dependent_root"`
+	SlotTime          *time.Time `json:"slot_time,omitempty"` // Chain time of slot; nil when genesis is unknown
+	IndexedAt         time.Time  `json:"indexed_at"`
+}
+
+// AttestationLiveness is the provisional inclusion result for one attester duty, checked a few slots
+// after the duty slot. RewardIncluded is filled once the epoch's attestation rewards are indexed
+// (true when source_reward > 0, i.e. a timely attestation was rewarded).
+type AttestationLiveness struct {
+	ValidatorIndex uint64     `json:"validator_index"`
+	Epoch          uint64     `json:"epoch"`
+	Slot           uint64     `json:"slot"`
+	Included       bool       `json:"included"`
+	InclusionSlot  *uint64    `json:"inclusion_slot,omitempty"`
+	CheckedAt      time.Time  `json:"checked_at"`
+	RewardIncluded *bool      `json:"reward_included,omitempty"`
+	ReconciledAt   *time.Time `json:"reconciled_at,omitempty"`
+}
+
+// ValidatorLiveness is the beacon node's liveness verdict for a validator in an epoch
+// (POST /eth/v1/validator/liveness), a fast miss signal without scanning blocks.
+type ValidatorLiveness struct {
+	ValidatorIndex uint64    `json:"validator_index"`
+	Epoch          uint64    `json:"epoch"`
+	IsLive         bool      `json:"is_live"`
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// Proposer duty statuses.
+const (
+	ProposalScheduled  = "scheduled"  // slot not checked yet
+	ProposalProposed   = "proposed"   // the validator's block is canonical at the slot
+	ProposalMissed     = "missed"     // no canonical block at the slot
+	ProposalReassigned = "reassigned" // a reorg reshuffled proposers; another validator's block is canonical
+)
+
+// ProposerDuty is one block proposal duty of a watched validator (from /eth/v1/validator/duties/proposer)
+// and, once checked a few slots after its slot, its outcome. Rows are provisional until Finalized, when
+// the slot was checked again after its epoch finalized, so a reorg near the slot cannot leave a wrong
+// status. A missed proposal has no explicit protocol penalty; it is recorded with no reward.
+type ProposerDuty struct {
+	ValidatorIndex uint64     `json:"validator_index"`
+	Epoch          uint64     `json:"epoch"`
+	Slot           uint64     `json:"slot"`
+	DependentRoot  string     `json:"dependent_root"`
+	Status         string     `json:"status"`
+	BlockRoot      *string    `json:"block_root,omitempty"`
+	RewardGwei     *uint64    `json:"reward_gwei,omitempty"` // block rewards total (/eth/v1/beacon/rewards/blocks)
+	Finalized      bool       `json:"finalized"`
+	CheckedAt      *time.Time `json:"checked_at,omitempty"`
+	IndexedAt      time.Time  `json:"indexed_at"`
+}
+
+// Duty mismatch reasons.
+const (
+	DutyMismatchPosition        = "position_mismatch"   // validator found in the slot's committees at another index/position
+	DutyMismatchNotInCommittees = "not_in_committees"   // validator absent from every committee at the duty slot
+	DutyMismatchMissingDuty     = "missing_from_duties" // validator in a committee but absent from the duties response
+	// Duty anomalies (attestation_duties.anomalies): the duties response itself is inconsistent.
+	DutyAnomalyMultipleCommittees = "multiple_committees" // more than one duty for the validator in the epoch (actual = the extra one)
+	DutyAnomalyNoCommittee        = "no_committee"        // no duty although the validator had one the epoch before (expected = that one)
+)
+
+// DutyMismatch records a stored attester duty whose committee index/position disagrees with the
+// committees at the duty slot once the attestation was included (a pipeline bug or a reorg that
+// changed the shuffling), or a duty anomaly: a validator in zero or several committees in an epoch.
+type DutyMismatch struct {
+	ValidatorIndex         uint64    `json:"validator_index"`
+	Epoch                  uint64    `json:"epoch"`
+	Slot                   uint64    `json:"slot"`
+	Reason                 string    `json:"reason"` // a DutyMismatch* or DutyAnomaly* reason
+	ExpectedCommitteeIndex uint64    `json:"expected_committee_index"`
+	ExpectedPosition       uint64    `json:"expected_position"`
+	ActualCommitteeIndex   *uint64   `json:"actual_committee_index,omitempty"`
+	ActualPosition         *uint64   `json:"actual_position,omitempty"`
+	DetectedAt             time.Time `json:"detected_at"`
+}
+
+// Validator set event types (audit trail of when monitoring started/stopped for an index).
+const (
+	ValidatorSetEventAdded   = "validator_added"
+	ValidatorSetEventRemoved = "validator_removed"
+)
+
+// ValidatorSetEvent records a validator index entering or leaving the watched validators list.
+type ValidatorSetEvent struct {
+	ValidatorIndex uint64    `json:"validator_index"`
+	Event          string    `json:"event"`  // ValidatorSetEventAdded or ValidatorSetEventRemoved
+	Source         string    `json:"source"` // "startup" or "reload"
+	Timestamp      time.Time `json:"timestamp"`
+}
+
 // ValidatorStatus constants from Beacon API
 const (
 	StatusPendingInitialized = "pending_initialized"
@@ -92,3 +209,19 @@ func IsActiveStatus(status string) bool {
 func IsSlashedStatus(status string) bool {
 	return status == StatusActiveSlashed || status == StatusExitedSlashed
 }
+
+// RawResponse is a gzip-compressed reward or validator status response body kept for audit
+// (raw_responses.enabled), keyed by endpoint, epoch and fetch time.
+type RawResponse struct {
+	// Endpoint is the request path with ids replaced by placeholders; Path is the exact request path.
+	Endpoint string `json:"endpoint"`
+	Path     string `json:"path"`
+	// Epoch is nil when the request named a state or block by root or by head/finalized.
+	Epoch     *uint64   `json:"epoch,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+	// Request is the JSON request body of a POST (validator indices), uncompressed.
+	Request []byte `json:"request,omitempty"`
+	// Body is the gzip-compressed response JSON; BodySize is its uncompressed length.
+	Body     []byte `json:"body"`
+	BodySize int    `json:"body_size"`
+}
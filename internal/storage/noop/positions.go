@@ -0,0 +1,70 @@
+package noop
+
+import (
+	"slices"
+	"sort"
+)
+
+// positions is a set of indexed slots or epochs kept as sorted, disjoint, non-adjacent runs, so memory
+// grows with the number of gaps rather than with every position ever marked.
+type positions struct {
+	runs []positionRun
+}
+
+// positionRun is an inclusive range of marked positions.
+type positionRun struct {
+	from, to uint64
+}
+
+// find returns the index of the first run ending at or after p.
+func (s *positions) find(p uint64) int {
+	return sort.Search(len(s.runs), func(i int) bool { return s.runs[i].to >= p })
+}
+
+func (s *positions) add(p uint64) {
+	i := s.find(p)
+	if i < len(s.runs) && s.runs[i].from <= p {
+		return
+	}
+	// p falls between runs[i-1] and runs[i]; join whichever it touches.
+	joinPrev := i > 0 && s.runs[i-1].to+1 == p
+	joinNext := i < len(s.runs) && p+1 == s.runs[i].from
+	switch {
+	case joinPrev && joinNext:
+		s.runs[i-1].to = s.runs[i].to
+		s.runs = slices.Delete(s.runs, i, i+1)
+	case joinPrev:
+		s.runs[i-1].to = p
+	case joinNext:
+		s.runs[i].from = p
+	default:
+		s.runs = slices.Insert(s.runs, i, positionRun{from: p, to: p})
+	}
+}
+
+func (s *positions) has(p uint64) bool {
+	i := s.find(p)
+	return i < len(s.runs) && s.runs[i].from <= p
+}
+
+func (s *positions) max() (uint64, bool) {
+	if len(s.runs) == 0 {
+		return 0, false
+	}
+	return s.runs[len(s.runs)-1].to, true
+}
+
+// firstMissing returns the lowest position in from..to that is not marked.
+func (s *positions) firstMissing(from, to uint64) (uint64, bool) {
+	if from > to {
+		return 0, false
+	}
+	i := s.find(from)
+	if i == len(s.runs) || s.runs[i].from > from {
+		return from, true
+	}
+	if s.runs[i].to >= to {
+		return 0, false
+	}
+	return s.runs[i].to + 1, true
+}
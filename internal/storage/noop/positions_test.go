@@ -0,0 +1,42 @@
+package noop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositions(t *testing.T) {
+	var s positions
+	_, ok := s.max()
+	require.False(t, ok)
+	missing, ok := s.firstMissing(3, 5)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), missing)
+
+	for _, p := range []uint64{10, 12, 11, 14, 9, 12} {
+		s.add(p)
+	}
+	require.Equal(t, []positionRun{{9, 12}, {14, 14}}, s.runs, "adjacent marks merge into runs")
+	require.True(t, s.has(11))
+	require.False(t, s.has(13))
+	max, ok := s.max()
+	require.True(t, ok)
+	require.Equal(t, uint64(14), max)
+
+	missing, ok = s.firstMissing(9, 14)
+	require.True(t, ok)
+	require.Equal(t, uint64(13), missing)
+	_, ok = s.firstMissing(9, 12)
+	require.False(t, ok)
+	missing, ok = s.firstMissing(5, 12)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), missing)
+
+	s.add(13)
+	require.Equal(t, []positionRun{{9, 14}}, s.runs)
+	s.add(^uint64(0))
+	require.True(t, s.has(^uint64(0)))
+	_, ok = s.firstMissing(^uint64(0), ^uint64(0))
+	require.False(t, ok)
+}
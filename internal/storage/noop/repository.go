@@ -0,0 +1,257 @@
+// Package noop provides a storage backend that persists nothing (database_driver: none).
+// Indexer progress and epoch_processed marks are kept in memory so runners still dedupe slots and epochs,
+// and metrics count rows once, within one process.
+package noop
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Repository discards indexed rows and answers reads with empty results.
+type Repository struct {
+	mu        sync.RWMutex
+	progress  map[string]*positions
+	scheduler map[string]storage.SchedulerState
+	processed map[storage.EpochProcessed]struct{}
+}
+
+// Ensure Repository implements storage.Repository.
+var _ storage.Repository = (*Repository)(nil)
+
+// NewRepository creates an empty in-memory progress tracker.
+func NewRepository() *Repository {
+	return &Repository{
+		progress: map[string]*positions{
+			storage.ProgressKindSlot:  {},
+			storage.ProgressKindEpoch: {},
+		},
+		scheduler: map[string]storage.SchedulerState{},
+		processed: map[storage.EpochProcessed]struct{}{},
+	}
+}
+
+func (r *Repository) SaveValidatorEpochRecords(context.Context, []*storage.ValidatorEpochRecord) error {
+	return nil
+}
+
+func (r *Repository) ListValidatorEpochRecords(context.Context, uint64, uint64, uint64) ([]*storage.ValidatorEpochRecord, error) {
+	return nil, nil
+}
+
+func (r *Repository) SaveBlock(context.Context, *storage.Block) error { return nil }
+
+func (r *Repository) SaveBlocks(context.Context, []*storage.Block) error { return nil }
+
+func (r *Repository) GetValidatorSnapshots(context.Context, uint64, uint64, uint64) ([]*storage.ValidatorSnapshot, error) {
+	return nil, nil
+}
+
+func (r *Repository) ListValidatorSnapshots(context.Context, uint64, uint64, uint64, int, int) ([]*storage.ValidatorSnapshot, error) {
+	return nil, nil
+}
+
+func (r *Repository) GetEffectiveBalanceSeries(_ context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.EffectiveBalancePoint, error) {
+	return storage.EffectiveBalanceSeries(validatorIndex, fromEpoch, toEpoch, nil), nil
+}
+
+func (r *Repository) GetAttestationRewards(context.Context, uint64, uint64, uint64) ([]*storage.AttestationReward, error) {
+	return nil, nil
+}
+
+func (r *Repository) ListAttestationRewards(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.AttestationReward, error) {
+	return nil, nil
+}
+
+func (r *Repository) ListBlocks(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.Block, error) {
+	return nil, nil
+}
+
+func (r *Repository) ListSyncCommitteeRewards(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.SyncCommitteeReward, error) {
+	return nil, nil
+}
+
+func (r *Repository) SaveAttestationDuties(context.Context, []*storage.AttestationDuty) error {
+	return nil
+}
+
+func (r *Repository) ListAttestationDuties(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.AttestationDuty, error) {
+	return nil, nil
+}
+
+func (r *Repository) GetDutiesForSlot(context.Context, uint64) ([]*storage.AttestationDuty, error) {
+	return nil, nil
+}
+
+func (r *Repository) SaveProposerDuties(context.Context, []*storage.ProposerDuty) error {
+	return nil
+}
+
+func (r *Repository) ListProposerDuties(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.ProposerDuty, error) {
+	return nil, nil
+}
+
+func (r *Repository) SaveAttestationLiveness(context.Context, []*storage.AttestationLiveness) error {
+	return nil
+}
+
+func (r *Repository) ListAttestationLiveness(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.AttestationLiveness, error) {
+	return nil, nil
+}
+
+func (r *Repository) ReconcileAttestationLiveness(context.Context, uint64) (int64, error) {
+	return 0, nil
+}
+
+func (r *Repository) SaveValidatorLiveness(context.Context, []*storage.ValidatorLiveness) error {
+	return nil
+}
+
+func (r *Repository) ListValidatorLiveness(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.ValidatorLiveness, error) {
+	return nil, nil
+}
+
+func (r *Repository) SaveDutyMismatches(context.Context, []*storage.DutyMismatch) error {
+	return nil
+}
+
+func (r *Repository) ListDutyMismatches(context.Context, *uint64, uint64, uint64, int, int) ([]*storage.DutyMismatch, error) {
+	return nil, nil
+}
+
+func (r *Repository) SaveValidatorSetEvents(context.Context, []*storage.ValidatorSetEvent) error {
+	return nil
+}
+
+func (r *Repository) ListValidatorSetEvents(context.Context, *uint64, int, int) ([]*storage.ValidatorSetEvent, error) {
+	return nil, nil
+}
+
+func (r *Repository) ListWatchedValidators(context.Context) ([]uint64, error) { return nil, nil }
+
+func (r *Repository) DeleteValidatorHistory(context.Context, uint64) error { return nil }
+
+func (r *Repository) PurgeValidator(context.Context, uint64) (int64, error) { return 0, nil }
+
+func (r *Repository) ListValidators(context.Context, int, int) ([]uint64, error) {
+	return nil, nil
+}
+
+func (r *Repository) GetLatestSnapshot(_ context.Context, validatorIndex uint64) (*storage.ValidatorSnapshot, error) {
+	return nil, fmt.Errorf("no snapshot for validator %d: storage backend is none", validatorIndex)
+}
+
+func (r *Repository) CountSnapshots(context.Context, uint64) (int, error) {
+	return 0, nil
+}
+
+// MarkSlotIndexed records slot progress in memory.
+func (r *Repository) MarkSlotIndexed(_ context.Context, slot uint64) error {
+	r.mark(storage.ProgressKindSlot, slot)
+	return nil
+}
+
+// MarkEpochIndexed records epoch progress in memory.
+func (r *Repository) MarkEpochIndexed(_ context.Context, epoch uint64) error {
+	r.mark(storage.ProgressKindEpoch, epoch)
+	return nil
+}
+
+func (r *Repository) MaxIndexedSlot(context.Context) (uint64, bool, error) {
+	slot, ok := r.max(storage.ProgressKindSlot)
+	return slot, ok, nil
+}
+
+func (r *Repository) MaxIndexedEpoch(context.Context) (uint64, bool, error) {
+	epoch, ok := r.max(storage.ProgressKindEpoch)
+	return epoch, ok, nil
+}
+
+func (r *Repository) FirstUnindexedSlot(_ context.Context, from, to uint64) (uint64, bool, error) {
+	slot, ok := r.firstMissing(storage.ProgressKindSlot, from, to)
+	return slot, ok, nil
+}
+
+func (r *Repository) FirstUnindexedEpoch(_ context.Context, from, to uint64) (uint64, bool, error) {
+	epoch, ok := r.firstMissing(storage.ProgressKindEpoch, from, to)
+	return epoch, ok, nil
+}
+
+func (r *Repository) IsSlotIndexed(_ context.Context, slot uint64) (bool, error) {
+	return r.has(storage.ProgressKindSlot, slot), nil
+}
+
+func (r *Repository) IsEpochIndexed(_ context.Context, epoch uint64) (bool, error) {
+	return r.has(storage.ProgressKindEpoch, epoch), nil
+}
+
+// SaveSchedulerState keeps the cursor in memory.
+func (r *Repository) SaveSchedulerState(_ context.Context, state *storage.SchedulerState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scheduler[state.Kind] = *state
+	return nil
+}
+
+func (r *Repository) GetSchedulerState(_ context.Context, kind string) (*storage.SchedulerState, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.scheduler[kind]
+	if !ok {
+		return nil, false, nil
+	}
+	return &state, true, nil
+}
+
+// MarkEpochProcessed keeps the marks in memory and returns the ones not seen before.
+func (r *Repository) MarkEpochProcessed(_ context.Context, marks []*storage.EpochProcessed) ([]*storage.EpochProcessed, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var fresh []*storage.EpochProcessed
+	for _, m := range marks {
+		if _, ok := r.processed[*m]; ok {
+			continue
+		}
+		r.processed[*m] = struct{}{}
+		fresh = append(fresh, m)
+	}
+	return fresh, nil
+}
+
+func (r *Repository) SaveRawResponses(context.Context, []*storage.RawResponse) error {
+	return nil
+}
+
+func (r *Repository) DeleteRawResponsesBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *Repository) Close() error { return nil }
+
+func (r *Repository) mark(kind string, position uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.progress[kind].add(position)
+}
+
+func (r *Repository) has(kind string, position uint64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.progress[kind].has(position)
+}
+
+func (r *Repository) max(kind string) (uint64, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.progress[kind].max()
+}
+
+func (r *Repository) firstMissing(kind string, from, to uint64) (uint64, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.progress[kind].firstMissing(from, to)
+}
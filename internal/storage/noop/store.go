@@ -0,0 +1,25 @@
+package noop
+
+import "github.com/tharun/pauli/internal/storage"
+
+// Store implements storage.Store without a database.
+type Store struct {
+	repo *Repository
+}
+
+// NewStore creates a Store whose repository discards all writes.
+func NewStore() storage.Store {
+	return &Store{repo: NewRepository()}
+}
+
+// RunMigrations is a no-op.
+func (s *Store) RunMigrations() error { return nil }
+
+// HealthCheck always succeeds.
+func (s *Store) HealthCheck() error { return nil }
+
+// Repository returns the in-memory repository.
+func (s *Store) Repository() storage.Repository { return s.repo }
+
+// Close is a no-op.
+func (s *Store) Close() {}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveAttestationDuties upserts attester duties in one batch (keyed by validator and epoch).
+func (r *Repository) SaveAttestationDuties(ctx context.Context, duties []*storage.AttestationDuty) error {
+	if len(duties) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO attestation_duties (
+			validator_index, epoch, slot, committee_index, committee_position,
+			committee_length, committees_at_slot, dependent_root, slot_time, indexed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (validator_index, epoch) DO UPDATE SET
+			slot = EXCLUDED.slot,
+			committee_index = EXCLUDED.committee_index,
+			committee_position = EXCLUDED.committee_position,
+			committee_length = EXCLUDED.committee_length,
+			committees_at_slot = EXCLUDED.committees_at_slot,
+			dependent_root = EXCLUDED.dependent_root,
+			slot_time = COALESCE(EXCLUDED.slot_time, attestation_duties.slot_time),
+			indexed_at = EXCLUDED.indexed_at
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, d := range duties {
+		if d.IndexedAt.IsZero() {
+			d.IndexedAt = now
+		}
+		batch.Queue(query,
+			d.ValidatorIndex,
+			d.Epoch,
+			d.Slot,
+			d.CommitteeIndex,
+			d.CommitteePosition,
+			d.CommitteeLength,
+			d.CommitteesAtSlot,
+			d.DependentRoot,
+			d.SlotTime,
+			d.IndexedAt,
+		)
+	}
+	br := r.client.Pool.SendBatch(ctx, batch)
+	defer br.Close()
+	for range duties {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("failed to save attestation duties batch: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListAttestationDuties returns attester duties for a slot range, optionally filtered to one validator.
+func (r *Repository) ListAttestationDuties(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.AttestationDuty, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT validator_index, epoch, slot, committee_index, committee_position,
+			committee_length, committees_at_slot, dependent_root, slot_time, indexed_at
+		FROM attestation_duties
+		WHERE slot >= $1 AND slot <= $2`)
+	args := []any{fromSlot, toSlot}
+	argPos := 3
+	if validatorIndex != nil {
+		fmt.Fprintf(&sb, " AND validator_index = $%d", argPos)
+		args = append(args, *validatorIndex)
+		argPos++
+	}
+	fmt.Fprintf(&sb, " ORDER BY slot DESC, validator_index ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attestation duties: %w", err)
+	}
+	return scanAttestationDuties(rows)
+}
+
+// GetDutiesForSlot returns every stored duty at slot (served by idx_attestation_duties_slot), ordered by
+// committee index and position.
+func (r *Repository) GetDutiesForSlot(ctx context.Context, slot uint64) ([]*storage.AttestationDuty, error) {
+	const query = `
+		SELECT validator_index, epoch, slot, committee_index, committee_position,
+			committee_length, committees_at_slot, dependent_root, slot_time, indexed_at
+		FROM attestation_duties
+		WHERE slot = $1
+		ORDER BY committee_index ASC, committee_position ASC`
+	rows, err := r.client.ReadPool.Query(ctx, query, slot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attestation duties for slot %d: %w", slot, err)
+	}
+	return scanAttestationDuties(rows)
+}
+
+func scanAttestationDuties(rows pgx.Rows) ([]*storage.AttestationDuty, error) {
+	defer rows.Close()
+
+	var out []*storage.AttestationDuty
+	for rows.Next() {
+		var d storage.AttestationDuty
+		if err := rows.Scan(
+			&d.ValidatorIndex,
+			&d.Epoch,
+			&d.Slot,
+			&d.CommitteeIndex,
+			&d.CommitteePosition,
+			&d.CommitteeLength,
+			&d.CommitteesAtSlot,
+			&d.DependentRoot,
+			&d.SlotTime,
+			&d.IndexedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attestation duty: %w", err)
+		}
+		cp := d
+		out = append(out, &cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attestation duties: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveAttestationLiveness upserts provisional inclusion results. Re-checks overwrite the provisional
+// fields but keep any reconciliation already recorded.
+func (r *Repository) SaveAttestationLiveness(ctx context.Context, rows []*storage.AttestationLiveness) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO attestation_liveness (
+			validator_index, epoch, slot, included, inclusion_slot, checked_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (validator_index, epoch) DO UPDATE SET
+			slot = EXCLUDED.slot,
+			included = EXCLUDED.included,
+			inclusion_slot = EXCLUDED.inclusion_slot,
+			checked_at = EXCLUDED.checked_at
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, row := range rows {
+		if row.CheckedAt.IsZero() {
+			row.CheckedAt = now
+		}
+		batch.Queue(query,
+			row.ValidatorIndex,
+			row.Epoch,
+			row.Slot,
+			row.Included,
+			row.InclusionSlot,
+			row.CheckedAt,
+		)
+	}
+	br := r.client.Pool.SendBatch(ctx, batch)
+	defer br.Close()
+	for range rows {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("failed to save attestation liveness batch: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListAttestationLiveness returns liveness rows for a slot range, optionally filtered to one validator.
+func (r *Repository) ListAttestationLiveness(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.AttestationLiveness, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT validator_index, epoch, slot, included, inclusion_slot, checked_at,
+			reward_included, reconciled_at
+		FROM attestation_liveness
+		WHERE slot >= $1 AND slot <= $2`)
+	args := []any{fromSlot, toSlot}
+	argPos := 3
+	if validatorIndex != nil {
+		fmt.Fprintf(&sb, " AND validator_index = $%d", argPos)
+		args = append(args, *validatorIndex)
+		argPos++
+	}
+	fmt.Fprintf(&sb, " ORDER BY slot DESC, validator_index ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attestation liveness: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.AttestationLiveness
+	for rows.Next() {
+		var row storage.AttestationLiveness
+		var inclusionSlot sql.NullInt64
+		var rewardIncluded sql.NullBool
+		var reconciledAt sql.NullTime
+		if err := rows.Scan(
+			&row.ValidatorIndex,
+			&row.Epoch,
+			&row.Slot,
+			&row.Included,
+			&inclusionSlot,
+			&row.CheckedAt,
+			&rewardIncluded,
+			&reconciledAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attestation liveness: %w", err)
+		}
+		if inclusionSlot.Valid {
+			s := uint64(inclusionSlot.Int64)
+			row.InclusionSlot = &s
+		}
+		if rewardIncluded.Valid {
+			b := rewardIncluded.Bool
+			row.RewardIncluded = &b
+		}
+		if reconciledAt.Valid {
+			t := reconciledAt.Time
+			row.ReconciledAt = &t
+		}
+		cp := row
+		out = append(out, &cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attestation liveness: %w", err)
+	}
+	return out, nil
+}
+
+// ReconcileAttestationLiveness marks each unreconciled liveness row of epoch with the authoritative
+// outcome from validator_epoch_records (source_reward > 0 means a timely attestation was rewarded).
+func (r *Repository) ReconcileAttestationLiveness(ctx context.Context, epoch uint64) (int64, error) {
+	const query = `
+		UPDATE attestation_liveness l
+		SET reward_included = (v.source_reward > 0),
+			reconciled_at = NOW()
+		FROM validator_epoch_records v
+		WHERE l.epoch = $1
+			AND l.reconciled_at IS NULL
+			AND v.validator_index = l.validator_index
+			AND v.epoch = l.epoch
+			AND v.source_reward IS NOT NULL
+	`
+	tag, err := r.client.Pool.Exec(ctx, query, epoch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reconcile attestation liveness for epoch %d: %w", epoch, err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"github.com/tharun/pauli/internal/metrics"
+	"github.com/tharun/pauli/pkg/backoff"
+)
+
+// writeBatchAttempts bounds how often a batch failing with a transient error (see IsTransient) is sent.
+const writeBatchAttempts = 3
+
+// writeRows runs query once per args row in batches of at most maxWriteBatchSize (see writeBatch).
+// With postgres.write_concurrency set, every batch holds one of the client's writer slots while it is
+// written, capping concurrent batch writes process-wide, and a call's batches are written on up to
+// that many goroutines; a call never has the same row in two batches, so a
+// validator's row is still written exactly once. The first failing batch stops the rest.
+func (r *Repository) writeRows(ctx context.Context, query string, args [][]any, describe func(i int) string) error {
+	write := r.writeBatch
+	if r.client.writers != nil {
+		write = r.writeBatchSlot
+	}
+	n := (len(args) + maxWriteBatchSize - 1) / maxWriteBatchSize
+	if r.client.writers == nil || n <= 1 {
+		for start := 0; start < len(args); start += maxWriteBatchSize {
+			if err := write(ctx, query, args, start, min(start+maxWriteBatchSize, len(args)), describe); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	starts := make(chan int)
+	errs := make(chan error, 1)
+	var wg sync.WaitGroup
+	for w := 0; w < min(n, cap(r.client.writers)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range starts {
+				if err := write(ctx, query, args, start, min(start+maxWriteBatchSize, len(args)), describe); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+				}
+			}
+		}()
+	}
+feed:
+	for start := 0; start < len(args); start += maxWriteBatchSize {
+		select {
+		case starts <- start:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(starts)
+	wg.Wait()
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// writeBatchSlot is writeBatch run while holding one of the client's writer slots.
+func (r *Repository) writeBatchSlot(ctx context.Context, query string, args [][]any, start, end int, describe func(i int) string) error {
+	select {
+	case r.client.writers <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	metrics.StorageWritersBusy.Inc()
+	defer func() {
+		metrics.StorageWritersBusy.Dec()
+		<-r.client.writers
+	}()
+	return r.writeBatch(ctx, query, args, start, end, describe)
+}
+
+// writeBatch writes args[start:end] as one pgx.Batch, which is one implicit transaction, so a single
+// bad row fails all of it. A transient failure resends the batch with backoff up to
+// writeBatchAttempts times and is then returned. A permanent failure is logged with its SQLSTATE;
+// with RowFallback set the batch is then retried row by row: valid rows are written and each
+// rejected row is logged (via describe) and dropped. The batch error is still returned when no row
+// of the batch could be written, since that points at the connection rather than the data.
+func (r *Repository) writeBatch(ctx context.Context, query string, args [][]any, start, end int, describe func(i int) string) error {
+	batch := &pgx.Batch{}
+	for _, a := range args[start:end] {
+		batch.Queue(query, a...)
+	}
+	err := r.execBatchRetrying(ctx, batch)
+	if err != nil && IsTransient(err) && r.client.DegradeSynchronousCommit != "" && ctx.Err() == nil {
+		err = r.execBatchDegraded(ctx, batch, err)
+	}
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil || IsTransient(err) {
+		return err
+	}
+	log.Error().
+		Err(err).
+		Str("sqlstate", sqlState(err)).
+		Int("rows", end-start).
+		Msg("postgres: write batch rejected by the database")
+	if !r.client.RowFallback {
+		return err
+	}
+	return r.writeRowsIndividually(ctx, query, args, start, end, describe)
+}
+
+// execBatchRetrying sends batch, resending it after backoff while it fails with a transient error.
+func (r *Repository) execBatchRetrying(ctx context.Context, batch *pgx.Batch) error {
+	b := backoff.NewDefault()
+	var err error
+	for attempt := 1; attempt <= writeBatchAttempts; attempt++ {
+		err = r.execBatch(ctx, batch)
+		if err == nil || !IsTransient(err) || attempt == writeBatchAttempts {
+			return err
+		}
+		log.Warn().Err(err).Int("attempt", attempt).Msg("postgres: transient write failure, retrying batch")
+		if !b.Wait(ctx) {
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// execBatchDegraded sends batch once more in a transaction with synchronous_commit lowered to
+// client.DegradeSynchronousCommit, after cause made the normal attempts fail. Success is logged as a
+// degraded write, since the rows may not have reached a synchronous standby.
+func (r *Repository) execBatchDegraded(ctx context.Context, batch *pgx.Batch, cause error) error {
+	level := r.client.DegradeSynchronousCommit
+	err := pgx.BeginFunc(ctx, r.client.Pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT set_config('synchronous_commit', $1, true)", level); err != nil {
+			return err
+		}
+		br := tx.SendBatch(ctx, batch)
+		for i := 0; i < batch.Len(); i++ {
+			if _, err := br.Exec(); err != nil {
+				br.Close()
+				return err
+			}
+		}
+		return br.Close()
+	})
+	if err != nil {
+		log.Error().Err(err).Str("synchronous_commit", level).Msg("postgres: degraded write failed")
+		return fmt.Errorf("%w (degraded retry: %v)", cause, err)
+	}
+	log.Warn().
+		Err(cause).
+		Str("synchronous_commit", level).
+		Int("rows", batch.Len()).
+		Msg("postgres: degraded write; batch committed with lowered synchronous_commit")
+	return nil
+}
+
+// writeRowsIndividually writes args[start:end] one statement at a time.
+func (r *Repository) writeRowsIndividually(ctx context.Context, query string, args [][]any, start, end int, describe func(i int) string) error {
+	var (
+		failed  int
+		lastErr error
+	)
+	for i := start; i < end; i++ {
+		if _, err := r.client.Pool.Exec(ctx, query, args[i]...); err != nil {
+			if IsTransient(err) {
+				// The connection, not the row, is the problem: do not drop the rest.
+				return err
+			}
+			failed++
+			lastErr = err
+			log.Error().Err(err).Str("sqlstate", sqlState(err)).Str("row", describe(i)).Msg("postgres: dropping row rejected by the database")
+		}
+	}
+	if failed == end-start {
+		return fmt.Errorf("all %d rows failed individually: %w", failed, lastErr)
+	}
+	if failed > 0 {
+		log.Warn().Int("dropped", failed).Int("written", end-start-failed).Msg("postgres: batch written row by row after failure")
+	}
+	return nil
+}
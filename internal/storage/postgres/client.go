@@ -6,14 +6,33 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
 	"github.com/tharun/pauli/internal/config"
+	"github.com/tharun/pauli/internal/metrics"
 	"github.com/tharun/pauli/internal/storage"
 )
 
 // Client wraps the PostgreSQL connection pool with configuration.
 type Client struct {
-	Pool    *pgxpool.Pool
-	TTLDays int
+	Pool *pgxpool.Pool
+	// ReadPool serves the Get/List/Count queries of reports, the API and exports. It is a separate pool on
+	// postgres.read_host (a hot standby) when set, otherwise Pool. Progress and cursor reads that must
+	// see this process's own writes stay on Pool.
+	ReadPool *pgxpool.Pool
+	// TTL is the configured retention (ttl_days or retention_epochs/slots translated to wall time).
+	TTL time.Duration
+	// RowFallback retries failed write batches one row at a time (postgres.batch_row_fallback).
+	RowFallback bool
+	// SkipMigrations makes RunMigrations only verify that every migration is applied (postgres.skip_migrations).
+	SkipMigrations bool
+	// DegradeSynchronousCommit is the synchronous_commit level for a last-resort write of a batch that
+	// kept failing transiently (postgres.degrade_synchronous_commit); "" disables it.
+	DegradeSynchronousCommit string
+	// Schema is the search_path schema pauli's tables live in (postgres.schema); "" = server default.
+	Schema string
+	// writers holds one token per batch being written (postgres.write_concurrency); nil = no limit and
+	// each save writes its batches sequentially.
+	writers chan struct{}
 }
 
 // Store implements storage.Store for PostgreSQL.
@@ -22,9 +41,9 @@ type Store struct {
 	repo   storage.Repository
 }
 
-// NewStore creates a new PostgreSQL-backed Store.
-func NewStore(cfg *config.PostgresConf) (storage.Store, error) {
-	client, err := NewClient(cfg)
+// NewStore creates a new PostgreSQL-backed Store with retention ttl (see config.Config.RetentionTTL).
+func NewStore(cfg *config.PostgresConf, ttl time.Duration) (storage.Store, error) {
+	client, err := NewClient(cfg, ttl)
 	if err != nil {
 		return nil, err
 	}
@@ -67,13 +86,51 @@ func (s *Store) Close() {
 }
 
 // NewClient creates a new PostgreSQL client with the given configuration.
-func NewClient(cfg *config.PostgresConf) (*Client, error) {
+func NewClient(cfg *config.PostgresConf, ttl time.Duration) (*Client, error) {
+	pool, err := connect(cfg, cfg.Host, cfg.Port)
+	if err != nil {
+		return nil, err
+	}
+	readPool := pool
+	if cfg.ReadHost != "" {
+		readPool, err = connect(cfg, cfg.ReadHost, cfg.ReadPort)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("read replica: %w", err)
+		}
+		log.Info().Str("read_host", cfg.ReadHost).Int("read_port", cfg.ReadPort).Msg("postgres: serving read queries from the read replica")
+	}
+
+	client := &Client{
+		Pool:                     pool,
+		ReadPool:                 readPool,
+		TTL:                      ttl,
+		RowFallback:              cfg.BatchRowFallback,
+		SkipMigrations:           cfg.SkipMigrations,
+		DegradeSynchronousCommit: cfg.DegradeSynchronousCommit,
+		Schema:                   cfg.Schema,
+	}
+	if cfg.WriteConcurrency > 0 {
+		client.writers = make(chan struct{}, cfg.WriteConcurrency)
+		metrics.StorageWritersLimit.Set(float64(cfg.WriteConcurrency))
+	}
+	if client.DegradeSynchronousCommit != "" {
+		log.Warn().
+			Str("synchronous_commit", client.DegradeSynchronousCommit).
+			Msg("postgres: degrade_synchronous_commit is set; writes that keep failing are retried without waiting for synchronous standbys and may be lost on failover")
+	}
+
+	return client, nil
+}
+
+// connect opens a pool to host:port with cfg's credentials, database and pool settings.
+func connect(cfg *config.PostgresConf, host string, port int) (*pgxpool.Pool, error) {
 	connString := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.User,
 		cfg.Password,
-		cfg.Host,
-		cfg.Port,
+		host,
+		port,
 		cfg.Database,
 		cfg.SSLMode,
 	)
@@ -86,6 +143,9 @@ func NewClient(cfg *config.PostgresConf) (*Client, error) {
 	if cfg.MaxConns > 0 {
 		pgxCfg.MaxConns = cfg.MaxConns
 	}
+	if cfg.Schema != "" {
+		pgxCfg.ConnConfig.RuntimeParams["search_path"] = cfg.Schema
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -94,17 +154,14 @@ func NewClient(cfg *config.PostgresConf) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
 	}
-
-	client := &Client{
-		Pool:    pool,
-		TTLDays: cfg.TTLDays,
-	}
-
-	return client, nil
+	return pool, nil
 }
 
-// Close closes the PostgreSQL pool.
+// Close closes the PostgreSQL pools.
 func (c *Client) Close() {
+	if c.ReadPool != nil && c.ReadPool != c.Pool {
+		c.ReadPool.Close()
+	}
 	if c.Pool != nil {
 		c.Pool.Close()
 	}
@@ -119,5 +176,10 @@ func (c *Client) HealthCheck() error {
 	if err := c.Pool.QueryRow(ctx, "SELECT 1").Scan(&one); err != nil {
 		return fmt.Errorf("postgres health check failed: %w", err)
 	}
+	if c.ReadPool != nil && c.ReadPool != c.Pool {
+		if err := c.ReadPool.QueryRow(ctx, "SELECT 1").Scan(&one); err != nil {
+			return fmt.Errorf("postgres read replica health check failed: %w", err)
+		}
+	}
 	return nil
 }
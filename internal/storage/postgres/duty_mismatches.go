@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveDutyMismatches upserts duty verification discrepancies (keyed by validator and epoch).
+func (r *Repository) SaveDutyMismatches(ctx context.Context, rows []*storage.DutyMismatch) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO duty_mismatches (
+			validator_index, epoch, slot, reason, expected_committee_index, expected_position,
+			actual_committee_index, actual_position, detected_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (validator_index, epoch) DO UPDATE SET
+			slot = EXCLUDED.slot,
+			reason = EXCLUDED.reason,
+			expected_committee_index = EXCLUDED.expected_committee_index,
+			expected_position = EXCLUDED.expected_position,
+			actual_committee_index = EXCLUDED.actual_committee_index,
+			actual_position = EXCLUDED.actual_position,
+			detected_at = EXCLUDED.detected_at
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, m := range rows {
+		if m.DetectedAt.IsZero() {
+			m.DetectedAt = now
+		}
+		batch.Queue(query,
+			m.ValidatorIndex,
+			m.Epoch,
+			m.Slot,
+			m.Reason,
+			m.ExpectedCommitteeIndex,
+			m.ExpectedPosition,
+			m.ActualCommitteeIndex,
+			m.ActualPosition,
+			m.DetectedAt,
+		)
+	}
+	if err := r.execBatch(ctx, batch); err != nil {
+		return fmt.Errorf("failed to save duty mismatches batch: %w", err)
+	}
+	return nil
+}
+
+// ListDutyMismatches returns duty mismatches for a slot range, optionally filtered to one validator.
+func (r *Repository) ListDutyMismatches(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.DutyMismatch, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT validator_index, epoch, slot, reason, expected_committee_index, expected_position,
+			actual_committee_index, actual_position, detected_at
+		FROM duty_mismatches
+		WHERE slot >= $1 AND slot <= $2`)
+	args := []any{fromSlot, toSlot}
+	argPos := 3
+	if validatorIndex != nil {
+		fmt.Fprintf(&sb, " AND validator_index = $%d", argPos)
+		args = append(args, *validatorIndex)
+		argPos++
+	}
+	fmt.Fprintf(&sb, " ORDER BY slot DESC, validator_index ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duty mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.DutyMismatch
+	for rows.Next() {
+		var m storage.DutyMismatch
+		var actualIndex, actualPos sql.NullInt64
+		if err := rows.Scan(
+			&m.ValidatorIndex,
+			&m.Epoch,
+			&m.Slot,
+			&m.Reason,
+			&m.ExpectedCommitteeIndex,
+			&m.ExpectedPosition,
+			&actualIndex,
+			&actualPos,
+			&m.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan duty mismatch: %w", err)
+		}
+		if actualIndex.Valid {
+			v := uint64(actualIndex.Int64)
+			m.ActualCommitteeIndex = &v
+		}
+		if actualPos.Valid {
+			v := uint64(actualPos.Int64)
+			m.ActualPosition = &v
+		}
+		cp := m
+		out = append(out, &cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate duty mismatches: %w", err)
+	}
+	return out, nil
+}
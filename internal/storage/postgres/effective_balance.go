@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// GetEffectiveBalanceSeries returns one point per epoch in fromEpoch..toEpoch from validator_epoch_records;
+// epochs without a row are returned as storage.EffectiveBalanceMissing points.
+func (r *Repository) GetEffectiveBalanceSeries(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.EffectiveBalancePoint, error) {
+	const q = `
+		SELECT epoch, effective_balance, balance, status, slot_time
+		FROM validator_epoch_records
+		WHERE validator_index = $1 AND epoch >= $2 AND epoch <= $3`
+
+	rows, err := r.client.ReadPool.Query(ctx, q, validatorIndex, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("get effective balance series: %w", err)
+	}
+	defer rows.Close()
+
+	indexed := make(map[uint64]*storage.EffectiveBalancePoint)
+	for rows.Next() {
+		p := &storage.EffectiveBalancePoint{ValidatorIndex: validatorIndex, Source: storage.EffectiveBalanceIndexed}
+		if err := rows.Scan(&p.Epoch, &p.EffectiveBalance, &p.Balance, &p.Status, &p.SlotTime); err != nil {
+			return nil, fmt.Errorf("scan effective balance point: %w", err)
+		}
+		indexed[p.Epoch] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate effective balance series: %w", err)
+	}
+	return storage.EffectiveBalanceSeries(validatorIndex, fromEpoch, toEpoch, indexed), nil
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// MarkEpochProcessed inserts marks into epoch_processed and returns the ones that were not there yet.
+func (r *Repository) MarkEpochProcessed(ctx context.Context, marks []*storage.EpochProcessed) ([]*storage.EpochProcessed, error) {
+	if len(marks) == 0 {
+		return nil, nil
+	}
+	const query = `
+		INSERT INTO epoch_processed (aggregate, epoch, validator_index, slot, processed_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (aggregate, epoch, validator_index, slot) DO NOTHING
+	`
+	batch := &pgx.Batch{}
+	for _, m := range marks {
+		batch.Queue(query, m.Aggregate, m.Epoch, m.ValidatorIndex, m.Slot)
+	}
+	br := r.client.Pool.SendBatch(ctx, batch)
+	defer br.Close()
+	var fresh []*storage.EpochProcessed
+	for _, m := range marks {
+		tag, err := br.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark epoch processed batch: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			fresh = append(fresh, m)
+		}
+	}
+	return fresh, nil
+}
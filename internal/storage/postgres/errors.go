@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// IsTransient reports whether err is a write failure worth retrying unchanged: a lost or refused
+// connection, a timeout, a server shutting down or out of resources, or a serialization failure or
+// deadlock. Everything the server rejected for the data or the statement itself (constraint and
+// type violations, syntax, missing tables or privileges) is permanent. Context cancellation is
+// neither retried nor reported as transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return transientSQLState(pgErr.Code)
+	}
+	if pgconn.Timeout(err) {
+		return true
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// transientSQLState classifies SQLSTATE codes (https://www.postgresql.org/docs/current/errcodes-appendix.html).
+func transientSQLState(code string) bool {
+	switch code {
+	case "40001", // serialization_failure
+		"40P01", // deadlock_detected
+		"55P03", // lock_not_available
+		"57014", // query_canceled (statement_timeout)
+		"57P01", // admin_shutdown
+		"57P02", // crash_shutdown
+		"57P03": // cannot_connect_now
+		return true
+	}
+	// Class 08 connection exceptions, 53 insufficient resources (disk full, too many connections).
+	return strings.HasPrefix(code, "08") || strings.HasPrefix(code, "53")
+}
+
+// sqlState returns err's SQLSTATE, or "" when it did not come from the server.
+func sqlState(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"undefined table", &pgconn.PgError{Code: "42P01"}, false},
+		{"numeric out of range", fmt.Errorf("save: %w", &pgconn.PgError{Code: "22003"}), false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, true},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, true},
+		{"admin shutdown", fmt.Errorf("save: %w", &pgconn.PgError{Code: "57P01"}), true},
+		{"network", &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, true},
+		{"cancelled", fmt.Errorf("save: %w", context.Canceled), false},
+		{"plain", errors.New("boom"), false},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, IsTransient(tc.err), tc.name)
+	}
+}
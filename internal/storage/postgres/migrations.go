@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog/log"
 	"github.com/tharun/pauli/sql"
 )
@@ -41,7 +42,15 @@ func (c *Client) RunMigrations() error {
 		return fmt.Errorf("failed to load postgres migrations: %w", err)
 	}
 
-	log.Debug().Int("total", len(migrations)).Msg("Loaded postgres migration files")
+	log.Debug().Int("total", len(migrations)).Dur("retention_ttl", c.TTL).Msg("Loaded postgres migration files")
+
+	if c.SkipMigrations {
+		return c.verifyMigrations(migrations)
+	}
+
+	if err := c.ensureSchema(); err != nil {
+		return err
+	}
 
 	// Ensure schema_migrations table exists (bootstrap)
 	if err := c.ensureMigrationsTable(); err != nil {
@@ -77,6 +86,25 @@ func (c *Client) RunMigrations() error {
 	return nil
 }
 
+// verifyMigrations checks, without any DDL, that every embedded migration is recorded in schema_migrations.
+func (c *Client) verifyMigrations(migrations []*Migration) error {
+	applied, err := c.getAppliedMigrations()
+	if err != nil {
+		return fmt.Errorf("skip_migrations is set but the schema could not be verified (migrate it with a privileged user first): %w", err)
+	}
+	var missing []string
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; !ok {
+			missing = append(missing, m.Version+"_"+m.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("skip_migrations is set but %d migrations are not applied: %s", len(missing), strings.Join(missing, ", "))
+	}
+	log.Debug().Int("applied", len(applied)).Msg("PostgreSQL schema verified; migrations skipped")
+	return nil
+}
+
 // loadMigrationsPG reads all SQL files from the embedded PostgreSQL filesystem.
 func loadMigrationsPG() ([]*Migration, error) {
 	var migrations []*Migration
@@ -156,6 +184,21 @@ func (c *Client) ensureMigrationsTable() error {
 	return nil
 }
 
+// ensureSchema creates the configured schema (postgres.schema) if it does not exist yet.
+func (c *Client) ensureSchema() error {
+	if c.Schema == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := "CREATE SCHEMA IF NOT EXISTS " + pgx.Identifier{c.Schema}.Sanitize()
+	if _, err := c.Pool.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema %s: %w", c.Schema, err)
+	}
+	return nil
+}
+
 // getAppliedMigrations returns a map of already applied migration versions.
 func (c *Client) getAppliedMigrations() (map[string]*MigrationRecord, error) {
 	const query = `SELECT version, name, applied_at, checksum FROM schema_migrations`
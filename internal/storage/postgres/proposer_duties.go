@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveProposerDuties upserts proposer duties (keyed by slot). A reorg that reassigns the slot replaces
+// the row's validator.
+func (r *Repository) SaveProposerDuties(ctx context.Context, duties []*storage.ProposerDuty) error {
+	if len(duties) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO proposer_duties (
+			slot, validator_index, epoch, dependent_root, status, block_root, reward_gwei, finalized,
+			checked_at, indexed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (slot) DO UPDATE SET
+			validator_index = EXCLUDED.validator_index,
+			epoch = EXCLUDED.epoch,
+			dependent_root = EXCLUDED.dependent_root,
+			status = EXCLUDED.status,
+			block_root = EXCLUDED.block_root,
+			reward_gwei = EXCLUDED.reward_gwei,
+			finalized = EXCLUDED.finalized,
+			checked_at = EXCLUDED.checked_at,
+			indexed_at = EXCLUDED.indexed_at
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, d := range duties {
+		if d.IndexedAt.IsZero() {
+			d.IndexedAt = now
+		}
+		var reward *int64
+		if d.RewardGwei != nil {
+			v := int64(*d.RewardGwei)
+			reward = &v
+		}
+		batch.Queue(query,
+			d.Slot,
+			d.ValidatorIndex,
+			d.Epoch,
+			d.DependentRoot,
+			d.Status,
+			d.BlockRoot,
+			reward,
+			d.Finalized,
+			d.CheckedAt,
+			d.IndexedAt,
+		)
+	}
+	if err := r.execBatch(ctx, batch); err != nil {
+		return fmt.Errorf("failed to save proposer duties batch: %w", err)
+	}
+	return nil
+}
+
+// ListProposerDuties returns proposer duties for a slot range, optionally filtered to one validator.
+func (r *Repository) ListProposerDuties(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.ProposerDuty, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT slot, validator_index, epoch, dependent_root, status, block_root, reward_gwei, finalized,
+			checked_at, indexed_at
+		FROM proposer_duties
+		WHERE slot >= $1 AND slot <= $2`)
+	args := []any{fromSlot, toSlot}
+	argPos := 3
+	if validatorIndex != nil {
+		fmt.Fprintf(&sb, " AND validator_index = $%d", argPos)
+		args = append(args, *validatorIndex)
+		argPos++
+	}
+	fmt.Fprintf(&sb, " ORDER BY slot DESC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list proposer duties: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.ProposerDuty
+	for rows.Next() {
+		var d storage.ProposerDuty
+		var blockRoot sql.NullString
+		var reward sql.NullInt64
+		var checkedAt sql.NullTime
+		if err := rows.Scan(
+			&d.Slot,
+			&d.ValidatorIndex,
+			&d.Epoch,
+			&d.DependentRoot,
+			&d.Status,
+			&blockRoot,
+			&reward,
+			&d.Finalized,
+			&checkedAt,
+			&d.IndexedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan proposer duty: %w", err)
+		}
+		if blockRoot.Valid {
+			d.BlockRoot = &blockRoot.String
+		}
+		if reward.Valid {
+			v := uint64(reward.Int64)
+			d.RewardGwei = &v
+		}
+		if checkedAt.Valid {
+			t := checkedAt.Time
+			d.CheckedAt = &t
+		}
+		cp := d
+		out = append(out, &cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate proposer duties: %w", err)
+	}
+	return out, nil
+}
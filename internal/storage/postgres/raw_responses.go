@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveRawResponses inserts raw beacon responses kept for audit. Rows are append-only: a response
+// fetched again is stored again under its new fetch time.
+func (r *Repository) SaveRawResponses(ctx context.Context, rows []*storage.RawResponse) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO raw_responses (endpoint, path, epoch, fetched_at, request, body, body_size)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, raw := range rows {
+		if raw.FetchedAt.IsZero() {
+			raw.FetchedAt = now
+		}
+		batch.Queue(query,
+			raw.Endpoint,
+			raw.Path,
+			raw.Epoch,
+			raw.FetchedAt,
+			raw.Request,
+			raw.Body,
+			raw.BodySize,
+		)
+	}
+	if err := r.execBatch(ctx, batch); err != nil {
+		return fmt.Errorf("failed to save raw responses batch: %w", err)
+	}
+	return nil
+}
+
+// DeleteRawResponsesBefore deletes raw responses fetched before the cutoff (raw_responses.ttl_days).
+func (r *Repository) DeleteRawResponsesBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.client.Pool.Exec(ctx, "DELETE FROM raw_responses WHERE fetched_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete raw responses: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
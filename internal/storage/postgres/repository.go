@@ -32,78 +32,144 @@ func (r *Repository) Close() error {
 	return nil
 }
 
-// SaveValidatorEpochRecords upserts network-wide validator epoch rows in one batch.
+// maxWriteBatchSize caps how many upserts are queued in one pgx.Batch round trip.
+const maxWriteBatchSize = 500
+
+const upsertValidatorEpochRecordQuery = `
+	INSERT INTO validator_epoch_records (
+		validator_index, epoch, epoch_start_slot, status, balance, effective_balance,
+		head_reward, source_reward, target_reward, total_reward, execution_optimistic, slot_time, indexed_at,
+		activation_epoch, exit_epoch, withdrawable_epoch
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	ON CONFLICT (validator_index, epoch) DO UPDATE SET
+		epoch_start_slot = EXCLUDED.epoch_start_slot,
+		status = EXCLUDED.status,
+		balance = EXCLUDED.balance,
+		effective_balance = EXCLUDED.effective_balance,
+		head_reward = COALESCE(EXCLUDED.head_reward, validator_epoch_records.head_reward),
+		source_reward = COALESCE(EXCLUDED.source_reward, validator_epoch_records.source_reward),
+		target_reward = COALESCE(EXCLUDED.target_reward, validator_epoch_records.target_reward),
+		total_reward = COALESCE(EXCLUDED.total_reward, validator_epoch_records.total_reward),
+		execution_optimistic = EXCLUDED.execution_optimistic,
+		slot_time = COALESCE(EXCLUDED.slot_time, validator_epoch_records.slot_time),
+		indexed_at = EXCLUDED.indexed_at,
+		activation_epoch = COALESCE(EXCLUDED.activation_epoch, validator_epoch_records.activation_epoch),
+		exit_epoch = COALESCE(EXCLUDED.exit_epoch, validator_epoch_records.exit_epoch),
+		withdrawable_epoch = COALESCE(EXCLUDED.withdrawable_epoch, validator_epoch_records.withdrawable_epoch)
+`
+
+// SaveValidatorEpochRecords upserts network-wide validator epoch rows. A single record is
+// written with one statement; larger inputs are sent as batches of at most maxWriteBatchSize.
 func (r *Repository) SaveValidatorEpochRecords(ctx context.Context, records []*storage.ValidatorEpochRecord) error {
-	if len(records) == 0 {
+	switch len(records) {
+	case 0:
 		return nil
-	}
-	const query = `
-		INSERT INTO validator_epoch_records (
-			validator_index, epoch, epoch_start_slot, status, balance, effective_balance,
-			head_reward, source_reward, target_reward, total_reward, indexed_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		ON CONFLICT (validator_index, epoch) DO UPDATE SET
-			epoch_start_slot = EXCLUDED.epoch_start_slot,
-			status = EXCLUDED.status,
-			balance = EXCLUDED.balance,
-			effective_balance = EXCLUDED.effective_balance,
-			head_reward = COALESCE(EXCLUDED.head_reward, validator_epoch_records.head_reward),
-			source_reward = COALESCE(EXCLUDED.source_reward, validator_epoch_records.source_reward),
-			target_reward = COALESCE(EXCLUDED.target_reward, validator_epoch_records.target_reward),
-			total_reward = COALESCE(EXCLUDED.total_reward, validator_epoch_records.total_reward),
-			indexed_at = EXCLUDED.indexed_at
-	`
-	now := time.Now().UTC()
-	batch := &pgx.Batch{}
-	for _, rec := range records {
-		if rec.IndexedAt.IsZero() {
-			rec.IndexedAt = now
+	case 1:
+		if _, err := r.client.Pool.Exec(ctx, upsertValidatorEpochRecordQuery, validatorEpochRecordArgs(records[0])...); err != nil {
+			return fmt.Errorf("failed to save validator epoch record: %w", err)
 		}
-		batch.Queue(query,
-			rec.ValidatorIndex,
-			rec.Epoch,
-			rec.EpochStartSlot,
-			rec.Status,
-			rec.Balance,
-			rec.EffectiveBalance,
-			rec.HeadReward,
-			rec.SourceReward,
-			rec.TargetReward,
-			rec.TotalReward,
-			rec.IndexedAt,
-		)
+		return nil
 	}
-	br := r.client.Pool.SendBatch(ctx, batch)
-	defer br.Close()
-	for range records {
-		if _, err := br.Exec(); err != nil {
-			return fmt.Errorf("failed to save validator epoch records batch: %w", err)
-		}
+	rows := make([][]any, len(records))
+	for i, rec := range records {
+		rows[i] = validatorEpochRecordArgs(rec)
+	}
+	err := r.writeRows(ctx, upsertValidatorEpochRecordQuery, rows, func(i int) string {
+		return fmt.Sprintf("validator %d epoch %d", records[i].ValidatorIndex, records[i].Epoch)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save validator epoch records batch: %w", err)
 	}
 	return nil
 }
 
+func validatorEpochRecordArgs(rec *storage.ValidatorEpochRecord) []any {
+	if rec.IndexedAt.IsZero() {
+		rec.IndexedAt = time.Now().UTC()
+	}
+	return []any{
+		rec.ValidatorIndex,
+		rec.Epoch,
+		rec.EpochStartSlot,
+		rec.Status,
+		rec.Balance,
+		rec.EffectiveBalance,
+		rec.HeadReward,
+		rec.SourceReward,
+		rec.TargetReward,
+		rec.TotalReward,
+		rec.ExecutionOptimistic,
+		rec.SlotTime,
+		rec.IndexedAt,
+		rec.ActivationEpoch,
+		rec.ExitEpoch,
+		rec.WithdrawableEpoch,
+	}
+}
+
+const upsertBlockQuery = `
+	INSERT INTO blocks (
+		validator_index, validator_pubkey, slot_number, block_number, rewards,
+		execution_priority_fees_wei, execution_mev_fees_wei, sync_committee_rewards, execution_optimistic, timestamp,
+		reward_attestations, reward_sync_aggregate, reward_proposer_slashings, reward_attester_slashings
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	ON CONFLICT (validator_index, slot_number) DO UPDATE SET
+		validator_pubkey = EXCLUDED.validator_pubkey,
+		block_number = EXCLUDED.block_number,
+		rewards = EXCLUDED.rewards,
+		execution_priority_fees_wei = EXCLUDED.execution_priority_fees_wei,
+		execution_mev_fees_wei = EXCLUDED.execution_mev_fees_wei,
+		sync_committee_rewards = COALESCE(EXCLUDED.sync_committee_rewards, blocks.sync_committee_rewards),
+		execution_optimistic = EXCLUDED.execution_optimistic,
+		timestamp = EXCLUDED.timestamp,
+		reward_attestations = COALESCE(EXCLUDED.reward_attestations, blocks.reward_attestations),
+		reward_sync_aggregate = COALESCE(EXCLUDED.reward_sync_aggregate, blocks.reward_sync_aggregate),
+		reward_proposer_slashings = COALESCE(EXCLUDED.reward_proposer_slashings, blocks.reward_proposer_slashings),
+		reward_attester_slashings = COALESCE(EXCLUDED.reward_attester_slashings, blocks.reward_attester_slashings)
+`
+
 // SaveBlock upserts one indexed block row (canonical proposer at slot).
 func (r *Repository) SaveBlock(ctx context.Context, row *storage.Block) error {
+	args, err := blockArgs(row)
+	if err != nil {
+		return err
+	}
+	if _, err := r.client.Pool.Exec(ctx, upsertBlockQuery, args...); err != nil {
+		return fmt.Errorf("failed to save block: %w", err)
+	}
+	return nil
+}
+
+// SaveBlocks saves multiple indexed block rows, batching when given more than one.
+func (r *Repository) SaveBlocks(ctx context.Context, rows []*storage.Block) error {
+	switch len(rows) {
+	case 0:
+		return nil
+	case 1:
+		return r.SaveBlock(ctx, rows[0])
+	}
+	args := make([][]any, len(rows))
+	for i, row := range rows {
+		a, err := blockArgs(row)
+		if err != nil {
+			return err
+		}
+		args[i] = a
+	}
+	err := r.writeRows(ctx, upsertBlockQuery, args, func(i int) string {
+		return fmt.Sprintf("validator %d slot %d", rows[i].ValidatorIndex, rows[i].SlotNumber)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save blocks batch: %w", err)
+	}
+	return nil
+}
+
+func blockArgs(row *storage.Block) ([]any, error) {
 	if row.Timestamp.IsZero() {
 		row.Timestamp = time.Now().UTC()
 	}
 
-	const query = `
-		INSERT INTO blocks (
-			validator_index, validator_pubkey, slot_number, block_number, rewards,
-			execution_priority_fees_wei, execution_mev_fees_wei, sync_committee_rewards, timestamp
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		ON CONFLICT (validator_index, slot_number) DO UPDATE SET
-			validator_pubkey = EXCLUDED.validator_pubkey,
-			block_number = EXCLUDED.block_number,
-			rewards = EXCLUDED.rewards,
-			execution_priority_fees_wei = EXCLUDED.execution_priority_fees_wei,
-			execution_mev_fees_wei = EXCLUDED.execution_mev_fees_wei,
-			sync_committee_rewards = COALESCE(EXCLUDED.sync_committee_rewards, blocks.sync_committee_rewards),
-			timestamp = EXCLUDED.timestamp
-	`
-
 	var blockNum interface{}
 	if row.BlockNumber != nil {
 		blockNum = *row.BlockNumber
@@ -121,12 +187,17 @@ func (r *Repository) SaveBlock(ctx context.Context, row *storage.Block) error {
 	if row.SyncCommitteeRewards != nil {
 		b, err := json.Marshal(row.SyncCommitteeRewards)
 		if err != nil {
-			return fmt.Errorf("marshal sync committee rewards: %w", err)
+			return nil, fmt.Errorf("marshal sync committee rewards: %w", err)
 		}
 		syncRewards = b
 	}
 
-	_, err := r.client.Pool.Exec(ctx, query,
+	var attRwd, syncAggRwd, propSlashRwd, attSlashRwd interface{}
+	if c := row.RewardComponents; c != nil {
+		attRwd, syncAggRwd, propSlashRwd, attSlashRwd = c.Attestations, c.SyncAggregate, c.ProposerSlashings, c.AttesterSlashings
+	}
+
+	return []any{
 		row.ValidatorIndex,
 		row.ValidatorPubkey,
 		row.SlotNumber,
@@ -135,34 +206,85 @@ func (r *Repository) SaveBlock(ctx context.Context, row *storage.Block) error {
 		priWei,
 		mevWei,
 		syncRewards,
+		row.ExecutionOptimistic,
 		row.Timestamp,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to save block: %w", err)
-	}
-	return nil
+		attRwd,
+		syncAggRwd,
+		propSlashRwd,
+		attSlashRwd,
+	}, nil
 }
 
-// SaveBlocks saves multiple indexed block rows.
-func (r *Repository) SaveBlocks(ctx context.Context, rows []*storage.Block) error {
-	for _, row := range rows {
-		if err := r.SaveBlock(ctx, row); err != nil {
+// execBatch sends batch and checks every queued statement's result.
+func (r *Repository) execBatch(ctx context.Context, batch *pgx.Batch) error {
+	br := r.client.Pool.SendBatch(ctx, batch)
+	defer br.Close()
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := br.Exec(); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// ListValidatorEpochRecords returns a validator's epoch records in an epoch range, oldest first.
+func (r *Repository) ListValidatorEpochRecords(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.ValidatorEpochRecord, error) {
+	const query = `
+		SELECT validator_index, epoch, epoch_start_slot, status, balance, effective_balance,
+			head_reward, source_reward, target_reward, total_reward, execution_optimistic, slot_time, indexed_at,
+			activation_epoch, exit_epoch, withdrawable_epoch
+		FROM validator_epoch_records
+		WHERE validator_index = $1 AND epoch >= $2 AND epoch <= $3
+		ORDER BY epoch ASC
+	`
+
+	rows, err := r.client.ReadPool.Query(ctx, query, validatorIndex, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validator epoch records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*storage.ValidatorEpochRecord
+	for rows.Next() {
+		var rec storage.ValidatorEpochRecord
+		if err := rows.Scan(
+			&rec.ValidatorIndex,
+			&rec.Epoch,
+			&rec.EpochStartSlot,
+			&rec.Status,
+			&rec.Balance,
+			&rec.EffectiveBalance,
+			&rec.HeadReward,
+			&rec.SourceReward,
+			&rec.TargetReward,
+			&rec.TotalReward,
+			&rec.ExecutionOptimistic,
+			&rec.SlotTime,
+			&rec.IndexedAt,
+			&rec.ActivationEpoch,
+			&rec.ExitEpoch,
+			&rec.WithdrawableEpoch,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan validator epoch record: %w", err)
+		}
+		records = append(records, &rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate validator epoch records: %w", err)
+	}
+	return records, nil
+}
+
 // GetValidatorSnapshots retrieves epoch balance snapshots for a validator (slot = epoch_start_slot).
 func (r *Repository) GetValidatorSnapshots(ctx context.Context, validatorIndex uint64, fromSlot, toSlot uint64) ([]*storage.ValidatorSnapshot, error) {
 	const query = `
-		SELECT validator_index, epoch_start_slot, status, balance, effective_balance, indexed_at
+		SELECT validator_index, epoch_start_slot, status, balance, effective_balance, slot_time, indexed_at
 		FROM validator_epoch_records
 		WHERE validator_index = $1 AND epoch_start_slot >= $2 AND epoch_start_slot <= $3
 		ORDER BY epoch_start_slot DESC
 	`
 
-	rows, err := r.client.Pool.Query(ctx, query, validatorIndex, fromSlot, toSlot)
+	rows, err := r.client.ReadPool.Query(ctx, query, validatorIndex, fromSlot, toSlot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get validator snapshots: %w", err)
 	}
@@ -177,6 +299,7 @@ func (r *Repository) GetValidatorSnapshots(ctx context.Context, validatorIndex u
 			&s.Status,
 			&s.Balance,
 			&s.EffectiveBalance,
+			&s.SlotTime,
 			&s.Timestamp,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan validator snapshot: %w", err)
@@ -194,14 +317,14 @@ func (r *Repository) GetValidatorSnapshots(ctx context.Context, validatorIndex u
 // ListValidatorSnapshots returns epoch balance snapshots for a validator in a slot range (epoch start slots).
 func (r *Repository) ListValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64, limit, offset int) ([]*storage.ValidatorSnapshot, error) {
 	const query = `
-		SELECT validator_index, epoch_start_slot, status, balance, effective_balance, indexed_at
+		SELECT validator_index, epoch_start_slot, status, balance, effective_balance, slot_time, indexed_at
 		FROM validator_epoch_records
 		WHERE validator_index = $1 AND epoch_start_slot >= $2 AND epoch_start_slot <= $3
 		ORDER BY epoch_start_slot DESC
 		LIMIT $4 OFFSET $5
 	`
 
-	rows, err := r.client.Pool.Query(ctx, query, validatorIndex, fromSlot, toSlot, limit, offset)
+	rows, err := r.client.ReadPool.Query(ctx, query, validatorIndex, fromSlot, toSlot, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list validator snapshots: %w", err)
 	}
@@ -216,6 +339,7 @@ func (r *Repository) ListValidatorSnapshots(ctx context.Context, validatorIndex,
 			&s.Status,
 			&s.Balance,
 			&s.EffectiveBalance,
+			&s.SlotTime,
 			&s.Timestamp,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan validator snapshot: %w", err)
@@ -238,7 +362,7 @@ func (r *Repository) GetAttestationRewards(ctx context.Context, validatorIndex u
 		ORDER BY epoch DESC
 	`
 
-	rows, err := r.client.Pool.Query(ctx, query, validatorIndex, fromEpoch, toEpoch)
+	rows, err := r.client.ReadPool.Query(ctx, query, validatorIndex, fromEpoch, toEpoch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get attestation rewards: %w", err)
 	}
@@ -285,7 +409,7 @@ func (r *Repository) ListAttestationRewards(ctx context.Context, validatorIndex
 	fmt.Fprintf(&sb, " ORDER BY epoch DESC, validator_index ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
 	args = append(args, limit, offset)
 
-	rows, err := r.client.Pool.Query(ctx, sb.String(), args...)
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list attestation rewards: %w", err)
 	}
@@ -319,7 +443,8 @@ func (r *Repository) ListBlocks(ctx context.Context, validatorIndex *uint64, fro
 	var sb strings.Builder
 	sb.WriteString(`
 		SELECT validator_index, validator_pubkey, slot_number, block_number, rewards,
-			execution_priority_fees_wei, execution_mev_fees_wei, timestamp
+			execution_priority_fees_wei, execution_mev_fees_wei, execution_optimistic, timestamp,
+			reward_attestations, reward_sync_aggregate, reward_proposer_slashings, reward_attester_slashings
 		FROM blocks
 		WHERE slot_number >= $1 AND slot_number <= $2`)
 	args := []any{fromSlot, toSlot}
@@ -332,7 +457,7 @@ func (r *Repository) ListBlocks(ctx context.Context, validatorIndex *uint64, fro
 	fmt.Fprintf(&sb, " ORDER BY slot_number DESC, validator_index ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
 	args = append(args, limit, offset)
 
-	rows, err := r.client.Pool.Query(ctx, sb.String(), args...)
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list blocks: %w", err)
 	}
@@ -343,6 +468,7 @@ func (r *Repository) ListBlocks(ctx context.Context, validatorIndex *uint64, fro
 		var row storage.Block
 		var blockNum sql.NullInt64
 		var priWei, mevWei sql.NullString
+		var attRwd, syncAggRwd, propSlashRwd, attSlashRwd sql.NullInt64
 		if err := rows.Scan(
 			&row.ValidatorIndex,
 			&row.ValidatorPubkey,
@@ -351,10 +477,23 @@ func (r *Repository) ListBlocks(ctx context.Context, validatorIndex *uint64, fro
 			&row.Rewards,
 			&priWei,
 			&mevWei,
+			&row.ExecutionOptimistic,
 			&row.Timestamp,
+			&attRwd,
+			&syncAggRwd,
+			&propSlashRwd,
+			&attSlashRwd,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan block: %w", err)
 		}
+		if attRwd.Valid {
+			row.RewardComponents = &storage.BlockRewardComponents{
+				Attestations:      uint64(attRwd.Int64),
+				SyncAggregate:     uint64(syncAggRwd.Int64),
+				ProposerSlashings: uint64(propSlashRwd.Int64),
+				AttesterSlashings: uint64(attSlashRwd.Int64),
+			}
+		}
 		if blockNum.Valid {
 			bn := uint64(blockNum.Int64)
 			row.BlockNumber = &bn
@@ -399,7 +538,7 @@ func (r *Repository) listSyncCommitteeRewardsScoped(ctx context.Context, validat
 		ORDER BY slot_number DESC
 		LIMIT $4 OFFSET $5`
 
-	rows, err := r.client.Pool.Query(ctx, query, fromSlot, toSlot, idxKey, limit, offset)
+	rows, err := r.client.ReadPool.Query(ctx, query, fromSlot, toSlot, idxKey, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sync committee rewards: %w", err)
 	}
@@ -423,7 +562,7 @@ func (r *Repository) listSyncCommitteeRewardsGlobal(ctx context.Context, fromSlo
 		ORDER BY b.slot_number DESC, t.key::bigint ASC
 		LIMIT $3 OFFSET $4`
 
-	rows, err := r.client.Pool.Query(ctx, query, fromSlot, toSlot, limit, offset)
+	rows, err := r.client.ReadPool.Query(ctx, query, fromSlot, toSlot, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sync committee rewards: %w", err)
 	}
@@ -482,7 +621,7 @@ func (r *Repository) ListValidators(ctx context.Context, limit, offset int) ([]u
 		ORDER BY validator_index ASC
 		LIMIT $1 OFFSET $2
 	`
-	rows, err := r.client.Pool.Query(ctx, query, limit, offset)
+	rows, err := r.client.ReadPool.Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list validators: %w", err)
 	}
@@ -505,7 +644,7 @@ func (r *Repository) ListValidators(ctx context.Context, limit, offset int) ([]u
 // GetLatestSnapshot retrieves the most recent epoch balance snapshot for a validator.
 func (r *Repository) GetLatestSnapshot(ctx context.Context, validatorIndex uint64) (*storage.ValidatorSnapshot, error) {
 	const query = `
-		SELECT validator_index, epoch_start_slot, status, balance, effective_balance, indexed_at
+		SELECT validator_index, epoch_start_slot, status, balance, effective_balance, slot_time, indexed_at
 		FROM validator_epoch_records
 		WHERE validator_index = $1
 		ORDER BY epoch DESC
@@ -513,12 +652,13 @@ func (r *Repository) GetLatestSnapshot(ctx context.Context, validatorIndex uint6
 	`
 
 	var snapshot storage.ValidatorSnapshot
-	if err := r.client.Pool.QueryRow(ctx, query, validatorIndex).Scan(
+	if err := r.client.ReadPool.QueryRow(ctx, query, validatorIndex).Scan(
 		&snapshot.ValidatorIndex,
 		&snapshot.Slot,
 		&snapshot.Status,
 		&snapshot.Balance,
 		&snapshot.EffectiveBalance,
+		&snapshot.SlotTime,
 		&snapshot.Timestamp,
 	); err != nil {
 		return nil, fmt.Errorf("failed to get latest snapshot: %w", err)
@@ -531,7 +671,7 @@ func (r *Repository) CountSnapshots(ctx context.Context, validatorIndex uint64)
 	const query = `SELECT COUNT(*) FROM validator_epoch_records WHERE validator_index = $1`
 
 	var count int
-	if err := r.client.Pool.QueryRow(ctx, query, validatorIndex).Scan(&count); err != nil {
+	if err := r.client.ReadPool.QueryRow(ctx, query, validatorIndex).Scan(&count); err != nil {
 		return 0, fmt.Errorf("failed to count snapshots: %w", err)
 	}
 	return count, nil
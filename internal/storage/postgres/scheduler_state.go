@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveSchedulerState upserts one scheduler cursor.
+func (r *Repository) SaveSchedulerState(ctx context.Context, state *storage.SchedulerState) error {
+	const q = `
+		INSERT INTO scheduler_state (kind, position, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (kind) DO UPDATE SET
+			position = EXCLUDED.position,
+			updated_at = EXCLUDED.updated_at`
+	if state.UpdatedAt.IsZero() {
+		state.UpdatedAt = time.Now().UTC()
+	}
+	if _, err := r.client.Pool.Exec(ctx, q, state.Kind, state.Position, state.UpdatedAt); err != nil {
+		return fmt.Errorf("save scheduler state %s: %w", state.Kind, err)
+	}
+	return nil
+}
+
+// GetSchedulerState returns the stored cursor for kind, if any.
+func (r *Repository) GetSchedulerState(ctx context.Context, kind string) (*storage.SchedulerState, bool, error) {
+	const q = `SELECT position, updated_at FROM scheduler_state WHERE kind = $1`
+	state := &storage.SchedulerState{Kind: kind}
+	var position int64
+	err := r.client.Pool.QueryRow(ctx, q, kind).Scan(&position, &state.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("get scheduler state %s: %w", kind, err)
+	}
+	state.Position = uint64(position)
+	return state, true, nil
+}
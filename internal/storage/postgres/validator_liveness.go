@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveValidatorLiveness upserts liveness verdicts (keyed by validator and epoch).
+func (r *Repository) SaveValidatorLiveness(ctx context.Context, rows []*storage.ValidatorLiveness) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO validator_liveness (validator_index, epoch, is_live, checked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (validator_index, epoch) DO UPDATE SET
+			is_live = EXCLUDED.is_live,
+			checked_at = EXCLUDED.checked_at
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, row := range rows {
+		if row.CheckedAt.IsZero() {
+			row.CheckedAt = now
+		}
+		batch.Queue(query, row.ValidatorIndex, row.Epoch, row.IsLive, row.CheckedAt)
+	}
+	if err := r.execBatch(ctx, batch); err != nil {
+		return fmt.Errorf("failed to save validator liveness batch: %w", err)
+	}
+	return nil
+}
+
+// ListValidatorLiveness returns liveness verdicts for an epoch range, optionally filtered to one validator.
+func (r *Repository) ListValidatorLiveness(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*storage.ValidatorLiveness, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT validator_index, epoch, is_live, checked_at
+		FROM validator_liveness
+		WHERE epoch >= $1 AND epoch <= $2`)
+	args := []any{fromEpoch, toEpoch}
+	argPos := 3
+	if validatorIndex != nil {
+		fmt.Fprintf(&sb, " AND validator_index = $%d", argPos)
+		args = append(args, *validatorIndex)
+		argPos++
+	}
+	fmt.Fprintf(&sb, " ORDER BY epoch DESC, validator_index ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validator liveness: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.ValidatorLiveness
+	for rows.Next() {
+		var row storage.ValidatorLiveness
+		if err := rows.Scan(&row.ValidatorIndex, &row.Epoch, &row.IsLive, &row.CheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan validator liveness: %w", err)
+		}
+		cp := row
+		out = append(out, &cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate validator liveness: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// SaveValidatorSetEvents appends validator set change events in one batch.
+func (r *Repository) SaveValidatorSetEvents(ctx context.Context, events []*storage.ValidatorSetEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	const query = `
+		INSERT INTO validator_set_events (validator_index, event, source, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, ev := range events {
+		if ev.Timestamp.IsZero() {
+			ev.Timestamp = now
+		}
+		batch.Queue(query, ev.ValidatorIndex, ev.Event, ev.Source, ev.Timestamp)
+	}
+	br := r.client.Pool.SendBatch(ctx, batch)
+	defer br.Close()
+	for range events {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("failed to save validator set events batch: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListValidatorSetEvents returns validator set events newest first, optionally filtered to one validator.
+func (r *Repository) ListValidatorSetEvents(ctx context.Context, validatorIndex *uint64, limit, offset int) ([]*storage.ValidatorSetEvent, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT validator_index, event, source, created_at
+		FROM validator_set_events`)
+	var args []any
+	argPos := 1
+	if validatorIndex != nil {
+		fmt.Fprintf(&sb, " WHERE validator_index = $%d", argPos)
+		args = append(args, *validatorIndex)
+		argPos++
+	}
+	fmt.Fprintf(&sb, " ORDER BY id DESC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.client.ReadPool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validator set events: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.ValidatorSetEvent
+	for rows.Next() {
+		var ev storage.ValidatorSetEvent
+		if err := rows.Scan(&ev.ValidatorIndex, &ev.Event, &ev.Source, &ev.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan validator set event: %w", err)
+		}
+		cp := ev
+		out = append(out, &cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate validator set events: %w", err)
+	}
+	return out, nil
+}
+
+// ListWatchedValidators returns indices whose most recent event is validator_added.
+func (r *Repository) ListWatchedValidators(ctx context.Context) ([]uint64, error) {
+	const query = `
+		SELECT validator_index FROM (
+			SELECT DISTINCT ON (validator_index) validator_index, event
+			FROM validator_set_events
+			ORDER BY validator_index, id DESC
+		) latest
+		WHERE event = $1
+		ORDER BY validator_index ASC
+	`
+	rows, err := r.client.Pool.Query(ctx, query, storage.ValidatorSetEventAdded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched validators: %w", err)
+	}
+	defer rows.Close()
+
+	var out []uint64
+	for rows.Next() {
+		var idx uint64
+		if err := rows.Scan(&idx); err != nil {
+			return nil, fmt.Errorf("failed to scan watched validator: %w", err)
+		}
+		out = append(out, idx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate watched validators: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteValidatorHistory removes attestation duties and liveness rows for one validator.
+func (r *Repository) DeleteValidatorHistory(ctx context.Context, validatorIndex uint64) error {
+	for _, table := range []string{"attestation_duties", "attestation_liveness", "duty_mismatches", "validator_liveness"} {
+		if _, err := r.client.Pool.Exec(ctx, "DELETE FROM "+table+" WHERE validator_index = $1", validatorIndex); err != nil {
+			return fmt.Errorf("failed to delete %s for validator %d: %w", table, validatorIndex, err)
+		}
+	}
+	return nil
+}
+
+// purgeValidatorTables lists every table with per-validator rows keyed by validator_index (blocks excluded).
+var purgeValidatorTables = []string{
+	"attestation_duties", "attestation_liveness", "duty_mismatches", "validator_liveness",
+	"validator_epoch_records", "validator_set_events",
+}
+
+// PurgeValidator deletes all of a validator's rows from purgeValidatorTables in one transaction.
+func (r *Repository) PurgeValidator(ctx context.Context, validatorIndex uint64) (int64, error) {
+	tx, err := r.client.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin purge of validator %d: %w", validatorIndex, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var deleted int64
+	for _, table := range purgeValidatorTables {
+		tag, err := tx.Exec(ctx, "DELETE FROM "+table+" WHERE validator_index = $1", validatorIndex)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge %s for validator %d: %w", table, validatorIndex, err)
+		}
+		deleted += tag.RowsAffected()
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit purge of validator %d: %w", validatorIndex, err)
+	}
+	return deleted, nil
+}
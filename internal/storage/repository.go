@@ -1,19 +1,60 @@
 package storage
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the data access methods for validator data.
 type Repository interface {
 	SaveValidatorEpochRecords(ctx context.Context, records []*ValidatorEpochRecord) error
+	// ListValidatorEpochRecords returns a validator's full epoch records in fromEpoch..toEpoch, ascending.
+	ListValidatorEpochRecords(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*ValidatorEpochRecord, error)
 	SaveBlock(ctx context.Context, row *Block) error
 	SaveBlocks(ctx context.Context, rows []*Block) error
 	GetValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64) ([]*ValidatorSnapshot, error)
 	ListValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64, limit, offset int) ([]*ValidatorSnapshot, error)
+	// GetEffectiveBalanceSeries returns one point per epoch in fromEpoch..toEpoch (ascending); epochs without an
+	// indexed record are EffectiveBalanceMissing points.
+	GetEffectiveBalanceSeries(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*EffectiveBalancePoint, error)
 	GetAttestationRewards(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*AttestationReward, error)
 	// ListAttestationRewards returns attestation rewards in epoch order (newest epoch first). If validatorIndex is nil, all validators are included.
 	ListAttestationRewards(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*AttestationReward, error)
 	ListBlocks(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*Block, error)
 	ListSyncCommitteeRewards(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*SyncCommitteeReward, error)
+	SaveAttestationDuties(ctx context.Context, duties []*AttestationDuty) error
+	// ListAttestationDuties returns duties in a slot range (newest slot first). If validatorIndex is nil, all validators are included.
+	ListAttestationDuties(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*AttestationDuty, error)
+	// GetDutiesForSlot returns the stored duties of every watched validator attesting at slot, by committee and position.
+	GetDutiesForSlot(ctx context.Context, slot uint64) ([]*AttestationDuty, error)
+	// SaveProposerDuties upserts proposer duties and their outcomes (keyed by slot).
+	SaveProposerDuties(ctx context.Context, duties []*ProposerDuty) error
+	// ListProposerDuties returns proposer duties in a slot range (newest slot first). If validatorIndex is nil, all validators are included.
+	ListProposerDuties(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*ProposerDuty, error)
+	SaveAttestationLiveness(ctx context.Context, rows []*AttestationLiveness) error
+	// ListAttestationLiveness returns liveness rows in a slot range (newest slot first). If validatorIndex is nil, all validators are included.
+	ListAttestationLiveness(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*AttestationLiveness, error)
+	// ReconcileAttestationLiveness sets reward_included on the epoch's liveness rows from validator_epoch_records
+	// and returns how many rows were updated.
+	ReconcileAttestationLiveness(ctx context.Context, epoch uint64) (int64, error)
+	SaveValidatorLiveness(ctx context.Context, rows []*ValidatorLiveness) error
+	// ListValidatorLiveness returns liveness rows in an epoch range (newest epoch first). If validatorIndex is nil, all validators are included.
+	ListValidatorLiveness(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*ValidatorLiveness, error)
+	SaveDutyMismatches(ctx context.Context, rows []*DutyMismatch) error
+	// ListDutyMismatches returns mismatches in a slot range (newest slot first). If validatorIndex is nil, all validators are included.
+	ListDutyMismatches(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*DutyMismatch, error)
+	SaveValidatorSetEvents(ctx context.Context, events []*ValidatorSetEvent) error
+	// ListValidatorSetEvents returns events newest first. If validatorIndex is nil, all validators are included.
+	ListValidatorSetEvents(ctx context.Context, validatorIndex *uint64, limit, offset int) ([]*ValidatorSetEvent, error)
+	// ListWatchedValidators returns indices whose latest validator set event is validator_added, ascending.
+	ListWatchedValidators(ctx context.Context) ([]uint64, error)
+	// DeleteValidatorHistory removes per-validator rows (attestation duties, liveness, duty mismatches, validator liveness) for a removed index.
+	// Network-wide tables such as validator_epoch_records and blocks are kept.
+	DeleteValidatorHistory(ctx context.Context, validatorIndex uint64) error
+	// PurgeValidator irreversibly deletes every row keyed by the validator index, including its epoch records and
+	// validator set events, in one transaction, and returns the number of rows deleted. Blocks it proposed are kept:
+	// they are per-slot chain records (covered by slot progress) and carry other validators' sync rewards.
+	PurgeValidator(ctx context.Context, validatorIndex uint64) (int64, error)
 	ListValidators(ctx context.Context, limit, offset int) ([]uint64, error)
 	GetLatestSnapshot(ctx context.Context, validatorIndex uint64) (*ValidatorSnapshot, error)
 	CountSnapshots(ctx context.Context, validatorIndex uint64) (int, error)
@@ -26,6 +67,14 @@ type Repository interface {
 	FirstUnindexedEpoch(ctx context.Context, from, to uint64) (epoch uint64, ok bool, err error)
 	IsSlotIndexed(ctx context.Context, slot uint64) (bool, error)
 	IsEpochIndexed(ctx context.Context, epoch uint64) (bool, error)
+	SaveSchedulerState(ctx context.Context, state *SchedulerState) error
+	GetSchedulerState(ctx context.Context, kind string) (state *SchedulerState, ok bool, err error)
+	// MarkEpochProcessed records marks in epoch_processed and returns those that were not recorded before;
+	// callers apply aggregate updates for the returned marks only.
+	MarkEpochProcessed(ctx context.Context, marks []*EpochProcessed) ([]*EpochProcessed, error)
+	SaveRawResponses(ctx context.Context, rows []*RawResponse) error
+	// DeleteRawResponsesBefore removes raw responses fetched before the cutoff and returns how many were deleted.
+	DeleteRawResponsesBefore(ctx context.Context, before time.Time) (int64, error)
 
 	Close() error
 }
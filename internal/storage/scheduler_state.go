@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scheduler state kinds for scheduler_state.kind.
+const (
+	// SchedulerStateRealtimeSlot is the last head slot the realtime chain completed.
+	SchedulerStateRealtimeSlot = "realtime_slot"
+	// SchedulerStateLivenessEpoch is the last epoch checked via the validator liveness endpoint.
+	SchedulerStateLivenessEpoch = "validator_liveness_epoch"
+	// SchedulerStateArchiveEpoch prefixes the per-validator archive cursors; see ArchiveEpochKind.
+	SchedulerStateArchiveEpoch = "archive_epoch"
+)
+
+// ArchiveEpochKind is the scheduler_state kind holding the last epoch of validator whose records were
+// copied to the object storage archive.
+func ArchiveEpochKind(validator uint64) string {
+	return fmt.Sprintf("%s:%d", SchedulerStateArchiveEpoch, validator)
+}
+
+// SchedulerState is one persisted realtime cursor and when it last moved.
+type SchedulerState struct {
+	Kind      string    `json:"kind"`
+	Position  uint64    `json:"position"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,335 @@
+// Package tenant routes per-validator rows to the storage of the tenant that owns the validator
+// (tenants), so one pauli instance can index validators for several tenants with separated storage.
+// Rows of validators outside any tenant, and network-wide rows (blocks, indexer progress, scheduler
+// state, raw responses), go to the default repository.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tharun/pauli/internal/storage"
+)
+
+// Tenant is one tenant's repository and the validators it owns.
+type Tenant struct {
+	Repo       storage.Repository
+	Validators []uint64
+}
+
+// Repository wraps the default storage.Repository and sends each validator's rows to its tenant.
+// Writes are split by validator index; reads for one validator go to its tenant. Listings without a
+// validator filter (validatorIndex nil) read the default repository only, except GetDutiesForSlot,
+// ListWatchedValidators and ListValidators, which merge every tenant.
+type Repository struct {
+	storage.Repository
+
+	// repos[0] is the default repository, repos[i] tenant i-1's.
+	repos []storage.Repository
+	owner map[uint64]int
+}
+
+// NewRepository routes the validators of each tenant to its repository and everything else to def.
+func NewRepository(def storage.Repository, tenants []Tenant) *Repository {
+	r := &Repository{Repository: def, repos: []storage.Repository{def}, owner: make(map[uint64]int)}
+	for _, t := range tenants {
+		r.repos = append(r.repos, t.Repo)
+		for _, v := range t.Validators {
+			r.owner[v] = len(r.repos) - 1
+		}
+	}
+	return r
+}
+
+// repo returns the repository holding validatorIndex's rows.
+func (r *Repository) repo(validatorIndex uint64) storage.Repository {
+	return r.repos[r.owner[validatorIndex]]
+}
+
+// repoFor is repo for an optional validator filter; nil selects the default repository.
+func (r *Repository) repoFor(validatorIndex *uint64) storage.Repository {
+	if validatorIndex == nil {
+		return r.Repository
+	}
+	return r.repo(*validatorIndex)
+}
+
+// save splits rows by owning repository and writes each part, attempting every part even if one fails.
+func save[T any](r *Repository, rows []T, index func(T) uint64, write func(storage.Repository, []T) error) error {
+	parts := make([][]T, len(r.repos))
+	for _, row := range rows {
+		i := r.owner[index(row)]
+		parts[i] = append(parts[i], row)
+	}
+	var errs []error
+	for i, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		if err := write(r.repos[i], part); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SaveValidatorEpochRecords writes each record to its validator's tenant.
+func (r *Repository) SaveValidatorEpochRecords(ctx context.Context, records []*storage.ValidatorEpochRecord) error {
+	return save(r, records, func(rec *storage.ValidatorEpochRecord) uint64 { return rec.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.ValidatorEpochRecord) error {
+			return repo.SaveValidatorEpochRecords(ctx, part)
+		})
+}
+
+// SaveAttestationDuties writes each duty to its validator's tenant.
+func (r *Repository) SaveAttestationDuties(ctx context.Context, duties []*storage.AttestationDuty) error {
+	return save(r, duties, func(d *storage.AttestationDuty) uint64 { return d.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.AttestationDuty) error {
+			return repo.SaveAttestationDuties(ctx, part)
+		})
+}
+
+// SaveProposerDuties writes each duty to its validator's tenant.
+func (r *Repository) SaveProposerDuties(ctx context.Context, duties []*storage.ProposerDuty) error {
+	return save(r, duties, func(d *storage.ProposerDuty) uint64 { return d.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.ProposerDuty) error {
+			return repo.SaveProposerDuties(ctx, part)
+		})
+}
+
+// SaveAttestationLiveness writes each row to its validator's tenant.
+func (r *Repository) SaveAttestationLiveness(ctx context.Context, rows []*storage.AttestationLiveness) error {
+	return save(r, rows, func(l *storage.AttestationLiveness) uint64 { return l.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.AttestationLiveness) error {
+			return repo.SaveAttestationLiveness(ctx, part)
+		})
+}
+
+// SaveValidatorLiveness writes each row to its validator's tenant.
+func (r *Repository) SaveValidatorLiveness(ctx context.Context, rows []*storage.ValidatorLiveness) error {
+	return save(r, rows, func(l *storage.ValidatorLiveness) uint64 { return l.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.ValidatorLiveness) error {
+			return repo.SaveValidatorLiveness(ctx, part)
+		})
+}
+
+// SaveDutyMismatches writes each mismatch to its validator's tenant.
+func (r *Repository) SaveDutyMismatches(ctx context.Context, rows []*storage.DutyMismatch) error {
+	return save(r, rows, func(m *storage.DutyMismatch) uint64 { return m.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.DutyMismatch) error {
+			return repo.SaveDutyMismatches(ctx, part)
+		})
+}
+
+// SaveValidatorSetEvents writes each event to its validator's tenant.
+func (r *Repository) SaveValidatorSetEvents(ctx context.Context, events []*storage.ValidatorSetEvent) error {
+	return save(r, events, func(e *storage.ValidatorSetEvent) uint64 { return e.ValidatorIndex },
+		func(repo storage.Repository, part []*storage.ValidatorSetEvent) error {
+			return repo.SaveValidatorSetEvents(ctx, part)
+		})
+}
+
+// Reads for one validator go to its tenant; unfiltered listings read the default repository.
+
+func (r *Repository) GetValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64) ([]*storage.ValidatorSnapshot, error) {
+	return r.repo(validatorIndex).GetValidatorSnapshots(ctx, validatorIndex, fromSlot, toSlot)
+}
+
+func (r *Repository) ListValidatorSnapshots(ctx context.Context, validatorIndex, fromSlot, toSlot uint64, limit, offset int) ([]*storage.ValidatorSnapshot, error) {
+	return r.repo(validatorIndex).ListValidatorSnapshots(ctx, validatorIndex, fromSlot, toSlot, limit, offset)
+}
+
+func (r *Repository) GetEffectiveBalanceSeries(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.EffectiveBalancePoint, error) {
+	return r.repo(validatorIndex).GetEffectiveBalanceSeries(ctx, validatorIndex, fromEpoch, toEpoch)
+}
+
+func (r *Repository) GetAttestationRewards(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.AttestationReward, error) {
+	return r.repo(validatorIndex).GetAttestationRewards(ctx, validatorIndex, fromEpoch, toEpoch)
+}
+
+func (r *Repository) ListAttestationRewards(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*storage.AttestationReward, error) {
+	return r.repoFor(validatorIndex).ListAttestationRewards(ctx, validatorIndex, fromEpoch, toEpoch, limit, offset)
+}
+
+func (r *Repository) ListAttestationDuties(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.AttestationDuty, error) {
+	return r.repoFor(validatorIndex).ListAttestationDuties(ctx, validatorIndex, fromSlot, toSlot, limit, offset)
+}
+
+func (r *Repository) ListProposerDuties(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.ProposerDuty, error) {
+	return r.repoFor(validatorIndex).ListProposerDuties(ctx, validatorIndex, fromSlot, toSlot, limit, offset)
+}
+
+func (r *Repository) ListAttestationLiveness(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.AttestationLiveness, error) {
+	return r.repoFor(validatorIndex).ListAttestationLiveness(ctx, validatorIndex, fromSlot, toSlot, limit, offset)
+}
+
+func (r *Repository) ListValidatorLiveness(ctx context.Context, validatorIndex *uint64, fromEpoch, toEpoch uint64, limit, offset int) ([]*storage.ValidatorLiveness, error) {
+	return r.repoFor(validatorIndex).ListValidatorLiveness(ctx, validatorIndex, fromEpoch, toEpoch, limit, offset)
+}
+
+func (r *Repository) ListDutyMismatches(ctx context.Context, validatorIndex *uint64, fromSlot, toSlot uint64, limit, offset int) ([]*storage.DutyMismatch, error) {
+	return r.repoFor(validatorIndex).ListDutyMismatches(ctx, validatorIndex, fromSlot, toSlot, limit, offset)
+}
+
+func (r *Repository) ListValidatorSetEvents(ctx context.Context, validatorIndex *uint64, limit, offset int) ([]*storage.ValidatorSetEvent, error) {
+	return r.repoFor(validatorIndex).ListValidatorSetEvents(ctx, validatorIndex, limit, offset)
+}
+
+func (r *Repository) ListValidatorEpochRecords(ctx context.Context, validatorIndex, fromEpoch, toEpoch uint64) ([]*storage.ValidatorEpochRecord, error) {
+	return r.repo(validatorIndex).ListValidatorEpochRecords(ctx, validatorIndex, fromEpoch, toEpoch)
+}
+
+func (r *Repository) GetLatestSnapshot(ctx context.Context, validatorIndex uint64) (*storage.ValidatorSnapshot, error) {
+	return r.repo(validatorIndex).GetLatestSnapshot(ctx, validatorIndex)
+}
+
+func (r *Repository) CountSnapshots(ctx context.Context, validatorIndex uint64) (int, error) {
+	return r.repo(validatorIndex).CountSnapshots(ctx, validatorIndex)
+}
+
+func (r *Repository) DeleteValidatorHistory(ctx context.Context, validatorIndex uint64) error {
+	return r.repo(validatorIndex).DeleteValidatorHistory(ctx, validatorIndex)
+}
+
+func (r *Repository) PurgeValidator(ctx context.Context, validatorIndex uint64) (int64, error) {
+	return r.repo(validatorIndex).PurgeValidator(ctx, validatorIndex)
+}
+
+// GetDutiesForSlot merges the slot's duties from every tenant.
+func (r *Repository) GetDutiesForSlot(ctx context.Context, slot uint64) ([]*storage.AttestationDuty, error) {
+	var out []*storage.AttestationDuty
+	for _, repo := range r.repos {
+		duties, err := repo.GetDutiesForSlot(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, duties...)
+	}
+	return out, nil
+}
+
+// ReconcileAttestationLiveness reconciles the epoch in every tenant (each against its own epoch records).
+func (r *Repository) ReconcileAttestationLiveness(ctx context.Context, epoch uint64) (int64, error) {
+	var total int64
+	for _, repo := range r.repos {
+		n, err := repo.ReconcileAttestationLiveness(ctx, epoch)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// ListWatchedValidators merges the watched validators of every tenant, ascending.
+func (r *Repository) ListWatchedValidators(ctx context.Context) ([]uint64, error) {
+	return r.mergeIndices(func(repo storage.Repository) ([]uint64, error) {
+		return repo.ListWatchedValidators(ctx)
+	}, 0, 0)
+}
+
+// ListValidators pages through the validators of every tenant, ascending.
+func (r *Repository) ListValidators(ctx context.Context, limit, offset int) ([]uint64, error) {
+	return r.mergeIndices(func(repo storage.Repository) ([]uint64, error) {
+		return repo.ListValidators(ctx, limit+offset, 0)
+	}, limit, offset)
+}
+
+// mergeIndices unions list over every repository and returns the ascending page [offset, offset+limit)
+// (everything when limit is 0).
+func (r *Repository) mergeIndices(list func(storage.Repository) ([]uint64, error), limit, offset int) ([]uint64, error) {
+	var out []uint64
+	for _, repo := range r.repos {
+		indices, err := list(repo)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, indices...)
+	}
+	slices.Sort(out)
+	out = slices.Compact(out)
+	if offset >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if limit > 0 && limit < len(out) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// Close closes every tenant's repository.
+func (r *Repository) Close() error {
+	var errs []error
+	for _, repo := range r.repos {
+		errs = append(errs, repo.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Store routes the repository of a default store to per-tenant stores.
+type Store struct {
+	storage.Store
+	tenants []StoreTenant
+	repo    *Repository
+}
+
+// StoreTenant is one tenant's store and the validators it owns.
+type StoreTenant struct {
+	Name       string
+	Store      storage.Store
+	Validators []uint64
+}
+
+// NewStore wraps def so the tenants' validators are stored in their own stores.
+func NewStore(def storage.Store, tenants []StoreTenant) *Store {
+	s := &Store{Store: def}
+	repos := make([]Tenant, 0, len(tenants))
+	for _, t := range tenants {
+		s.tenants = append(s.tenants, t)
+		repos = append(repos, Tenant{Repo: t.Store.Repository(), Validators: t.Validators})
+	}
+	s.repo = NewRepository(def.Repository(), repos)
+	return s
+}
+
+// RunMigrations migrates the default store and every tenant's.
+func (s *Store) RunMigrations() error {
+	if err := s.Store.RunMigrations(); err != nil {
+		return err
+	}
+	for _, t := range s.tenants {
+		if err := t.Store.RunMigrations(); err != nil {
+			return fmt.Errorf("tenant %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// HealthCheck checks the default store and every tenant's.
+func (s *Store) HealthCheck() error {
+	if err := s.Store.HealthCheck(); err != nil {
+		return err
+	}
+	for _, t := range s.tenants {
+		if err := t.Store.HealthCheck(); err != nil {
+			return fmt.Errorf("tenant %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// Repository returns the routing repository.
+func (s *Store) Repository() storage.Repository {
+	return s.repo
+}
+
+// Close closes every tenant's store and the default store.
+func (s *Store) Close() {
+	for _, t := range s.tenants {
+		t.Store.Close()
+	}
+	s.Store.Close()
+}
@@ -0,0 +1,86 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/noop"
+)
+
+type recordingRepo struct {
+	*noop.Repository
+	records []uint64 // validator indices of saved epoch records
+	duties  []*storage.AttestationDuty
+	watched []uint64
+}
+
+func newRecordingRepo() *recordingRepo {
+	return &recordingRepo{Repository: noop.NewRepository()}
+}
+
+func (r *recordingRepo) SaveValidatorEpochRecords(_ context.Context, records []*storage.ValidatorEpochRecord) error {
+	for _, rec := range records {
+		r.records = append(r.records, rec.ValidatorIndex)
+	}
+	return nil
+}
+
+func (r *recordingRepo) SaveAttestationDuties(_ context.Context, duties []*storage.AttestationDuty) error {
+	r.duties = append(r.duties, duties...)
+	return nil
+}
+
+func (r *recordingRepo) GetDutiesForSlot(_ context.Context, slot uint64) ([]*storage.AttestationDuty, error) {
+	var out []*storage.AttestationDuty
+	for _, d := range r.duties {
+		if d.Slot == slot {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *recordingRepo) ListWatchedValidators(context.Context) ([]uint64, error) {
+	return r.watched, nil
+}
+
+func TestRepository_routesRowsByValidator(t *testing.T) {
+	def, acme, globex := newRecordingRepo(), newRecordingRepo(), newRecordingRepo()
+	repo := NewRepository(def, []Tenant{
+		{Repo: acme, Validators: []uint64{10, 11}},
+		{Repo: globex, Validators: []uint64{20}},
+	})
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveValidatorEpochRecords(ctx, []*storage.ValidatorEpochRecord{
+		{ValidatorIndex: 1}, {ValidatorIndex: 10}, {ValidatorIndex: 20}, {ValidatorIndex: 11},
+	}))
+	require.Equal(t, []uint64{1}, def.records)
+	require.Equal(t, []uint64{10, 11}, acme.records)
+	require.Equal(t, []uint64{20}, globex.records)
+
+	require.NoError(t, repo.SaveAttestationDuties(ctx, []*storage.AttestationDuty{
+		{ValidatorIndex: 1, Slot: 5}, {ValidatorIndex: 10, Slot: 5}, {ValidatorIndex: 20, Slot: 6},
+	}))
+	duties, err := repo.GetDutiesForSlot(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, duties, 2, "duties are merged across tenants")
+	require.Len(t, acme.duties, 1)
+}
+
+func TestRepository_mergesValidatorLists(t *testing.T) {
+	def, acme := newRecordingRepo(), newRecordingRepo()
+	def.watched = []uint64{1, 30}
+	acme.watched = []uint64{10, 11}
+	repo := NewRepository(def, []Tenant{{Repo: acme, Validators: []uint64{10, 11}}})
+
+	watched, err := repo.ListWatchedValidators(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 10, 11, 30}, watched)
+
+	page, err := repo.mergeIndices(func(storage.Repository) ([]uint64, error) { return []uint64{4, 2, 3}, nil }, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 4}, page)
+}
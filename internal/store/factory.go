@@ -1,12 +1,77 @@
 package store
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/tharun/pauli/internal/archive"
 	"github.com/tharun/pauli/internal/config"
 	"github.com/tharun/pauli/internal/storage"
+	"github.com/tharun/pauli/internal/storage/changeonly"
+	"github.com/tharun/pauli/internal/storage/jsonl"
+	"github.com/tharun/pauli/internal/storage/noop"
 	"github.com/tharun/pauli/internal/storage/postgres"
+	"github.com/tharun/pauli/internal/storage/tenant"
 )
 
-// NewStore creates a new PostgreSQL-backed storage.Store.
+// NewStore creates the storage.Store selected by database_driver, routing tenants' validators to their
+// own schemas, reading aged validator history from the archive when archive is enabled, teeing saved rows
+// to stdout as JSON Lines when output_jsonl is set and skipping unchanged epoch records when
+// snapshot_writes.change_only is set.
 func NewStore(cfg *config.Config) (storage.Store, error) {
-	return postgres.NewStore(&cfg.Postgres)
+	var s storage.Store
+	switch cfg.DatabaseDriver {
+	case "none":
+		s = noop.NewStore()
+	default:
+		pg, err := postgres.NewStore(&cfg.Postgres, cfg.RetentionTTL())
+		if err != nil {
+			return nil, err
+		}
+		s = pg
+		if len(cfg.Tenants) > 0 {
+			if s, err = newTenantStore(cfg, pg); err != nil {
+				pg.Close()
+				return nil, err
+			}
+		}
+	}
+	if cfg.Archive.Enabled {
+		objects, err := archive.NewS3ClientFromConfig(cfg.Archive)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		s = archive.NewStore(s, archive.NewReader(objects, cfg.Archive.Prefix))
+	}
+	if cfg.OutputJSONL {
+		s = jsonl.NewStore(s, os.Stdout)
+	}
+	// Outermost, so skipped records are not teed either.
+	if cfg.SnapshotWrites.ChangeOnly {
+		s = changeonly.NewStore(s, changeonly.Options{
+			Fields:                    cfg.SnapshotWrites.Fields,
+			FullSnapshotIntervalSlots: cfg.SnapshotWrites.FullSnapshotIntervalSlots,
+		})
+	}
+	return s, nil
+}
+
+// newTenantStore opens one PostgreSQL store per tenant, on the default connection settings with the
+// tenant's schema, and routes their validators' rows there.
+func newTenantStore(cfg *config.Config, def storage.Store) (storage.Store, error) {
+	tenants := make([]tenant.StoreTenant, 0, len(cfg.Tenants))
+	for _, t := range cfg.Tenants {
+		pgCfg := cfg.Postgres
+		pgCfg.Schema = t.Schema
+		s, err := postgres.NewStore(&pgCfg, cfg.RetentionTTL())
+		if err != nil {
+			for _, opened := range tenants {
+				opened.Store.Close()
+			}
+			return nil, fmt.Errorf("tenant %s: %w", t.Name, err)
+		}
+		tenants = append(tenants, tenant.StoreTenant{Name: t.Name, Store: s, Validators: t.Validators})
+	}
+	return tenant.NewStore(def, tenants), nil
 }
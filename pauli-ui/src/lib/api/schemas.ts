@@ -63,6 +63,14 @@ export const blockProposerRewardRowSchema = z.object({
   rewards: z.coerce.number(),
   execution_priority_fees_wei: z.union([z.string(), z.null()]).optional(),
   execution_mev_fees_wei: z.union([z.string(), z.null()]).optional(),
+  reward_components: z
+    .object({
+      attestations: z.coerce.number(),
+      sync_aggregate: z.coerce.number(),
+      proposer_slashings: z.coerce.number(),
+      attester_slashings: z.coerce.number(),
+    })
+    .optional(),
   timestamp: z.string(),
 });
 
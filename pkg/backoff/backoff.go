@@ -14,6 +14,13 @@ type Config struct {
 	MaxDelay     time.Duration
 	Multiplier   float64
 	JitterFactor float64 // 0.2 means +/- 20%
+
+	// Rand returns jitter samples in [0, 1); nil uses math/rand. Tests set a fixed value to assert
+	// exact delays.
+	Rand func() float64
+	// Sleep waits d or until ctx is done, returning false if ctx ended first; nil uses a timer. Tests
+	// set it to record delays without waiting.
+	Sleep func(ctx context.Context, d time.Duration) bool
 }
 
 // DefaultConfig returns a default backoff configuration.
@@ -66,7 +73,11 @@ func (b *Backoff) NextDelay() time.Duration {
 	delay := float64(b.cfg.InitialDelay) * math.Pow(b.cfg.Multiplier, float64(b.attempts))
 
 	// Apply jitter (+/- jitterFactor)
-	jitter := 1.0 + (rand.Float64()*2-1)*b.cfg.JitterFactor
+	random := rand.Float64
+	if b.cfg.Rand != nil {
+		random = b.cfg.Rand
+	}
+	jitter := 1.0 + (random()*2-1)*b.cfg.JitterFactor
 	delay *= jitter
 
 	// Cap at max delay
@@ -78,10 +89,13 @@ func (b *Backoff) NextDelay() time.Duration {
 	return time.Duration(delay)
 }
 
-// Wait waits for the next backoff delay or until context is cancelled.
+// Wait waits for the next backoff delay (via Config.Sleep when set) or until context is cancelled.
 // Returns true if the wait completed, false if context was cancelled.
 func (b *Backoff) Wait(ctx context.Context) bool {
 	delay := b.NextDelay()
+	if b.cfg.Sleep != nil {
+		return b.cfg.Sleep(ctx, delay)
+	}
 	timer := time.NewTimer(delay)
 	defer timer.Stop()
 
@@ -118,7 +132,12 @@ func IsRetryable(err error) bool {
 // Retry executes the given function with exponential backoff.
 // It retries on RetryableError until maxRetries is reached or context is cancelled.
 func Retry(ctx context.Context, maxRetries int, fn func() error) error {
-	b := NewDefault()
+	return RetryWith(ctx, DefaultConfig(), maxRetries, fn)
+}
+
+// RetryWith is Retry with the backoff configured by cfg.
+func RetryWith(ctx context.Context, cfg Config, maxRetries int, fn func() error) error {
+	b := New(cfg)
 
 	var lastErr error
 	for i := 0; i <= maxRetries; i++ {
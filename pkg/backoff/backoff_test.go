@@ -0,0 +1,68 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a Config.Sleep that records delays instead of waiting.
+type recorder struct {
+	delays []time.Duration
+}
+
+func (r *recorder) sleep(ctx context.Context, d time.Duration) bool {
+	r.delays = append(r.delays, d)
+	return ctx.Err() == nil
+}
+
+func TestBackoff_deterministicDelays(t *testing.T) {
+	var rec recorder
+	cfg := DefaultConfig()
+	cfg.Rand = func() float64 { return 1 } // upper jitter bound: +20%
+	cfg.Sleep = rec.sleep
+	b := New(cfg)
+
+	for i := 0; i < 4; i++ {
+		require.True(t, b.Wait(context.Background()))
+	}
+	require.Equal(t, []time.Duration{
+		100 * time.Millisecond, // first delay is never jittered
+		240 * time.Millisecond,
+		480 * time.Millisecond,
+		960 * time.Millisecond,
+	}, rec.delays)
+
+	cfg.Rand = func() float64 { return 0.5 } // no jitter
+	cfg.MaxDelay = 300 * time.Millisecond
+	b = New(cfg)
+	b.NextDelay()
+	b.NextDelay()
+	require.Equal(t, 300*time.Millisecond, b.NextDelay(), "capped at MaxDelay")
+}
+
+func TestRetryWith(t *testing.T) {
+	var rec recorder
+	cfg := DefaultConfig()
+	cfg.Rand = func() float64 { return 0.5 }
+	cfg.Sleep = rec.sleep
+
+	calls := 0
+	err := RetryWith(context.Background(), cfg, 3, func() error {
+		calls++
+		if calls < 3 {
+			return &RetryableError{StatusCode: 503, Message: "unavailable"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, rec.delays)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = RetryWith(ctx, cfg, 3, func() error { return &RetryableError{Message: "again"} })
+	require.ErrorIs(t, err, context.Canceled)
+}
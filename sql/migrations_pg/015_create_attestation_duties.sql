@@ -0,0 +1,17 @@
+-- Attester duties for watched validators, including committee sizing (committees_at_slot, committee_length).
+CREATE TABLE IF NOT EXISTS attestation_duties (
+    validator_index     BIGINT      NOT NULL,
+    epoch               BIGINT      NOT NULL,
+    slot                BIGINT      NOT NULL,
+    committee_index     BIGINT      NOT NULL,
+    committee_position  BIGINT      NOT NULL,
+    committee_length    BIGINT      NOT NULL,
+    committees_at_slot  BIGINT      NOT NULL,
+    dependent_root      TEXT        NOT NULL DEFAULT '',
+    indexed_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (validator_index, epoch),
+    CHECK (committee_position < committee_length)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attestation_duties_slot
+    ON attestation_duties (slot DESC);
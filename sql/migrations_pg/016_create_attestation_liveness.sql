@@ -0,0 +1,20 @@
+-- Provisional attestation inclusion (block scan shortly after the duty slot), reconciled against
+-- validator_epoch_records once the epoch's rewards are indexed.
+CREATE TABLE IF NOT EXISTS attestation_liveness (
+    validator_index  BIGINT      NOT NULL,
+    epoch            BIGINT      NOT NULL,
+    slot             BIGINT      NOT NULL,
+    included         BOOLEAN     NOT NULL,
+    inclusion_slot   BIGINT,
+    checked_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reward_included  BOOLEAN,
+    reconciled_at    TIMESTAMPTZ,
+    PRIMARY KEY (validator_index, epoch)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attestation_liveness_slot
+    ON attestation_liveness (slot DESC);
+
+CREATE INDEX IF NOT EXISTS idx_attestation_liveness_unreconciled
+    ON attestation_liveness (epoch)
+    WHERE reconciled_at IS NULL;
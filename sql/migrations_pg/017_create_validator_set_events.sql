@@ -0,0 +1,11 @@
+-- Audit trail of watched validator set changes (config at startup or SIGHUP reload).
+CREATE TABLE IF NOT EXISTS validator_set_events (
+    id               BIGSERIAL   PRIMARY KEY,
+    validator_index  BIGINT      NOT NULL,
+    event            TEXT        NOT NULL,
+    source           TEXT        NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_validator_set_events_validator
+    ON validator_set_events (validator_index, id DESC);
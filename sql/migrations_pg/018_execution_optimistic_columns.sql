@@ -0,0 +1,9 @@
+-- Rows built from execution-optimistic beacon responses are provisional until re-indexed.
+ALTER TABLE validator_epoch_records
+    ADD COLUMN IF NOT EXISTS execution_optimistic BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE blocks
+    ADD COLUMN IF NOT EXISTS execution_optimistic BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN validator_epoch_records.execution_optimistic IS 'Validator or rewards response was execution_optimistic; epoch is re-indexed once verified.';
+COMMENT ON COLUMN blocks.execution_optimistic IS 'Header or rewards response was execution_optimistic; data may be reorged out.';
@@ -0,0 +1,9 @@
+-- Chain time of the row's slot (genesis + slot × slot duration), alongside ingestion time.
+ALTER TABLE validator_epoch_records
+    ADD COLUMN IF NOT EXISTS slot_time TIMESTAMPTZ;
+
+ALTER TABLE attestation_duties
+    ADD COLUMN IF NOT EXISTS slot_time TIMESTAMPTZ;
+
+COMMENT ON COLUMN validator_epoch_records.slot_time IS 'Wall-clock start of epoch_start_slot; indexed_at - slot_time is ingestion latency.';
+COMMENT ON COLUMN attestation_duties.slot_time IS 'Wall-clock start of the duty slot.';
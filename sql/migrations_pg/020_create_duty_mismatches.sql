@@ -0,0 +1,17 @@
+-- Attester duties whose stored committee index/position disagrees with the slot's committees
+-- once the attestation was included (pipeline bug or a reorg that changed the shuffling).
+CREATE TABLE IF NOT EXISTS duty_mismatches (
+    validator_index          BIGINT      NOT NULL,
+    epoch                    BIGINT      NOT NULL,
+    slot                     BIGINT      NOT NULL,
+    reason                   TEXT        NOT NULL,
+    expected_committee_index BIGINT      NOT NULL,
+    expected_position        BIGINT      NOT NULL,
+    actual_committee_index   BIGINT,
+    actual_position          BIGINT,
+    detected_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (validator_index, epoch)
+);
+
+CREATE INDEX IF NOT EXISTS idx_duty_mismatches_slot
+    ON duty_mismatches (slot DESC);
@@ -0,0 +1,11 @@
+-- Beacon node liveness verdicts (POST /eth/v1/validator/liveness) for watched validators.
+CREATE TABLE IF NOT EXISTS validator_liveness (
+    validator_index  BIGINT      NOT NULL,
+    epoch            BIGINT      NOT NULL,
+    is_live          BOOLEAN     NOT NULL,
+    checked_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (validator_index, epoch)
+);
+
+CREATE INDEX IF NOT EXISTS idx_validator_liveness_epoch
+    ON validator_liveness (epoch DESC);
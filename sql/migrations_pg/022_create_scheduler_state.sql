@@ -0,0 +1,6 @@
+-- Realtime scheduler cursors (last processed slot/epoch per kind) restored on startup.
+CREATE TABLE IF NOT EXISTS scheduler_state (
+    kind        TEXT        PRIMARY KEY,
+    position    BIGINT      NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+-- Proposer reward breakdown from GET /eth/v1/beacon/rewards/blocks/{block_id} (gwei).
+-- NULL for rows indexed before this migration.
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS reward_attestations BIGINT;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS reward_sync_aggregate BIGINT;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS reward_proposer_slashings BIGINT;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS reward_attester_slashings BIGINT;
@@ -0,0 +1,18 @@
+-- Raw reward and validator status responses (gzip-compressed JSON) kept for audit when
+-- raw_responses.enabled is set. Rows are deleted after raw_responses.ttl_days.
+CREATE TABLE IF NOT EXISTS raw_responses (
+    id          BIGSERIAL   PRIMARY KEY,
+    endpoint    TEXT        NOT NULL,
+    path        TEXT        NOT NULL,
+    epoch       BIGINT,
+    fetched_at  TIMESTAMPTZ NOT NULL,
+    request     BYTEA,
+    body        BYTEA       NOT NULL,
+    body_size   INTEGER     NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_raw_responses_endpoint_epoch
+    ON raw_responses (endpoint, epoch, fetched_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_raw_responses_fetched_at
+    ON raw_responses (fetched_at);
@@ -0,0 +1,17 @@
+-- Block proposal duties of watched validators and their outcome: the proposer reward when the block is
+-- canonical, or a missed proposal. Rows stay provisional until checked again after the epoch finalized.
+CREATE TABLE IF NOT EXISTS proposer_duties (
+    slot             BIGINT      PRIMARY KEY,
+    validator_index  BIGINT      NOT NULL,
+    epoch            BIGINT      NOT NULL,
+    dependent_root   TEXT        NOT NULL,
+    status           TEXT        NOT NULL,
+    block_root       TEXT,
+    reward_gwei      BIGINT,
+    finalized        BOOLEAN     NOT NULL DEFAULT FALSE,
+    checked_at       TIMESTAMPTZ,
+    indexed_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_proposer_duties_validator_slot
+    ON proposer_duties (validator_index, slot DESC);
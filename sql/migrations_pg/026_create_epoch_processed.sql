@@ -0,0 +1,10 @@
+-- Markers for rows already applied to a derived aggregate (e.g. metrics counters), so reprocessing an epoch
+-- (backfill overlapping realtime, replay, retried jobs) refreshes raw rows without counting them again.
+CREATE TABLE IF NOT EXISTS epoch_processed (
+    aggregate        TEXT        NOT NULL,
+    epoch            BIGINT      NOT NULL,
+    validator_index  BIGINT      NOT NULL,
+    slot             BIGINT      NOT NULL,
+    processed_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (aggregate, epoch, validator_index, slot)
+);
@@ -0,0 +1,6 @@
+-- Activation, exit and withdrawable epochs of each record. NULL means not scheduled yet (the Beacon API's
+-- FAR_FUTURE_EPOCH, 2^64-1), so pending activations and exits do not show up as a huge epoch.
+ALTER TABLE validator_epoch_records
+    ADD COLUMN IF NOT EXISTS activation_epoch BIGINT,
+    ADD COLUMN IF NOT EXISTS exit_epoch BIGINT,
+    ADD COLUMN IF NOT EXISTS withdrawable_epoch BIGINT;